@@ -0,0 +1,54 @@
+package audit
+
+const (
+	ActionLogin         = "auth.login"
+	ActionLoginFailed   = "auth.login_failed"
+	ActionMFAFailed     = "auth.mfa_failed"
+	ActionMFAEnabled    = "auth.mfa_enabled"
+	ActionMFADisabled   = "auth.mfa_disabled"
+	ActionPasswordReset = "auth.password_reset"
+	ActionLockoutClear  = "auth.lockout_cleared"
+
+	ActionImpersonationStarted = "auth.impersonation_started"
+
+	ActionUserUpdated         = "user.updated"
+	ActionUserPasswordChanged = "user.password_changed"
+	ActionUserRoleChanged     = "user.role_changed"
+
+	ActionAPIKeyCreated = "api_key.created"
+	ActionAPIKeyRevoked = "api_key.revoked"
+
+	ActionLoanCheckedOut = "loan.checked_out"
+	ActionLoanReturned   = "loan.returned"
+
+	ActionGameTagsChanged = "game.tags_changed"
+
+	ActionTournamentTeamRegistered = "tournament.team_registered"
+	ActionTournamentTeamWithdrawn  = "tournament.team_withdrawn"
+
+	ActionRatingsRecalculated = "player_rating.recalculated"
+
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+const (
+	ResourceUser         = "user"
+	ResourceAPIKey       = "api_key"
+	ResourceGame         = "game"
+	ResourceTournament   = "tournament"
+	ResourceTeam         = "team"
+	ResourceNews         = "news"
+	ResourceGameReview   = "game_review"
+	ResourceGameCopy     = "game_copy"
+	ResourceLoan         = "loan"
+	ResourceTag          = "tag"
+	ResourcePlay         = "play"
+	ResourcePlayerRating = "player_rating"
+)
+
+// ResourceAction builds actions such as "game.created" for plain CRUD.
+func ResourceAction(resourceType, verb string) string {
+	return resourceType + "." + verb
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDiff_ReportsChangedFieldsOnly(t *testing.T) {
+	// Given: A game before and after an update
+	before := models.Game{Model: gorm.Model{ID: 1}, Name: "Catan", MinPlayers: 3}
+	after := before
+	after.MinPlayers = 2
+
+	// When: Diffing the two
+	changes := Diff(before, after)
+
+	// Then: Only the changed field should be reported
+	assert.Equal(t, map[string]Change{"MinPlayers": {Before: float64(3), After: float64(2)}}, changes)
+}
+
+func TestDiff_RedactsSensitiveFields(t *testing.T) {
+	// Given: Snapshots where a secret changed
+	before := map[string]interface{}{"KeyHash": "old-hash", "Name": "bot"}
+	after := map[string]interface{}{"KeyHash": "new-hash", "Name": "bot"}
+
+	// When: Diffing the two
+	changes := Diff(before, after)
+
+	// Then: The change should be recorded without the values
+	assert.Equal(t, map[string]Change{"KeyHash": {Before: redacted, After: redacted}}, changes)
+}
+
+func TestDiff_Creation(t *testing.T) {
+	// When: Diffing a creation
+	changes := Diff(nil, map[string]interface{}{"Name": "Catan"})
+
+	// Then: The new value should be reported without a previous one
+	assert.Equal(t, map[string]Change{"Name": {After: "Catan"}}, changes)
+}
+
+func TestRecorder_Record_CapturesRequestContext(t *testing.T) {
+	// Given: A request authenticated with an API key
+	store := NewMemoryStore()
+	recorder := NewRecorder(store)
+	app := fiber.New()
+	app.Post("/games/:id", func(c *fiber.Ctx) error {
+		c.Locals("user", &models.User{Model: gorm.Model{ID: 4}, Email: "owner@example.com"})
+		c.Locals("apiKey", &models.APIKey{Model: gorm.Model{ID: 11}})
+		recorder.RecordChange(c, ResourceGame, ActionDeleted, 8, map[string]interface{}{"Name": "Catan"}, nil)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("POST", "/games/8", nil)
+	req.Header.Set("User-Agent", "scoreboard/1.0")
+
+	// When: The handler records a change
+	_, err := app.Test(req)
+
+	// Then: Actor, client and key should be stored with the event
+	assert.NoError(t, err)
+	events := store.Events()
+	assert.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "game.deleted", event.Action)
+	assert.Equal(t, "8", event.ResourceID)
+	assert.Equal(t, uint(4), *event.ActorID)
+	assert.Equal(t, "owner@example.com", event.ActorEmail)
+	assert.Equal(t, "scoreboard/1.0", event.UserAgent)
+	assert.NotEmpty(t, event.IP)
+	assert.JSONEq(t, `{"api_key_id":11}`, event.Metadata)
+	assert.JSONEq(t, `{"Name":{"before":"Catan"}}`, event.Changes)
+}
+
+func TestRecorder_Record_ExplicitActor(t *testing.T) {
+	// Given: An unauthenticated request on behalf of a known user
+	store := NewMemoryStore()
+	recorder := NewRecorder(store)
+	app := fiber.New()
+	app.Post("/login", func(c *fiber.Ctx) error {
+		recorder.Record(c, Event{Action: ActionLogin, Actor: &models.User{Model: gorm.Model{ID: 2}, Email: "ada@example.com"}})
+		return nil
+	})
+
+	// When: Recording the login
+	app.Test(httptest.NewRequest("POST", "/login", nil))
+
+	// Then: The given actor should be attributed
+	events := store.Events()
+	assert.Equal(t, []string{ActionLogin}, store.Actions())
+	assert.Equal(t, uint(2), *events[0].ActorID)
+	assert.Empty(t, events[0].Changes)
+}
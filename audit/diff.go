@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+const redacted = "[redacted]"
+
+// Fields that must never appear in the audit trail, only the fact that
+// they changed.
+var sensitiveFields = map[string]bool{
+	"Password":   true,
+	"TOTPSecret": true,
+	"KeyHash":    true,
+	"TokenHash":  true,
+	"CodeHash":   true,
+}
+
+// Bookkeeping fields that change on every write and say nothing about
+// what the actor did.
+var ignoredFields = map[string]bool{
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"DeletedAt": true,
+}
+
+type Change struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Snapshot captures the top-level scalar fields of a model so it can be
+// compared after the model has been modified in place. Associations are
+// left out.
+func Snapshot(value interface{}) map[string]interface{} {
+	if value == nil {
+		return nil
+	}
+	if snapshot, ok := value.(map[string]interface{}); ok {
+		return snapshot
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	snapshot := make(map[string]interface{}, len(fields))
+	for name, field := range fields {
+		if ignoredFields[name] {
+			continue
+		}
+		switch field.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		snapshot[name] = field
+	}
+	return snapshot
+}
+
+// Diff returns the fields that differ between before and after. Either
+// side may be nil for creations and deletions.
+func Diff(before, after interface{}) map[string]Change {
+	beforeFields := Snapshot(before)
+	afterFields := Snapshot(after)
+
+	changes := map[string]Change{}
+	for name, value := range beforeFields {
+		if other, ok := afterFields[name]; !ok || !reflect.DeepEqual(value, other) {
+			changes[name] = redact(name, Change{Before: value, After: afterFields[name]})
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = redact(name, Change{After: value})
+		}
+	}
+	return changes
+}
+
+func redact(name string, change Change) Change {
+	if !sensitiveFields[name] {
+		return change
+	}
+	if change.Before != nil {
+		change.Before = redacted
+	}
+	if change.After != nil {
+		change.After = redacted
+	}
+	return change
+}
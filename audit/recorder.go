@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const maxUserAgentLength = 512
+
+// Store persists audit events. The audit event repository satisfies it.
+type Store interface {
+	Append(ctx context.Context, event *models.AuditEvent) error
+}
+
+type Event struct {
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       interface{}
+	After        interface{}
+	Metadata     map[string]interface{}
+
+	// Actor overrides the authenticated user of the request, e.g. for a
+	// login where no user is attached to the request yet.
+	Actor *models.User
+}
+
+type Recorder struct {
+	store Store
+}
+
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{store: store}
+}
+
+// DefaultRecorder only logs until Configure connects it to the database.
+var DefaultRecorder = NewRecorder(logStore{})
+
+func Configure(db *gorm.DB) {
+	DefaultRecorder = NewRecorder(repositories.NewAuditEventRepository(db))
+}
+
+// Record stores the event together with the actor, IP and user agent of
+// the request. Failures are logged and never fail the request itself.
+func (r *Recorder) Record(c *fiber.Ctx, event Event) {
+	stored := &models.AuditEvent{
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		IP:           c.IP(),
+		UserAgent:    truncate(c.Get(fiber.HeaderUserAgent), maxUserAgentLength),
+	}
+
+	actor := event.Actor
+	if actor == nil {
+		actor, _ = c.Locals("user").(*models.User)
+	}
+	if actor != nil && actor.ID != 0 {
+		id := actor.ID
+		stored.ActorID = &id
+		stored.ActorEmail = actor.Email
+	}
+
+	metadata := event.Metadata
+	if key, ok := c.Locals("apiKey").(*models.APIKey); ok && key != nil {
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		metadata["api_key_id"] = key.ID
+	}
+	if impersonatorID, ok := c.Locals("impersonatorID").(uint); ok {
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		metadata["impersonator_id"] = impersonatorID
+	}
+	if len(metadata) > 0 {
+		stored.Metadata = marshal(metadata)
+	}
+
+	if event.Before != nil || event.After != nil {
+		if changes := Diff(event.Before, event.After); len(changes) > 0 {
+			stored.Changes = marshal(changes)
+		}
+	}
+
+	if err := r.store.Append(c.Context(), stored); err != nil {
+		log.Printf("Failed to record audit event %s: %v", event.Action, err)
+	}
+}
+
+// ID formats a model ID for Event.ResourceID.
+func ID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func marshal(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
+
+// RecordChange records the creation, update or deletion of a resource.
+// Pass nil as before for creations and as after for deletions.
+func (r *Recorder) RecordChange(c *fiber.Ctx, resourceType, verb string, id uint, before, after interface{}) {
+	r.Record(c, Event{
+		Action:       ResourceAction(resourceType, verb),
+		ResourceType: resourceType,
+		ResourceID:   ID(id),
+		Before:       before,
+		After:        after,
+	})
+}
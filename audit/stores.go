@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+type logStore struct{}
+
+func (logStore) Append(ctx context.Context, event *models.AuditEvent) error {
+	actor := "anonymous"
+	if event.ActorID != nil {
+		actor = "user " + ID(*event.ActorID)
+	}
+	log.Printf("Audit: %s %s/%s by %s from %s", event.Action, event.ResourceType, event.ResourceID, actor, event.IP)
+	return nil
+}
+
+// MemoryStore keeps events in memory for tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	events []models.AuditEvent
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, event *models.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func (s *MemoryStore) Events() []models.AuditEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.AuditEvent(nil), s.events...)
+}
+
+// Actions lists the recorded actions in order.
+func (s *MemoryStore) Actions() []string {
+	events := s.Events()
+	actions := make([]string, len(events))
+	for i, event := range events {
+		actions[i] = event.Action
+	}
+	return actions
+}
@@ -0,0 +1,80 @@
+package bgg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+// Complexity maps BGG's average weight (1 light to 5 heavy) onto the club's
+// scale. Documents fetched without stats=1 have no weight; the playtime and
+// minimum age then stand in for it. Zero means unknown for every argument.
+func Complexity(weight float64, playtimeMinutes, minAge int) models.GameComplexity {
+	switch {
+	case weight >= 3.5:
+		return models.ComplexityExpert
+	case weight >= 2.6:
+		return models.ComplexityHard
+	case weight >= 1.8:
+		return models.ComplexityMedium
+	case weight > 0:
+		return models.ComplexityEasy
+	}
+
+	switch {
+	case playtimeMinutes >= 180 || minAge >= 16:
+		return models.ComplexityExpert
+	case playtimeMinutes >= 90 || minAge >= 13:
+		return models.ComplexityHard
+	case playtimeMinutes > 0 && playtimeMinutes <= 30 && minAge > 0 && minAge <= 8:
+		return models.ComplexityEasy
+	}
+	return models.ComplexityMedium
+}
+
+// categoryNames maps BGG category, mechanic and subdomain names onto club
+// categories. It is checked in order, so a cooperative card game is
+// Cooperative and a party card game is Party. Mechanics such as "Dice
+// Rolling" are deliberately absent: too many strategy games use them.
+var categoryNames = []struct {
+	category models.GameCategory
+	names    []string
+}{
+	{models.CategoryCooperative, []string{"cooperative game"}},
+	{models.CategoryParty, []string{"party game", "party games"}},
+	{models.CategoryCard, []string{"card game", "trick-taking"}},
+	{models.CategoryDice, []string{"dice"}},
+	{models.CategoryFamily, []string{"family games", "children's game"}},
+}
+
+// Category picks a club category from an item's BGG categories, mechanics
+// and subdomains, defaulting to Strategy.
+func Category(values []string) models.GameCategory {
+	for _, candidate := range categoryNames {
+		for _, value := range values {
+			for _, name := range candidate.names {
+				if strings.EqualFold(strings.TrimSpace(value), name) {
+					return candidate.category
+				}
+			}
+		}
+	}
+	return models.CategoryStrategy
+}
+
+func atoi(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func atof(value string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return f
+}
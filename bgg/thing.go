@@ -0,0 +1,205 @@
+// Package bgg reads BoardGameGeek XML API2 "thing" documents, as returned by
+// https://boardgamegeek.com/xmlapi2/thing?id=13&stats=1, and turns their
+// board games into catalog entries.
+package bgg
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+// MaxItems bounds how many games one document may import.
+const MaxItems = 500
+
+const typeBoardGame = "boardgame"
+
+var (
+	ErrInvalidDocument = errors.New("not a BoardGameGeek thing document")
+	ErrTooManyItems    = fmt.Errorf("a document may list at most %d games", MaxItems)
+)
+
+type valueAttr struct {
+	Value string `xml:"value,attr"`
+}
+
+type name struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type link struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type item struct {
+	Type          string    `xml:"type,attr"`
+	ID            int       `xml:"id,attr"`
+	Names         []name    `xml:"name"`
+	Description   string    `xml:"description"`
+	YearPublished valueAttr `xml:"yearpublished"`
+	MinPlayers    valueAttr `xml:"minplayers"`
+	MaxPlayers    valueAttr `xml:"maxplayers"`
+	PlayingTime   valueAttr `xml:"playingtime"`
+	MaxPlayTime   valueAttr `xml:"maxplaytime"`
+	MinAge        valueAttr `xml:"minage"`
+	Links         []link    `xml:"link"`
+	AverageWeight valueAttr `xml:"statistics>ratings>averageweight"`
+}
+
+type document struct {
+	XMLName xml.Name `xml:"items"`
+	Items   []item   `xml:"item"`
+}
+
+// Thing is one item of a document. Game is nil when the item is not a board
+// game (an expansion or accessory, for example) or has no name; Skipped
+// then says why.
+type Thing struct {
+	ID      int
+	Type    string
+	Name    string
+	Game    *models.Game
+	Skipped string
+}
+
+// Parse reads a thing document. Items that cannot become a game are
+// returned with Skipped set rather than failing the whole document.
+func Parse(r io.Reader) ([]Thing, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	if len(doc.Items) > MaxItems {
+		return nil, ErrTooManyItems
+	}
+
+	things := make([]Thing, len(doc.Items))
+	for i, it := range doc.Items {
+		thing := Thing{ID: it.ID, Type: it.Type, Name: it.primaryName()}
+		switch {
+		case it.Type != typeBoardGame:
+			thing.Skipped = fmt.Sprintf("%s items are not imported", it.Type)
+		case thing.Name == "":
+			thing.Skipped = "item has no name"
+		default:
+			thing.Game = it.toGame(thing.Name)
+		}
+		things[i] = thing
+	}
+	return things, nil
+}
+
+func (it item) primaryName() string {
+	for _, n := range it.Names {
+		if n.Type == "primary" {
+			return strings.TrimSpace(n.Value)
+		}
+	}
+	if len(it.Names) > 0 {
+		return strings.TrimSpace(it.Names[0].Value)
+	}
+	return ""
+}
+
+func (it item) toGame(name string) *models.Game {
+	builder := models.NewGameBuilder().
+		SetName(name).
+		SetDescription(description(it.Description))
+
+	minPlayers, maxPlayers := atoi(it.MinPlayers.Value), atoi(it.MaxPlayers.Value)
+	if minPlayers > 0 && maxPlayers >= minPlayers {
+		builder.SetPlayerRange(minPlayers, maxPlayers)
+	} else if minPlayers > 0 {
+		builder.SetPlayerRange(minPlayers, minPlayers)
+	}
+
+	playtime := atoi(it.PlayingTime.Value)
+	if playtime == 0 {
+		playtime = atoi(it.MaxPlayTime.Value)
+	}
+	if playtime > 0 {
+		builder.SetPlaytimeMinutes(playtime)
+	}
+
+	minAge := atoi(it.MinAge.Value)
+	if minAge > 0 {
+		builder.SetMinAge(minAge)
+	}
+
+	if year := atoi(it.YearPublished.Value); year > 0 {
+		builder.SetYearPublished(year)
+	}
+	if publisher := it.firstLink("boardgamepublisher"); publisher != "" {
+		builder.SetPublisher(publisher)
+	}
+
+	return builder.
+		SetComplexity(Complexity(atof(it.AverageWeight.Value), playtime, minAge)).
+		SetCategory(Category(it.linkValues())).
+		Build()
+}
+
+func (it item) firstLink(linkType string) string {
+	for _, l := range it.Links {
+		if l.Type == linkType {
+			return strings.TrimSpace(l.Value)
+		}
+	}
+	return ""
+}
+
+func (it item) linkValues() []string {
+	values := make([]string, 0, len(it.Links))
+	for _, l := range it.Links {
+		switch l.Type {
+		case "boardgamecategory", "boardgamemechanic", "boardgamesubdomain":
+			values = append(values, l.Value)
+		}
+	}
+	return values
+}
+
+// description turns BGG's escaped text, which encodes line breaks as
+// "&#10;", back into plain text.
+func description(raw string) string {
+	text := html.UnescapeString(raw)
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(text)
+}
+
+// Merge applies an imported game onto an existing catalog row. The row keeps
+// its ID, its spelling of the name, its base game and its rating, which comes
+// from member reviews rather than from BGG. Text fields BGG left empty are not cleared.
+func Merge(existing, imported *models.Game) *models.Game {
+	builder := models.NewGameBuilder().
+		SetModel(existing.Model).
+		SetName(existing.Name).
+		SetDescription(imported.Description).
+		SetPlayerRange(imported.MinPlayers, imported.MaxPlayers).
+		SetPlaytimeMinutes(imported.PlaytimeMinutes).
+		SetMinAge(imported.MinAge).
+		SetComplexity(imported.Complexity).
+		SetCategory(imported.Category).
+		SetPublisher(imported.Publisher).
+		SetYearPublished(imported.YearPublished).
+		SetRating(existing.Rating).
+		SetRatingCount(existing.RatingCount).
+		SetBaseGameID(existing.BaseGameID)
+
+	if imported.Description == "" {
+		builder.SetDescription(existing.Description)
+	}
+	if imported.Publisher == "" {
+		builder.SetPublisher(existing.Publisher)
+	}
+	return builder.Build()
+}
@@ -0,0 +1,136 @@
+package bgg
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// Given: A thing document with two games and an expansion
+	file, err := os.Open("testdata/things.xml")
+	assert.NoError(t, err)
+	defer file.Close()
+
+	// When: Parsing it
+	things, err := Parse(file)
+
+	// Then: The games are mapped and the expansion is skipped
+	assert.NoError(t, err)
+	assert.Len(t, things, 3)
+
+	catan := things[0].Game
+	assert.Equal(t, 13, things[0].ID)
+	assert.Equal(t, "CATAN", catan.Name)
+	assert.Equal(t, "In CATAN, players try to be the dominant force on the island of Catan.\n\nPlayers build roads, settlements & cities.", catan.Description)
+	assert.Equal(t, 3, catan.MinPlayers)
+	assert.Equal(t, 4, catan.MaxPlayers)
+	assert.Equal(t, 4, catan.NumberOfPlayers)
+	assert.Equal(t, 120, catan.PlaytimeMinutes)
+	assert.Equal(t, 10, catan.MinAge)
+	assert.Equal(t, 1995, catan.YearPublished)
+	assert.Equal(t, "KOSMOS", catan.Publisher)
+	assert.Equal(t, models.ComplexityMedium, catan.Complexity)
+	assert.Equal(t, models.CategoryStrategy, catan.Category)
+	assert.Zero(t, catan.Rating)
+
+	assert.Nil(t, things[1].Game)
+	assert.Equal(t, "CATAN: Seafarers", things[1].Name)
+	assert.Contains(t, things[1].Skipped, "boardgameexpansion")
+
+	codenames := things[2].Game
+	assert.Equal(t, models.CategoryParty, codenames.Category)
+	assert.Equal(t, models.ComplexityHard, codenames.Complexity)
+}
+
+func TestParse_RejectsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     error
+	}{
+		{name: "not xml", document: "name,players\nCatan,4", want: ErrInvalidDocument},
+		{name: "other root", document: "<games><game/></games>", want: ErrInvalidDocument},
+		{name: "too many items", document: "<items>" + strings.Repeat(`<item type="boardgame"/>`, MaxItems+1) + "</items>", want: ErrTooManyItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Parsing the document
+			things, err := Parse(strings.NewReader(tt.document))
+
+			// Then: It should be rejected
+			assert.ErrorIs(t, err, tt.want)
+			assert.Nil(t, things)
+		})
+	}
+}
+
+func TestComplexity(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   float64
+		playtime int
+		minAge   int
+		want     models.GameComplexity
+	}{
+		{name: "light weight", weight: 1.2, playtime: 200, minAge: 18, want: models.ComplexityEasy},
+		{name: "medium weight", weight: 2.0, want: models.ComplexityMedium},
+		{name: "heavy weight", weight: 3.0, want: models.ComplexityHard},
+		{name: "very heavy weight", weight: 4.2, want: models.ComplexityExpert},
+		{name: "long game without weight", playtime: 240, minAge: 12, want: models.ComplexityExpert},
+		{name: "teen game without weight", playtime: 60, minAge: 14, want: models.ComplexityHard},
+		{name: "short kids game without weight", playtime: 20, minAge: 6, want: models.ComplexityEasy},
+		{name: "nothing known", want: models.ComplexityMedium},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Complexity(tt.weight, tt.playtime, tt.minAge))
+		})
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   models.GameCategory
+	}{
+		{name: "cooperative wins over card", values: []string{"Card Game", "Cooperative Game"}, want: models.CategoryCooperative},
+		{name: "semi-cooperative is not cooperative", values: []string{"Semi-Cooperative Game"}, want: models.CategoryStrategy},
+		{name: "dice rolling mechanic alone", values: []string{"Dice Rolling"}, want: models.CategoryStrategy},
+		{name: "dice category", values: []string{"Dice"}, want: models.CategoryDice},
+		{name: "family subdomain", values: []string{"Family Games", "Animals"}, want: models.CategoryFamily},
+		{name: "nothing matches", values: nil, want: models.CategoryStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Category(tt.values))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	// Given: A rated catalog game and a fresh import without a publisher
+	existing := models.NewGameBuilder().SetID(7).SetName("Catan").SetPublisher("Kosmos").
+		SetDescription("Trade and build").SetRating(8.5).SetRatingCount(4).Build()
+	imported := models.NewGameBuilder().SetName("CATAN").SetPlayerRange(3, 4).SetPlaytimeMinutes(90).Build()
+
+	// When: Merging them
+	merged := Merge(existing, imported)
+
+	// Then: The import's values win but identity, rating and missing text are kept
+	assert.Equal(t, uint(7), merged.ID)
+	assert.Equal(t, "Catan", merged.Name)
+	assert.Equal(t, 3, merged.MinPlayers)
+	assert.Equal(t, 90, merged.PlaytimeMinutes)
+	assert.Equal(t, "Kosmos", merged.Publisher)
+	assert.Equal(t, "Trade and build", merged.Description)
+	assert.Equal(t, 8.5, merged.Rating)
+	assert.Equal(t, 4, merged.RatingCount)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,8 +19,49 @@ const (
 	EnvRedisPassword = "REDIS_PASSWORD"
 	EnvRedisDB       = "REDIS_DB"
 	EnvCacheTTL      = "CACHE_TTL_SECONDS"
+
+	EnvPasswordHashAlgorithm = "PASSWORD_HASH_ALGORITHM"
+	EnvBcryptCost            = "BCRYPT_COST"
+	EnvArgon2MemoryKiB       = "ARGON2_MEMORY_KIB"
+	EnvArgon2Iterations      = "ARGON2_ITERATIONS"
+	EnvArgon2Parallelism     = "ARGON2_PARALLELISM"
+	EnvAccessTokenTTL        = "ACCESS_TOKEN_TTL_MINUTES"
+	EnvRefreshTokenTTL       = "REFRESH_TOKEN_TTL_HOURS"
+	EnvAdminEmail            = "ADMIN_EMAIL"
+	EnvJWTSecrets            = "JWT_SECRETS"
+	EnvJWTPrivateKeyFiles    = "JWT_PRIVATE_KEY_FILES"
+	EnvJWTPublicKeyFiles     = "JWT_PUBLIC_KEY_FILES"
+	EnvEmailVerificationTTL  = "EMAIL_VERIFICATION_TTL_HOURS"
+	EnvPasswordResetTTL      = "PASSWORD_RESET_TTL_MINUTES"
+	EnvLoginMaxFailures      = "LOGIN_MAX_FAILURES"
+	EnvLoginMaxIPFailures    = "LOGIN_MAX_IP_FAILURES"
+	EnvLoginLockoutBase      = "LOGIN_LOCKOUT_BASE_SECONDS"
+	EnvLoginLockoutMax       = "LOGIN_LOCKOUT_MAX_MINUTES"
+	EnvLibraryMaxLoans       = "LIBRARY_MAX_LOANS"
+	EnvLibraryLoanDays       = "LIBRARY_LOAN_DAYS"
+	EnvRatingAlgorithm       = "RATING_ALGORITHM"
+
+	EnvAppBaseURL   = "APP_BASE_URL"
+	EnvMailFrom     = "MAIL_FROM"
+	EnvSMTPHost     = "SMTP_HOST"
+	EnvSMTPPort     = "SMTP_PORT"
+	EnvSMTPUsername = "SMTP_USERNAME"
+	EnvSMTPPassword = "SMTP_PASSWORD"
+
+	// EnvOIDCProviders lists provider names; each one is configured through
+	// OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET, _REDIRECT_URL and _SCOPES.
+	EnvOIDCProviders = "OIDC_PROVIDERS"
 )
 
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
 type Config struct {
 	DbHost        string
 	DbName        string
@@ -30,6 +72,36 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 	CacheTTL      time.Duration
+
+	PasswordHashAlgorithm string
+	BcryptCost            int
+	Argon2MemoryKiB       int
+	Argon2Iterations      int
+	Argon2Parallelism     int
+	AccessTokenTTL        time.Duration
+	RefreshTokenTTL       time.Duration
+	AdminEmail            string
+	JWTSecrets            []string
+	JWTPrivateKeyFiles    []string
+	JWTPublicKeyFiles     []string
+	EmailVerificationTTL  time.Duration
+	PasswordResetTTL      time.Duration
+	LoginMaxFailures      int
+	LoginMaxIPFailures    int
+	LoginLockoutBase      time.Duration
+	LoginLockoutMax       time.Duration
+	LibraryMaxLoans       int
+	LibraryLoanPeriod     time.Duration
+	RatingAlgorithm       string
+
+	AppBaseURL   string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	OIDCProviders []OIDCProviderConfig
 }
 
 func GetFromEnv() *Config {
@@ -41,11 +113,42 @@ func GetFromEnv() *Config {
 	flag.StringVar(&conf.DbPswd, EnvDbPswd, os.Getenv(EnvDbPswd), "database user password")
 	flag.StringVar(&conf.RedisAddr, EnvRedisAddr, getEnvOrDefault(EnvRedisAddr, "localhost:6379"), "redis address")
 	flag.StringVar(&conf.RedisPassword, EnvRedisPassword, os.Getenv(EnvRedisPassword), "redis password")
+	flag.StringVar(&conf.PasswordHashAlgorithm, EnvPasswordHashAlgorithm, getEnvOrDefault(EnvPasswordHashAlgorithm, "bcrypt"), "password hash algorithm (bcrypt or argon2id)")
+	flag.StringVar(&conf.RatingAlgorithm, EnvRatingAlgorithm, getEnvOrDefault(EnvRatingAlgorithm, "elo"), "player rating algorithm (elo or glicko2)")
+	flag.StringVar(&conf.AdminEmail, EnvAdminEmail, os.Getenv(EnvAdminEmail), "email of the user promoted to admin on startup")
+	flag.StringVar(&conf.AppBaseURL, EnvAppBaseURL, getEnvOrDefault(EnvAppBaseURL, "http://localhost:3000"), "public URL used in links sent by email")
+	flag.StringVar(&conf.MailFrom, EnvMailFrom, getEnvOrDefault(EnvMailFrom, "GameClub <no-reply@gameclub.local>"), "sender address of outgoing email")
+	flag.StringVar(&conf.SMTPHost, EnvSMTPHost, os.Getenv(EnvSMTPHost), "SMTP server host (email is logged when empty)")
+	flag.StringVar(&conf.SMTPUsername, EnvSMTPUsername, os.Getenv(EnvSMTPUsername), "SMTP user name")
+	flag.StringVar(&conf.SMTPPassword, EnvSMTPPassword, os.Getenv(EnvSMTPPassword), "SMTP password")
 	flag.Parse()
 
 	conf.RedisDB = getEnvAsInt(EnvRedisDB, 0)
 	conf.CacheTTL = time.Duration(getEnvAsInt(EnvCacheTTL, 300)) * time.Second
 
+	conf.BcryptCost = getEnvAsInt(EnvBcryptCost, 12)
+	conf.Argon2MemoryKiB = getEnvAsInt(EnvArgon2MemoryKiB, 64*1024)
+	conf.Argon2Iterations = getEnvAsInt(EnvArgon2Iterations, 3)
+	conf.Argon2Parallelism = getEnvAsInt(EnvArgon2Parallelism, 2)
+	conf.AccessTokenTTL = time.Duration(getEnvAsInt(EnvAccessTokenTTL, 15)) * time.Minute
+	conf.RefreshTokenTTL = time.Duration(getEnvAsInt(EnvRefreshTokenTTL, 720)) * time.Hour
+
+	conf.JWTSecrets = getEnvAsList(EnvJWTSecrets)
+	conf.JWTPrivateKeyFiles = getEnvAsList(EnvJWTPrivateKeyFiles)
+	conf.JWTPublicKeyFiles = getEnvAsList(EnvJWTPublicKeyFiles)
+	conf.EmailVerificationTTL = time.Duration(getEnvAsInt(EnvEmailVerificationTTL, 24)) * time.Hour
+	conf.PasswordResetTTL = time.Duration(getEnvAsInt(EnvPasswordResetTTL, 60)) * time.Minute
+	conf.LoginMaxFailures = getEnvAsInt(EnvLoginMaxFailures, 5)
+	conf.LoginMaxIPFailures = getEnvAsInt(EnvLoginMaxIPFailures, 20)
+	conf.LoginLockoutBase = time.Duration(getEnvAsInt(EnvLoginLockoutBase, 30)) * time.Second
+	conf.LoginLockoutMax = time.Duration(getEnvAsInt(EnvLoginLockoutMax, 60)) * time.Minute
+	conf.LibraryMaxLoans = getEnvAsInt(EnvLibraryMaxLoans, 3)
+	conf.LibraryLoanPeriod = time.Duration(getEnvAsInt(EnvLibraryLoanDays, 14)) * 24 * time.Hour
+
+	conf.SMTPPort = getEnvAsInt(EnvSMTPPort, 587)
+
+	conf.OIDCProviders = getOIDCProviders()
+
 	return conf
 }
 
@@ -65,6 +168,32 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range getEnvAsList(EnvOIDCProviders) {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         strings.ToLower(name),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       getEnvAsList(prefix + "SCOPES"),
+		})
+	}
+	return providers
+}
+
 func (cfg *Config) ConnString() string {
 	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		cfg.DbHost,
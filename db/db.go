@@ -5,6 +5,7 @@ import (
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/config"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -29,8 +30,39 @@ func Migrate() {
 		&models.News{},
 		&models.Comment{},
 		&models.FriendRequest{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.PasswordResetToken{},
+		&models.RecoveryCode{},
+		&models.UserIdentity{},
+		&models.APIKey{},
+		&models.AuditEvent{},
+		&models.GameReview{},
+		&models.GameCopy{},
+		&models.Loan{},
+		&models.Tag{},
+		&models.Play{},
+		&models.PlayPlayer{},
+		&models.PlayerRating{},
 	); err != nil {
 		log.Fatalf("Failed to migrate models: %v", err)
 	}
+	if err := repositories.SetupGameSearch(DB); err != nil {
+		log.Fatalf("Failed to set up game search: %v", err)
+	}
 	log.Println("Database migration completed successfully")
 }
+
+func PromoteAdmin(email string) {
+	if email == "" {
+		return
+	}
+	result := DB.Model(&models.User{}).Where("email = ?", email).Update("role", models.RoleAdmin)
+	if result.Error != nil {
+		log.Printf("Failed to promote %s to admin: %v", email, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		log.Printf("Admin user %s not found, skipping promotion", email)
+	}
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventResponse struct {
+	ID           uint            `json:"id"`
+	CreatedAt    time.Time       `json:"created_at"`
+	ActorID      *uint           `json:"actor_id"`
+	ActorEmail   string          `json:"actor_email,omitempty"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type,omitempty"`
+	ResourceID   string          `json:"resource_id,omitempty"`
+	IP           string          `json:"ip"`
+	UserAgent    string          `json:"user_agent"`
+	Changes      json.RawMessage `json:"changes,omitempty"`
+	Metadata     json.RawMessage `json:"metadata,omitempty"`
+}
+
+type AuditEventPageResponse struct {
+	Items    []AuditEventResponse `json:"items"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"page_size"`
+	Total    int64                `json:"total"`
+}
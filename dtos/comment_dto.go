@@ -2,7 +2,6 @@ package dtos
 
 type CreateCommentRequest struct {
 	Content string `json:"content" validate:"required"`
-	UserID  uint   `json:"user_id" validate:"required"`
 	NewsID  uint   `json:"news_id" validate:"required"`
 }
 
@@ -1,7 +1,6 @@
 package dtos
 
 type CreateFriendRequestRequest struct {
-	SenderID   uint `json:"sender_id" validate:"required"`
 	ReceiverID uint `json:"receiver_id" validate:"required"`
 }
 
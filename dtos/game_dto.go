@@ -1,18 +1,20 @@
 package dtos
 
 type CreateGameRequest struct {
-	Name            string  `json:"name" validate:"required"`
-	Description     string  `json:"description"`
-	NumberOfPlayers int     `json:"numberOfPlayers" validate:"required,min=1"`
-	MinPlayers      int     `json:"minPlayers" validate:"min=1"`
-	MaxPlayers      int     `json:"maxPlayers" validate:"min=1"`
-	PlaytimeMinutes int     `json:"playtimeMinutes" validate:"min=1"`
-	MinAge          int     `json:"minAge" validate:"min=3"`
-	Complexity      string  `json:"complexity"`
-	Category        string  `json:"category"`
-	Publisher       string  `json:"publisher"`
-	YearPublished   int     `json:"yearPublished" validate:"min=1900,max=2100"`
-	Rating          float64 `json:"rating" validate:"min=0,max=10"`
+	Name            string `json:"name" validate:"required"`
+	Description     string `json:"description"`
+	NumberOfPlayers int    `json:"numberOfPlayers" validate:"required,min=1"`
+	MinPlayers      int    `json:"minPlayers" validate:"min=1"`
+	MaxPlayers      int    `json:"maxPlayers" validate:"min=1"`
+	PlaytimeMinutes int    `json:"playtimeMinutes" validate:"min=1"`
+	MinAge          int    `json:"minAge" validate:"min=3"`
+	Complexity      string `json:"complexity"`
+	Category        string `json:"category"`
+	Publisher       string `json:"publisher"`
+	YearPublished   int    `json:"yearPublished" validate:"min=1900,max=2100"`
+	// BaseGameID makes the game an expansion. On update, null keeps the
+	// current base game and 0 detaches the expansion.
+	BaseGameID *uint `json:"baseGameId"`
 }
 
 type GameResponse struct {
@@ -29,4 +31,31 @@ type GameResponse struct {
 	Publisher       string  `json:"publisher"`
 	YearPublished   int     `json:"yearPublished"`
 	Rating          float64 `json:"rating"`
+	RatingCount     int     `json:"ratingCount"`
+	BaseGameID      *uint   `json:"baseGameId"`
+
+	Tags       []TagResponse         `json:"tags"`
+	Expansions []GameSummaryResponse `json:"expansions"`
+}
+
+type GameSummaryResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type SetGameTagsRequest struct {
+	TagIDs []uint `json:"tagIds"`
+}
+
+type GamePageResponse struct {
+	Items []GameResponse `json:"items"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
+}
+
+type GameSearchResponse struct {
+	Query          string         `json:"query"`
+	CorrectedQuery string         `json:"correctedQuery,omitempty"`
+	Items          []GameResponse `json:"items"`
 }
@@ -0,0 +1,50 @@
+package dtos
+
+const (
+	GameImportCreate    = "create"
+	GameImportUpdate    = "update"
+	GameImportUnchanged = "unchanged"
+	GameImportSkipped   = "skipped"
+)
+
+type GameImportFieldChange struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type GameImportItem struct {
+	BggID   int                              `json:"bggId"`
+	Name    string                           `json:"name"`
+	Action  string                           `json:"action"`
+	GameID  uint                             `json:"gameId,omitempty"`
+	Changes map[string]GameImportFieldChange `json:"changes,omitempty"`
+	Reason  string                           `json:"reason,omitempty"`
+}
+
+type GameImportResponse struct {
+	DryRun    bool             `json:"dryRun"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Skipped   int              `json:"skipped"`
+	Items     []GameImportItem `json:"items"`
+}
+
+const GameImportFailed = "failed"
+
+type GameBulkImportRow struct {
+	Row    int    `json:"row"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	GameID uint   `json:"gameId,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type GameBulkImportResponse struct {
+	Atomic    bool                `json:"atomic"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Failed    int                 `json:"failed"`
+	Rows      []GameBulkImportRow `json:"rows"`
+}
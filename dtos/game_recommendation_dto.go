@@ -0,0 +1,11 @@
+package dtos
+
+type GameRecommendation struct {
+	Game    GameResponse `json:"game"`
+	Score   float64      `json:"score"`
+	Reasons []string     `json:"reasons"`
+}
+
+type GameRecommendationResponse struct {
+	Items []GameRecommendation `json:"items"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+type GameReviewRequest struct {
+	Score int    `json:"score" validate:"required,min=1,max=10"`
+	Text  string `json:"text"`
+}
+
+type GameReviewResponse struct {
+	ID        uint   `json:"id"`
+	GameID    uint   `json:"game_id"`
+	GameName  string `json:"game_name,omitempty"`
+	UserID    uint   `json:"user_id"`
+	UserName  string `json:"user_name,omitempty"`
+	Score     int    `json:"score"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import "time"
+
+// GameCopyRequest creates or updates a copy. AcquiredAt is a date such as
+// "2024-05-01".
+type GameCopyRequest struct {
+	Barcode    string `json:"barcode" validate:"required"`
+	Condition  string `json:"condition"`
+	Location   string `json:"location"`
+	AcquiredAt string `json:"acquired_at"`
+}
+
+type GameCopyResponse struct {
+	ID          uint          `json:"id"`
+	GameID      uint          `json:"game_id"`
+	GameName    string        `json:"game_name"`
+	Barcode     string        `json:"barcode"`
+	Condition   string        `json:"condition"`
+	Location    string        `json:"location"`
+	AcquiredAt  string        `json:"acquired_at,omitempty"`
+	Available   bool          `json:"available"`
+	CurrentLoan *LoanResponse `json:"current_loan,omitempty"`
+}
+
+// CheckOutRequest lends a copy, given by ID or barcode, to a member. DueAt
+// defaults to the club's loan period.
+type CheckOutRequest struct {
+	CopyID  uint       `json:"copy_id"`
+	Barcode string     `json:"barcode"`
+	UserID  uint       `json:"user_id" validate:"required"`
+	DueAt   *time.Time `json:"due_at"`
+}
+
+type ReturnLoanRequest struct {
+	Condition string `json:"condition"`
+}
+
+type LoanResponse struct {
+	ID           uint       `json:"id"`
+	CopyID       uint       `json:"copy_id"`
+	Barcode      string     `json:"barcode"`
+	GameID       uint       `json:"game_id"`
+	GameName     string     `json:"game_name"`
+	UserID       uint       `json:"user_id"`
+	UserName     string     `json:"user_name,omitempty"`
+	CheckedOutAt time.Time  `json:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	Overdue      bool       `json:"overdue"`
+}
@@ -0,0 +1,72 @@
+package dtos
+
+import "time"
+
+// PlayRequest logs a play. In a cooperative play Won records the table's
+// result and players carry no winner flag; otherwise the flagged players
+// won, or, when none is flagged, those with the highest score.
+type PlayRequest struct {
+	GameID          uint                `json:"game_id" validate:"required"`
+	PlayedAt        *time.Time          `json:"played_at"`
+	DurationMinutes int                 `json:"duration_minutes"`
+	Cooperative     bool                `json:"cooperative"`
+	Won             *bool               `json:"won"`
+	Notes           string              `json:"notes"`
+	Players         []PlayPlayerRequest `json:"players" validate:"required"`
+}
+
+type PlayPlayerRequest struct {
+	UserID uint `json:"user_id" validate:"required"`
+	Score  *int `json:"score"`
+	Winner bool `json:"winner"`
+}
+
+type PlayResponse struct {
+	ID              uint                 `json:"id"`
+	GameID          uint                 `json:"game_id"`
+	GameName        string               `json:"game_name"`
+	PlayedAt        time.Time            `json:"played_at"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	Cooperative     bool                 `json:"cooperative"`
+	Won             *bool                `json:"won,omitempty"`
+	Official        bool                 `json:"official"`
+	Notes           string               `json:"notes"`
+	LoggedByID      uint                 `json:"logged_by_id"`
+	Players         []PlayPlayerResponse `json:"players"`
+}
+
+type PlayPlayerResponse struct {
+	UserID   uint   `json:"user_id"`
+	UserName string `json:"user_name,omitempty"`
+	Score    *int   `json:"score"`
+	Winner   bool   `json:"winner"`
+}
+
+type MostPlayedGameResponse struct {
+	GameID   uint   `json:"game_id"`
+	GameName string `json:"game_name"`
+	Plays    int    `json:"plays"`
+	Minutes  int    `json:"minutes"`
+}
+
+type GameWinRateResponse struct {
+	GameID   uint    `json:"game_id"`
+	GameName string  `json:"game_name"`
+	Plays    int     `json:"plays"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"win_rate"`
+}
+
+// NemesisResponse names the opponent who most often beat the user. Nemesis
+// is null until someone has.
+type NemesisResponse struct {
+	UserID  uint              `json:"user_id"`
+	Nemesis *OpponentResponse `json:"nemesis"`
+}
+
+type OpponentResponse struct {
+	UserID   uint   `json:"user_id"`
+	UserName string `json:"user_name"`
+	Plays    int    `json:"plays"`
+	Losses   int    `json:"losses"`
+}
@@ -0,0 +1,32 @@
+package dtos
+
+type PlayerRatingResponse struct {
+	UserID     uint    `json:"user_id"`
+	UserName   string  `json:"user_name,omitempty"`
+	GameID     uint    `json:"game_id"`
+	GameName   string  `json:"game_name,omitempty"`
+	Global     bool    `json:"global"`
+	Algorithm  string  `json:"algorithm"`
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation,omitempty"`
+	Volatility float64 `json:"volatility,omitempty"`
+	Plays      int     `json:"plays"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank int `json:"rank"`
+	PlayerRatingResponse
+}
+
+type LeaderboardResponse struct {
+	GameID    uint                       `json:"game_id"`
+	GameName  string                     `json:"game_name"`
+	Algorithm string                     `json:"algorithm"`
+	Entries   []LeaderboardEntryResponse `json:"entries"`
+}
+
+type RatingRecalculationResponse struct {
+	Algorithm string `json:"algorithm"`
+	Plays     int    `json:"plays"`
+	Ratings   int    `json:"ratings"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
@@ -10,14 +10,19 @@ type CreateTournamentRequest struct {
 }
 
 type TournamentResponse struct {
-	ID                  uint      `json:"id"`
-	Name                string    `json:"name"`
-	Game                string    `json:"game"`
-	BasePrizePool       float64   `json:"basePrizePool"`
-	CalculatedPrizePool float64   `json:"calculatedPrizePool"`
-	PrizePool           float64   `json:"prizePool"`
-	BonusType           string    `json:"bonusType"`
-	BonusMultiplier     float64   `json:"bonusMultiplier"`
-	StartDate           time.Time `json:"startDate"`
-	Status              string    `json:"status"`
+	ID                  uint           `json:"id"`
+	Name                string         `json:"name"`
+	Game                string         `json:"game"`
+	BasePrizePool       float64        `json:"basePrizePool"`
+	CalculatedPrizePool float64        `json:"calculatedPrizePool"`
+	PrizePool           float64        `json:"prizePool"`
+	BonusType           string         `json:"bonusType"`
+	BonusMultiplier     float64        `json:"bonusMultiplier"`
+	StartDate           time.Time      `json:"startDate"`
+	Status              string         `json:"status"`
+	Teams               []TeamResponse `json:"teams"`
+}
+
+type RegisterTeamRequest struct {
+	TeamID uint `json:"teamId" validate:"required"`
 }
@@ -5,6 +5,12 @@ type UpdateUserRequest struct {
 	LastName  string `json:"last_name"`
 	Email     string `json:"email" validate:"omitempty,email"`
 	Password  string `json:"password" validate:"omitempty,min=6"`
+	// CurrentPassword is required when Password is set.
+	CurrentPassword string `json:"current_password"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
 }
 
 type UserResponse struct {
@@ -12,4 +18,23 @@ type UserResponse struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+type UserExportProfile struct {
+	UserResponse
+	EmailVerified bool   `json:"email_verified"`
+	MFAEnabled    bool   `json:"mfa_enabled"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type UserExportResponse struct {
+	ExportedAt     string                  `json:"exported_at"`
+	Profile        UserExportProfile       `json:"profile"`
+	Teams          []TeamResponse          `json:"teams"`
+	News           []NewsResponse          `json:"news"`
+	Comments       []CommentResponse       `json:"comments"`
+	FriendRequests []FriendRequestResponse `json:"friend_requests"`
+	Reviews        []GameReviewResponse    `json:"reviews"`
+	Loans          []LoanResponse          `json:"loans"`
 }
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// AccountHandler serves the signed-in user's own personal data: the export
+// archive and account deletion.
+type AccountHandler struct {
+	userRepo          repositories.UserRepository
+	commentRepo       repositories.CommentRepository
+	friendRequestRepo repositories.FriendRequestRepository
+	reviewRepo        repositories.GameReviewRepository
+	loanRepo          repositories.LoanRepository
+	recorder          *audit.Recorder
+}
+
+func NewAccountHandler(db *gorm.DB) *AccountHandler {
+	return NewAccountHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewCommentRepository(db),
+		repositories.NewFriendRequestRepository(db),
+		repositories.NewGameReviewRepository(db),
+		repositories.NewLoanRepository(db),
+	)
+}
+
+func NewAccountHandlerWithRepo(userRepo repositories.UserRepository, commentRepo repositories.CommentRepository, friendRequestRepo repositories.FriendRequestRepository, reviewRepo repositories.GameReviewRepository, loanRepo repositories.LoanRepository) *AccountHandler {
+	return &AccountHandler{
+		userRepo:          userRepo,
+		commentRepo:       commentRepo,
+		friendRequestRepo: friendRequestRepo,
+		reviewRepo:        reviewRepo,
+		loanRepo:          loanRepo,
+		recorder:          audit.DefaultRecorder,
+	}
+}
+
+func (h *AccountHandler) ExportData(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	user, err := h.userRepo.FindForExport(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	comments, err := h.commentRepo.FindByUserID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export data"))
+	}
+
+	sent, err := h.friendRequestRepo.FindBySenderID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export data"))
+	}
+	received, err := h.friendRequestRepo.FindByReceiverID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export data"))
+	}
+
+	reviews, err := h.reviewRepo.FindByUserID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export data"))
+	}
+
+	loans, err := h.loanRepo.FindByUserID(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export data"))
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="gameclub-export-%d.json"`, userID))
+	return c.JSON(mappers.ToUserExportResponse(user, comments, append(sent, received...), reviews, loans, time.Now()))
+}
+
+// DeleteAccount anonymizes the signed-in user. Their news, comments and
+// reviews are kept under a placeholder name; every token and API key stops working.
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	if err := h.userRepo.Delete(c.Context(), userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete account"))
+	}
+
+	// Only the ID is kept in the audit trail, not the erased email.
+	deleted := &models.User{Model: gorm.Model{ID: userID}}
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ResourceAction(audit.ResourceUser, audit.ActionDeleted),
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(userID),
+		Actor:        deleted,
+	})
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
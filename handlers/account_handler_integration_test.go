@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupAccountTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	accountHandler := NewAccountHandler(db)
+
+	app.Get("/users/me/export", middleware.JWTMiddleware(db), accountHandler.ExportData)
+	app.Delete("/users/me", middleware.JWTMiddleware(db), accountHandler.DeleteAccount)
+	return app
+}
+
+// seedAccountData gives the user a team, a news post with a comment, a game
+// review, a loan and one friendship, the content the export and deletion deal with.
+func seedAccountData(db *gorm.DB, user, friend models.User) models.News {
+	db.AutoMigrate(&models.FriendRequest{})
+
+	team := models.Team{Name: "Meeples", Users: []*models.User{&user, &friend}}
+	db.Create(&team)
+	news := models.News{Title: "Game night", Description: "Friday", AuthorID: user.ID}
+	db.Create(&news)
+	db.Create(&models.Comment{Content: "See you there", UserID: user.ID, NewsID: news.ID})
+	db.Create(&models.FriendRequest{SenderID: friend.ID, ReceiverID: user.ID, Status: models.StatusAccepted})
+	game := models.NewGameBuilder().SetName("Azul").Build()
+	db.Create(game)
+	db.Create(&models.GameReview{GameID: game.ID, UserID: user.ID, Score: 8, Text: "Pretty tiles"})
+	gameCopy := models.GameCopy{GameID: game.ID, Barcode: "AZUL-1"}
+	db.Create(&gameCopy)
+	db.Create(&models.Loan{CopyID: gameCopy.ID, UserID: user.ID, CheckedOutAt: time.Now(), DueAt: time.Now().Add(time.Hour)})
+	return news
+}
+
+func TestAccountHandler_ExportData(t *testing.T) {
+	// Given: A user with teams, news, comments, a review, a loan and a friendship
+	db := setupTestDB(t)
+	app := setupAccountTestApp(db)
+	user := createSessionTestUser(db, "export@example.com")
+	friend := createSessionTestUser(db, "friend@example.com")
+	seedAccountData(db, user, friend)
+
+	req := httptest.NewRequest("GET", "/users/me/export", nil)
+	authenticate(req, user)
+
+	// When: Exporting their data
+	resp, err := app.Test(req)
+
+	// Then: The archive should contain all of it as a download
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "attachment")
+
+	var export dtos.UserExportResponse
+	json.NewDecoder(resp.Body).Decode(&export)
+	assert.Equal(t, "export@example.com", export.Profile.Email)
+	assert.NotEmpty(t, export.ExportedAt)
+	assert.Len(t, export.Teams, 1)
+	assert.Equal(t, "Meeples", export.Teams[0].Name)
+	assert.Len(t, export.News, 1)
+	assert.Len(t, export.Comments, 1)
+	assert.Equal(t, "Game night", export.Comments[0].NewsTitle)
+	assert.Len(t, export.FriendRequests, 1)
+	assert.Equal(t, friend.ID, export.FriendRequests[0].SenderID)
+	assert.Len(t, export.Reviews, 1)
+	assert.Equal(t, "Azul", export.Reviews[0].GameName)
+	assert.Len(t, export.Loans, 1)
+	assert.Equal(t, "AZUL-1", export.Loans[0].Barcode)
+}
+
+func TestAccountHandler_DeleteAccount_AnonymizesAndRevokes(t *testing.T) {
+	// Given: A user with content, a friendship, a session and an API key
+	db := setupTestDB(t)
+	app := setupAccountTestApp(db)
+	user := createSessionTestUser(db, "leaving@example.com")
+	friend := createSessionTestUser(db, "friend@example.com")
+	news := seedAccountData(db, user, friend)
+	db.Create(&models.RefreshToken{UserID: user.ID, TokenHash: "refresh-hash", FamilyID: "family"})
+	db.Create(&models.APIKey{UserID: user.ID, Name: "bot", Prefix: "gck_test", KeyHash: "key-hash", Scopes: models.ScopeNewsWrite})
+
+	req := httptest.NewRequest("DELETE", "/users/me", nil)
+	authenticate(req, user)
+
+	// When: The user deletes their account
+	resp, err := app.Test(req)
+
+	// Then: The account should be anonymized and soft-deleted
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	var deleted models.User
+	db.Unscoped().First(&deleted, user.ID)
+	assert.True(t, deleted.DeletedAt.Valid)
+	assert.Equal(t, "Deleted", deleted.FirstName)
+	assert.NotContains(t, deleted.Email, "leaving")
+	assert.Empty(t, deleted.Password)
+
+	// And: Authored content should survive without dangling references
+	var keptNews models.News
+	assert.NoError(t, db.First(&keptNews, news.ID).Error)
+	assert.Equal(t, user.ID, keptNews.AuthorID)
+	var commentCount int64
+	db.Model(&models.Comment{}).Where("user_id = ?", user.ID).Count(&commentCount)
+	assert.Equal(t, int64(1), commentCount)
+	var violations []map[string]interface{}
+	db.Raw("PRAGMA foreign_key_check").Scan(&violations)
+	assert.Empty(t, violations)
+
+	// And: Friendships, memberships, sessions and keys should be gone
+	var count int64
+	db.Unscoped().Model(&models.FriendRequest{}).Where("sender_id = ? OR receiver_id = ?", user.ID, user.ID).Count(&count)
+	assert.Zero(t, count)
+	db.Table("user_teams").Where("user_id = ?", user.ID).Count(&count)
+	assert.Zero(t, count)
+	db.Table("user_teams").Where("user_id = ?", friend.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+	db.Unscoped().Model(&models.RefreshToken{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Zero(t, count)
+	db.Unscoped().Model(&models.APIKey{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Zero(t, count)
+
+	// And: The old access token should no longer work
+	req = httptest.NewRequest("GET", "/users/me/export", nil)
+	authenticate(req, user)
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	// And: The email should be free to register again
+	assert.NoError(t, db.Create(&models.User{FirstName: "New", Email: "leaving@example.com", Password: "hashed"}).Error)
+}
+
+func TestAccountHandler_RequiresAuthentication(t *testing.T) {
+	// Given: No credentials
+	db := setupTestDB(t)
+	app := setupAccountTestApp(db)
+
+	// When: Deleting "my" account anonymously
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/users/me", nil))
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func actorID(c *fiber.Ctx) (uint, bool) {
+	id, ok := c.Locals("userID").(uint)
+	return id, ok && id != 0
+}
+
+func actorRole(c *fiber.Ctx) models.UserRole {
+	role, _ := c.Locals("role").(models.UserRole)
+	return role
+}
+
+// impersonated reports whether an admin is acting as the user.
+func impersonated(c *fiber.Ctx) bool {
+	_, ok := middleware.Impersonator(c)
+	return ok
+}
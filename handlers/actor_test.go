@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func withActor(userID uint, role models.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("role", role)
+		return c.Next()
+	}
+}
+
+func TestActorID_FromLocals(t *testing.T) {
+	// Given: A request with an authenticated user in locals
+	app := fiber.New()
+	var id uint
+	var ok bool
+	app.Get("/", withActor(7, models.RoleMember), func(c *fiber.Ctx) error {
+		id, ok = actorID(c)
+		return nil
+	})
+
+	// When: Reading the actor
+	app.Test(httptest.NewRequest("GET", "/", nil))
+
+	// Then: The user ID should be returned
+	assert.True(t, ok)
+	assert.Equal(t, uint(7), id)
+}
+
+func TestActorID_Missing(t *testing.T) {
+	// Given: A request without an authenticated user
+	app := fiber.New()
+	ok := true
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, ok = actorID(c)
+		return nil
+	})
+
+	// When: Reading the actor
+	app.Test(httptest.NewRequest("GET", "/", nil))
+
+	// Then: No actor should be found
+	assert.False(t, ok)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type APIKeyHandler struct {
+	apiKeys  *security.APIKeyManager
+	recorder *audit.Recorder
+}
+
+func NewAPIKeyHandler(db *gorm.DB) *APIKeyHandler {
+	return NewAPIKeyHandlerWithRepo(repositories.NewAPIKeyRepository(db))
+}
+
+func NewAPIKeyHandlerWithRepo(apiKeyRepo repositories.APIKeyRepository) *APIKeyHandler {
+	return &APIKeyHandler{apiKeys: security.NewAPIKeyManager(apiKeyRepo), recorder: audit.DefaultRecorder}
+}
+
+func validateCreateAPIKeyRequest(req *dtos.CreateAPIKeyRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Scopes) == 0 {
+		return errors.New("name and at least one scope are required")
+	}
+	if len(req.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if req.ExpiresInDays < 0 {
+		return errors.New("expires_in_days must not be negative")
+	}
+	return nil
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	var req dtos.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	if err := validateCreateAPIKeyRequest(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	ttl := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	plain, key, err := h.apiKeys.Create(c.Context(), userID, req.Name, req.Scopes, ttl)
+	switch {
+	case errors.Is(err, security.ErrInvalidAPIKeyScope):
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Unknown scope; valid scopes are: " + strings.Join(models.APIKeyScopes, ", ")))
+	case errors.Is(err, security.ErrTooManyAPIKeys):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("API key limit reached; revoke an unused key first"))
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create API key"))
+	}
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionAPIKeyCreated,
+		ResourceType: audit.ResourceAPIKey,
+		ResourceID:   audit.ID(key.ID),
+		Metadata:     map[string]interface{}{"name": key.Name, "scopes": key.ScopeList()},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(dtos.CreatedAPIKeyResponse{
+		APIKeyResponse: mappers.ToAPIKeyResponse(key),
+		Key:            plain,
+	})
+}
+
+func (h *APIKeyHandler) GetAPIKeys(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	keys, err := h.apiKeys.List(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch API keys"))
+	}
+
+	return c.JSON(mappers.ToAPIKeyResponseList(keys))
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid API key ID"))
+	}
+
+	revoked, err := h.apiKeys.Revoke(c.Context(), uint(id), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to revoke API key"))
+	}
+	if !revoked {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionAPIKeyRevoked,
+		ResourceType: audit.ResourceAPIKey,
+		ResourceID:   audit.ID(uint(id)),
+	})
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
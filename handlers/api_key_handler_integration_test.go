@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupAPIKeyTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	apiKeyHandler := NewAPIKeyHandler(db)
+
+	authRequired := middleware.JWTMiddleware(db)
+	app.Get("/api-keys", authRequired, apiKeyHandler.GetAPIKeys)
+	app.Post("/api-keys", authRequired, apiKeyHandler.CreateAPIKey)
+	app.Delete("/api-keys/:id", authRequired, apiKeyHandler.RevokeAPIKey)
+
+	app.Get("/whoami", middleware.JWTOrAPIKeyMiddleware(db), middleware.RequireScope(models.ScopeNewsWrite), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"id": c.Locals("userID")})
+	})
+	return app
+}
+
+func createAPIKey(t *testing.T, app *fiber.App, user models.User, body string) (*dtos.CreatedAPIKeyResponse, int) {
+	req := httptest.NewRequest("POST", "/api-keys", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, user)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var created dtos.CreatedAPIKeyResponse
+	json.NewDecoder(resp.Body).Decode(&created)
+	return &created, resp.StatusCode
+}
+
+func TestAPIKeyHandler_CreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	// Given: A signed-in user
+	db := setupTestDB(t)
+	app := setupAPIKeyTestApp(db)
+	user := createSessionTestUser(db, "keys@example.com")
+
+	// When: Creating an API key
+	created, status := createAPIKey(t, app, user, `{"name":"Discord bot","scopes":["news:write"],"expires_in_days":30}`)
+
+	// Then: The plain key should be returned and only its hash stored
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.NotEmpty(t, created.Key)
+	assert.Equal(t, created.Key[:len(created.Prefix)], created.Prefix)
+	assert.Equal(t, []string{"news:write"}, created.Scopes)
+	assert.NotNil(t, created.ExpiresAt)
+
+	var stored models.APIKey
+	db.First(&stored, created.ID)
+	assert.Equal(t, security.HashToken(created.Key), stored.KeyHash)
+	assert.Equal(t, user.ID, stored.UserID)
+
+	// And: Listing keys should not reveal the key again
+	req := httptest.NewRequest("GET", "/api-keys", nil)
+	authenticate(req, user)
+	resp, _ := app.Test(req)
+	var raw []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&raw)
+	assert.Len(t, raw, 1)
+	assert.NotContains(t, raw[0], "key")
+	assert.Equal(t, "Discord bot", raw[0]["name"])
+}
+
+func TestAPIKeyHandler_CreateAPIKey_ValidatesRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing name", body: `{"scopes":["news:write"]}`},
+		{name: "missing scopes", body: `{"name":"bot"}`},
+		{name: "unknown scope", body: `{"name":"bot","scopes":["admin:all"]}`},
+		{name: "negative expiry", body: `{"name":"bot","scopes":["news:write"],"expires_in_days":-1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: A signed-in user
+			db := setupTestDB(t)
+			app := setupAPIKeyTestApp(db)
+			user := createSessionTestUser(db, "keys@example.com")
+
+			// When: Creating a key with an invalid request
+			_, status := createAPIKey(t, app, user, tt.body)
+
+			// Then: The request should be rejected
+			assert.Equal(t, fiber.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestAPIKeyHandler_KeyAuthenticatesAsOwner(t *testing.T) {
+	// Given: A user with an API key
+	db := setupTestDB(t)
+	app := setupAPIKeyTestApp(db)
+	user := createSessionTestUser(db, "bot@example.com")
+	created, _ := createAPIKey(t, app, user, `{"name":"bot","scopes":["news:write"]}`)
+
+	// When: Calling an API-key route with it
+	req := httptest.NewRequest("GET", "/whoami", nil)
+	req.Header.Set("Authorization", "ApiKey "+created.Key)
+	resp, err := app.Test(req)
+
+	// Then: The request should run as the owner
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body map[string]uint
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, user.ID, body["id"])
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	// Given: A user with an API key
+	db := setupTestDB(t)
+	app := setupAPIKeyTestApp(db)
+	user := createSessionTestUser(db, "revoke@example.com")
+	created, _ := createAPIKey(t, app, user, `{"name":"bot","scopes":["news:write"]}`)
+
+	// When: Revoking it
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/api-keys/%d", created.ID), nil)
+	authenticate(req, user)
+	resp, _ := app.Test(req)
+
+	// Then: The key should stop working and disappear from the list
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	useReq := httptest.NewRequest("GET", "/whoami", nil)
+	useReq.Header.Set("Authorization", "ApiKey "+created.Key)
+	useResp, _ := app.Test(useReq)
+	assert.Equal(t, fiber.StatusUnauthorized, useResp.StatusCode)
+
+	listReq := httptest.NewRequest("GET", "/api-keys", nil)
+	authenticate(listReq, user)
+	listResp, _ := app.Test(listReq)
+	var keys []dtos.APIKeyResponse
+	json.NewDecoder(listResp.Body).Decode(&keys)
+	assert.Empty(t, keys)
+}
+
+func TestAPIKeyHandler_RevokeAPIKey_OtherUsersKey(t *testing.T) {
+	// Given: A key owned by someone else
+	db := setupTestDB(t)
+	app := setupAPIKeyTestApp(db)
+	owner := createSessionTestUser(db, "owner@example.com")
+	intruder := createSessionTestUser(db, "intruder@example.com")
+	created, _ := createAPIKey(t, app, owner, `{"name":"bot","scopes":["news:write"]}`)
+
+	// When: Another user tries to revoke it
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/api-keys/%d", created.ID), nil)
+	authenticate(req, intruder)
+	resp, _ := app.Test(req)
+
+	// Then: The key should be reported as not found and keep working
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	var stored models.APIKey
+	db.First(&stored, created.ID)
+	assert.Nil(t, stored.RevokedAt)
+}
+
+func TestAPIKeyHandler_CannotManageKeysWithAPIKey(t *testing.T) {
+	// Given: A user with an API key
+	db := setupTestDB(t)
+	app := setupAPIKeyTestApp(db)
+	user := createSessionTestUser(db, "escalate@example.com")
+	created, _ := createAPIKey(t, app, user, `{"name":"bot","scopes":["news:write"]}`)
+
+	// When: Using the key to create another key
+	req := httptest.NewRequest("POST", "/api-keys", bytes.NewBufferString(`{"name":"more","scopes":["games:write"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "ApiKey "+created.Key)
+	resp, _ := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
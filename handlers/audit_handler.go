@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+type AuditHandler struct {
+	auditRepo repositories.AuditEventRepository
+}
+
+func NewAuditHandler(db *gorm.DB) *AuditHandler {
+	return NewAuditHandlerWithRepo(repositories.NewAuditEventRepository(db))
+}
+
+func NewAuditHandlerWithRepo(auditRepo repositories.AuditEventRepository) *AuditHandler {
+	return &AuditHandler{auditRepo: auditRepo}
+}
+
+// GetAuditEvents lists audit events, newest first. Supported filters are
+// actor_id, action, resource_type, resource_id and an RFC 3339 from/to range.
+func (h *AuditHandler) GetAuditEvents(c *fiber.Ctx) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	events, total, err := h.auditRepo.Find(c.Context(), filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch audit events"))
+	}
+
+	return c.JSON(dtos.AuditEventPageResponse{
+		Items:    mappers.ToAuditEventResponseList(events),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Total:    total,
+	})
+}
+
+func parseAuditFilter(c *fiber.Ctx) (repositories.AuditEventFilter, error) {
+	filter := repositories.AuditEventFilter{
+		Action:       c.Query("action"),
+		ResourceType: c.Query("resource_type"),
+		ResourceID:   c.Query("resource_id"),
+		Page:         c.QueryInt("page", 1),
+		PageSize:     c.QueryInt("page_size", defaultAuditPageSize),
+	}
+
+	if filter.Page < 1 {
+		return filter, fmt.Errorf("page must be at least 1")
+	}
+	if filter.PageSize < 1 || filter.PageSize > maxAuditPageSize {
+		return filter, fmt.Errorf("page_size must be between 1 and %d", maxAuditPageSize)
+	}
+
+	if value := c.Query("actor_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("actor_id must be a user ID")
+		}
+		actorID := uint(id)
+		filter.ActorID = &actorID
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := c.Query(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 timestamp", bound.name)
+		}
+		*bound.target = &parsed
+	}
+
+	return filter, nil
+}
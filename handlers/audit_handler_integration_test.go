@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupAuditTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	auditHandler := NewAuditHandler(db)
+	userHandler := NewUserHandler(db)
+	userHandler.recorder = audit.NewRecorder(repositories.NewAuditEventRepository(db))
+
+	admin := app.Group("/admin", middleware.JWTMiddleware(db), middleware.RequireRole(models.RoleAdmin))
+	admin.Get("/audit", auditHandler.GetAuditEvents)
+	admin.Put("/users/:id/role", userHandler.UpdateUserRole)
+	return app
+}
+
+func getAuditEvents(t *testing.T, app *fiber.App, token string, query url.Values) (*dtos.AuditEventPageResponse, int) {
+	req := httptest.NewRequest("GET", "/admin/audit?"+query.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var page dtos.AuditEventPageResponse
+	json.NewDecoder(resp.Body).Decode(&page)
+	return &page, resp.StatusCode
+}
+
+func TestAuditHandler_RoleChangeIsRecorded(t *testing.T) {
+	// Given: An admin and a member
+	db := setupTestDB(t)
+	app := setupAuditTestApp(db)
+	admin, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Organizer"})
+	req := authorizedRequest("PUT", fmt.Sprintf("/admin/users/%d/role", member.ID), adminToken, body)
+	req.Header.Set("User-Agent", "audit-test")
+	resp, _ := app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// When: Querying the audit log for role changes
+	page, status := getAuditEvents(t, app, adminToken, url.Values{"action": {audit.ActionUserRoleChanged}})
+
+	// Then: The change should be attributed to the admin with a diff
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, int64(1), page.Total)
+	event := page.Items[0]
+	assert.Equal(t, &admin.ID, event.ActorID)
+	assert.Equal(t, "admin@example.com", event.ActorEmail)
+	assert.Equal(t, audit.ResourceUser, event.ResourceType)
+	assert.Equal(t, fmt.Sprint(member.ID), event.ResourceID)
+	assert.Equal(t, "audit-test", event.UserAgent)
+	assert.JSONEq(t, `{"Role":{"before":"Member","after":"Organizer"}}`, string(event.Changes))
+}
+
+func TestAuditHandler_GetAuditEvents_FiltersAndPaginates(t *testing.T) {
+	// Given: Events of different actions and actors
+	db := setupTestDB(t)
+	app := setupAuditTestApp(db)
+	admin, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		db.Create(&models.AuditEvent{CreatedAt: base.Add(time.Duration(i) * time.Minute), ActorID: &admin.ID, Action: audit.ActionLogin})
+	}
+	db.Create(&models.AuditEvent{CreatedAt: base, Action: audit.ActionLoginFailed})
+
+	// When: Requesting the second page of the admin's logins
+	page, status := getAuditEvents(t, app, adminToken, url.Values{
+		"actor_id":  {fmt.Sprint(admin.ID)},
+		"action":    {audit.ActionLogin},
+		"page":      {"2"},
+		"page_size": {"2"},
+	})
+
+	// Then: Only matching events should be counted, newest first
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Len(t, page.Items, 2)
+	assert.True(t, page.Items[0].CreatedAt.After(page.Items[1].CreatedAt))
+
+	// And: A time range should narrow the results
+	page, _ = getAuditEvents(t, app, adminToken, url.Values{
+		"from": {base.Add(3 * time.Minute).Format(time.RFC3339)},
+	})
+	assert.Equal(t, int64(2), page.Total)
+}
+
+func TestAuditHandler_GetAuditEvents_RejectsInvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{name: "page zero", query: url.Values{"page": {"0"}}},
+		{name: "page size too large", query: url.Values{"page_size": {"1000"}}},
+		{name: "actor not a number", query: url.Values{"actor_id": {"abc"}}},
+		{name: "from not a timestamp", query: url.Values{"from": {"yesterday"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: An admin
+			db := setupTestDB(t)
+			app := setupAuditTestApp(db)
+			_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+
+			// When: Querying with an invalid parameter
+			_, status := getAuditEvents(t, app, adminToken, tt.query)
+
+			// Then: The request should be rejected
+			assert.Equal(t, fiber.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestAuditHandler_GetAuditEvents_RequiresAdmin(t *testing.T) {
+	// Given: A member
+	db := setupTestDB(t)
+	app := setupAuditTestApp(db)
+	_, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	// When: The member reads the audit log
+	_, status := getAuditEvents(t, app, memberToken, url.Values{})
+
+	// Then: Access should be forbidden
+	assert.Equal(t, fiber.StatusForbidden, status)
+}
+
+func TestAuditEvent_IsAppendOnly(t *testing.T) {
+	// Given: A stored audit event
+	db := setupTestDB(t)
+	event := models.AuditEvent{Action: audit.ActionLogin}
+	db.Create(&event)
+
+	// When: Updating or deleting it
+	updateErr := db.Model(&event).Update("action", "forged").Error
+	deleteErr := db.Delete(&event).Error
+
+	// Then: Both should be refused and the event unchanged
+	assert.ErrorIs(t, updateErr, models.ErrAuditEventImmutable)
+	assert.ErrorIs(t, deleteErr, models.ErrAuditEventImmutable)
+	var stored models.AuditEvent
+	db.First(&stored, event.ID)
+	assert.Equal(t, audit.ActionLogin, stored.Action)
+}
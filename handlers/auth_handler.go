@@ -1,15 +1,24 @@
 package handlers
 
 import (
-	"crypto/sha256"
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	netmail "net/mail"
+	"strconv"
+	"time"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
 )
 
@@ -17,35 +26,76 @@ var errResponseSent = fmt.Errorf("response already sent")
 
 type AuthHandler struct {
 	userRepo repositories.UserRepository
+	hasher   security.PasswordHasher
+	sessions *security.SessionManager
+	resets   *security.PasswordResetManager
+	mfa      *security.MFAManager
+	throttle *security.LoginThrottle
+	mailer   mail.Sender
+	recorder *audit.Recorder
+	// background runs work the response must not wait for.
+	background func(task func())
 }
 
-func NewAuthHandler(db *gorm.DB) *AuthHandler {
-	return &AuthHandler{userRepo: repositories.NewUserRepository(db)}
-}
+// passwordResetMailTimeout bounds a password reset lookup and mail, which
+// run after the response has been sent.
+const passwordResetMailTimeout = 30 * time.Second
 
-func NewAuthHandlerWithRepo(userRepo repositories.UserRepository) *AuthHandler {
-	return &AuthHandler{userRepo: userRepo}
+func runInBackground(task func()) {
+	go task()
 }
 
-func hashPassword(password string) string {
-	hash := sha256.Sum256([]byte(password))
-	return fmt.Sprintf("%x", hash)
+func NewAuthHandler(db *gorm.DB) *AuthHandler {
+	return NewAuthHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewDefaultRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		repositories.NewRecoveryCodeRepository(db),
+		security.NewDefaultLoginThrottle(),
+		mail.DefaultSender,
+	)
 }
 
-func verifyPassword(hashedPassword, password string) bool {
-	return hashPassword(password) == hashedPassword
+func NewAuthHandlerWithRepo(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, revokedTokenRepo repositories.RevokedTokenRepository, resetTokenRepo repositories.PasswordResetTokenRepository, recoveryCodeRepo repositories.RecoveryCodeRepository, throttle *security.LoginThrottle, mailer mail.Sender) *AuthHandler {
+	return &AuthHandler{
+		userRepo:   userRepo,
+		hasher:     security.DefaultPasswordHasher,
+		sessions:   security.NewSessionManager(userRepo, refreshTokenRepo, revokedTokenRepo),
+		resets:     security.NewPasswordResetManager(resetTokenRepo),
+		mfa:        security.NewMFAManager(userRepo, recoveryCodeRepo, security.DefaultTOTP),
+		throttle:   throttle,
+		mailer:     mailer,
+		recorder:   audit.DefaultRecorder,
+		background: runInBackground,
+	}
 }
 
 func validateRegisterRequest(req *dtos.RegisterRequest) error {
 	if req.Email == "" || req.Password == "" || req.FirstName == "" {
 		return fmt.Errorf("email, password, and first name are required")
 	}
-	if len(req.Password) < 6 {
+	if !isValidEmail(req.Email) {
+		return fmt.Errorf("email address is invalid")
+	}
+	return validatePassword(req.Password)
+}
+
+func validatePassword(password string) error {
+	if len(password) < 6 {
 		return fmt.Errorf("password must be at least 6 characters")
 	}
+	if len(password) > security.MaxPasswordBytes {
+		return security.ErrPasswordTooLong
+	}
 	return nil
 }
 
+func isValidEmail(email string) bool {
+	addr, err := netmail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
 func validateLoginRequest(req *dtos.LoginRequest) error {
 	if req.Email == "" || req.Password == "" {
 		return fmt.Errorf("email and password are required")
@@ -53,22 +103,29 @@ func validateLoginRequest(req *dtos.LoginRequest) error {
 	return nil
 }
 
-func buildAuthResponse(user *models.User, token string) dtos.AuthResponse {
+func buildAuthResponse(user *models.User, session *security.Session) dtos.AuthResponse {
 	return dtos.AuthResponse{
-		ID:        user.ID,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Token:     token,
+		ID:            user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		EmailVerified: user.EmailVerified,
+		Token:         session.AccessToken,
+		RefreshToken:  session.RefreshToken,
+		ExpiresIn:     session.ExpiresIn,
 	}
 }
 
 func buildUserResponse(user *models.User) fiber.Map {
 	return fiber.Map{
-		"id":         user.ID,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"email":      user.Email,
+		"id":             user.ID,
+		"first_name":     user.FirstName,
+		"last_name":      user.LastName,
+		"email":          user.Email,
+		"role":           user.Role,
+		"email_verified": user.EmailVerified,
+		"mfa_enabled":    user.TOTPEnabled,
 	}
 }
 
@@ -91,6 +148,10 @@ func (ah *AuthHandler) Register(c *fiber.Ctx) error {
 		return nil
 	}
 
+	if err := sendVerificationEmail(c, ah.mailer, user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
 	return ah.respondWithAuthToken(c, user, fiber.StatusCreated)
 }
 
@@ -104,23 +165,399 @@ func (ah *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
 	}
 
-	user, err := ah.findUserByEmail(c, req.Email)
+	ctx := c.Context()
+	if retryAfter := ah.throttle.RetryAfter(ctx, req.Email, c.IP()); retryAfter > 0 {
+		ah.recordLoginFailure(c, nil, req.Email, "locked_out")
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return c.Status(fiber.StatusTooManyRequests).JSON(utils.TooManyRequests("Too many failed login attempts, try again later"))
+	}
+
+	user, err := ah.authenticate(c, req)
 	if err != nil {
 		return nil
 	}
 
-	if !verifyPassword(user.Password, req.Password) {
-		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid password"))
+	ah.rehashPasswordIfNeeded(c, user, req.Password)
+
+	// With two-factor on, the failure count is only cleared once VerifyMFA
+	// accepts a code; otherwise knowing the password would reset the lockout
+	// that guards the code.
+	if user.TOTPEnabled {
+		return respondWithMFAChallenge(c, user)
 	}
 
+	ah.throttle.RecordSuccess(ctx, req.Email)
+	recordLogin(c, ah.recorder, user, "password", nil)
 	return ah.respondWithAuthToken(c, user, fiber.StatusOK)
 }
 
+func (ah *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req dtos.MFAVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.MFAToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("MFA token and a code or recovery code are required"))
+	}
+
+	pending, err := security.ParseMFAPendingToken(req.MFAToken)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid or expired MFA token"))
+	}
+
+	ctx := c.Context()
+	used, err := ah.sessions.IsTokenRevoked(ctx, pending.JTI)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to verify two-factor code"))
+	}
+	if used {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid or expired MFA token"))
+	}
+
+	user, err := ah.userRepo.FindByID(ctx, pending.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid or expired MFA token"))
+	}
+
+	// Codes are short, so failed attempts count towards the login lockout.
+	if retryAfter := ah.throttle.RetryAfter(ctx, user.Email, c.IP()); retryAfter > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return c.Status(fiber.StatusTooManyRequests).JSON(utils.TooManyRequests("Too many failed login attempts, try again later"))
+	}
+
+	if err := ah.mfa.Verify(ctx, user, req.Code, req.RecoveryCode); err != nil {
+		if errors.Is(err, security.ErrInvalidMFACode) || errors.Is(err, security.ErrMFANotEnabled) {
+			ah.throttle.RecordFailure(ctx, user.Email, c.IP())
+			ah.recorder.Record(c, audit.Event{
+				Action:       audit.ActionMFAFailed,
+				ResourceType: audit.ResourceUser,
+				ResourceID:   audit.ID(user.ID),
+			})
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid two-factor code"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to verify two-factor code"))
+	}
+
+	// The pending token is spent; a second code cannot open another session.
+	if err := ah.sessions.RevokeAccessToken(ctx, pending.JTI, user.ID, pending.ExpiresAt); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to verify two-factor code"))
+	}
+
+	ah.throttle.RecordSuccess(ctx, user.Email)
+	recordLogin(c, ah.recorder, user, "mfa", nil)
+	return ah.respondWithAuthToken(c, user, fiber.StatusOK)
+}
+
+func (ah *AuthHandler) EnrollMFA(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	enrollment, err := ah.mfa.Enroll(c.Context(), user)
+	if err != nil {
+		if errors.Is(err, security.ErrMFAAlreadyEnabled) {
+			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Two-factor authentication is already enabled"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to enroll two-factor authentication"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.MFAEnrollResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+func (ah *AuthHandler) ConfirmMFA(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req dtos.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Code is required"))
+	}
+
+	codes, err := ah.mfa.Confirm(c.Context(), user, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, security.ErrMFAAlreadyEnabled):
+			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Two-factor authentication is already enabled"))
+		case errors.Is(err, security.ErrMFANotEnrolled):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Two-factor authentication has not been enrolled"))
+		case errors.Is(err, security.ErrInvalidMFACode):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid two-factor code"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to confirm two-factor authentication"))
+	}
+
+	ah.recordUserEvent(c, audit.ActionMFAEnabled, user)
+	return c.Status(fiber.StatusOK).JSON(dtos.MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (ah *AuthHandler) DisableMFA(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req dtos.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Code or recovery code is required"))
+	}
+
+	if err := ah.mfa.Disable(c.Context(), user, req.Code, req.RecoveryCode); err != nil {
+		switch {
+		case errors.Is(err, security.ErrMFANotEnabled):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Two-factor authentication is not enabled"))
+		case errors.Is(err, security.ErrInvalidMFACode):
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid two-factor code"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to disable two-factor authentication"))
+	}
+
+	ah.recordUserEvent(c, audit.ActionMFADisabled, user)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ah *AuthHandler) UnlockUser(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	user, err := ah.userRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
+	}
+
+	if err := ah.throttle.Unlock(c.Context(), user.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to unlock account"))
+	}
+
+	ah.recordUserEvent(c, audit.ActionLockoutClear, user)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 func (ah *AuthHandler) GetCurrentUser(c *fiber.Ctx) error {
 	user := c.Locals("user").(*models.User)
 	return c.Status(fiber.StatusOK).JSON(buildUserResponse(user))
 }
 
+func (ah *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dtos.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Verification token is required"))
+	}
+
+	claims, err := security.ParseEmailVerificationToken(req.Token)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid or expired verification token"))
+	}
+
+	user, err := ah.userRepo.FindByID(c.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid or expired verification token"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
+	}
+
+	if user.Email != claims.Email {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid or expired verification token"))
+	}
+
+	if !user.EmailVerified {
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		if err := ah.userRepo.Update(c.Context(), user); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to verify email"))
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(buildUserResponse(user))
+}
+
+func (ah *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	if user.EmailVerified {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Email already verified"))
+	}
+
+	if err := sendVerificationEmail(c, ah.mailer, user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to send verification email"))
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+func (ah *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dtos.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Email is required"))
+	}
+
+	// The response is the same whether or not the address belongs to an
+	// account, so this endpoint cannot be used to discover registered emails.
+	// The lookup and the mail happen after it is sent, so neither can its
+	// timing.
+	email := req.Email
+	ah.background(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetMailTimeout)
+		defer cancel()
+		if err := ah.sendPasswordResetEmail(ctx, email); err != nil {
+			log.Printf("Failed to send password reset email: %v", err)
+		}
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "If an account with that email exists, a password reset link has been sent",
+	})
+}
+
+func (ah *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dtos.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Reset token is required"))
+	}
+
+	if err := validatePassword(req.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	ctx := c.Context()
+	userID, err := ah.resets.Redeem(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, security.ErrInvalidResetToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid or expired reset token"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to reset password"))
+	}
+
+	user, err := ah.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid or expired reset token"))
+	}
+
+	hashedPassword, err := ah.hasher.Hash(req.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to reset password"))
+	}
+
+	user.Password = hashedPassword
+	if !user.EmailVerified {
+		// Following the emailed link proves ownership of the address.
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := ah.userRepo.Update(ctx, user); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to reset password"))
+	}
+
+	if err := ah.sessions.RevokeAll(ctx, user); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to reset password"))
+	}
+
+	ah.recorder.Record(c, audit.Event{
+		Action:       audit.ActionPasswordReset,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(user.ID),
+		Actor:        user,
+	})
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ah *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req dtos.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	if req.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Refresh token is required"))
+	}
+
+	session, user, err := ah.sessions.Refresh(c.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, security.ErrInvalidRefreshToken) || errors.Is(err, security.ErrRefreshTokenReused) {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid refresh token"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to refresh token"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(buildAuthResponse(user, session))
+}
+
+func (ah *AuthHandler) Logout(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req dtos.RefreshTokenRequest
+	c.BodyParser(&req)
+
+	if err := ah.revokeCurrentAccessToken(c, user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to revoke token"))
+	}
+
+	if req.RefreshToken != "" {
+		err := ah.sessions.RevokeRefreshToken(c.Context(), req.RefreshToken, user.ID)
+		if err != nil && !errors.Is(err, security.ErrInvalidRefreshToken) {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to revoke refresh token"))
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ah *AuthHandler) LogoutAll(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	if err := ah.sessions.RevokeAll(c.Context(), user); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to revoke sessions"))
+	}
+
+	if err := ah.revokeCurrentAccessToken(c, user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to revoke token"))
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ah *AuthHandler) revokeCurrentAccessToken(c *fiber.Ctx, userID uint) error {
+	claims, ok := c.Locals("claims").(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	jti, _ := claims["jti"].(string)
+	expiresAt := time.Now().Add(security.AccessTokenTTL)
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
+	return ah.sessions.RevokeAccessToken(c.Context(), jti, userID, expiresAt)
+}
+
 func (ah *AuthHandler) parseRegisterRequest(c *fiber.Ctx) (*dtos.RegisterRequest, error) {
 	var req dtos.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -155,11 +592,18 @@ func (ah *AuthHandler) checkEmailAvailable(c *fiber.Ctx) error {
 
 func (ah *AuthHandler) createUser(c *fiber.Ctx, req *dtos.RegisterRequest) (*models.User, error) {
 	ctx := c.Context()
+	hashedPassword, err := ah.hasher.Hash(req.Password)
+	if err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create user"))
+		return nil, errResponseSent
+	}
+
 	user := &models.User{
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Email:     req.Email,
-		Password:  hashPassword(req.Password),
+		Password:  hashedPassword,
+		Role:      models.RoleMember,
 	}
 
 	if err := ah.userRepo.Create(ctx, user); err != nil {
@@ -169,25 +613,133 @@ func (ah *AuthHandler) createUser(c *fiber.Ctx, req *dtos.RegisterRequest) (*mod
 	return user, nil
 }
 
-func (ah *AuthHandler) findUserByEmail(c *fiber.Ctx, email string) (*models.User, error) {
+// authenticate gives the same answer for an unknown email and a wrong
+// password so the login form cannot be used to discover accounts.
+func (ah *AuthHandler) authenticate(c *fiber.Ctx, req *dtos.LoginRequest) (*models.User, error) {
 	ctx := c.Context()
-	user, err := ah.userRepo.FindByEmail(ctx, email)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("User with this email address not found"))
-			return nil, errResponseSent
-		}
+	user, err := ah.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil && err != gorm.ErrRecordNotFound {
 		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
 		return nil, errResponseSent
 	}
+
+	// An unknown email is checked against a dummy hash, so it takes as long
+	// to reject as a wrong password.
+	known := err == nil
+	hashedPassword := security.DummyPasswordHash(ah.hasher)
+	if known {
+		hashedPassword = user.Password
+	}
+
+	if !ah.hasher.Verify(hashedPassword, req.Password) || !known {
+		ah.throttle.RecordFailure(ctx, req.Email, c.IP())
+		ah.recordLoginFailure(c, user, req.Email, "invalid_credentials")
+		c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Invalid email or password"))
+		return nil, errResponseSent
+	}
+
 	return user, nil
 }
 
+func (ah *AuthHandler) rehashPasswordIfNeeded(c *fiber.Ctx, user *models.User, password string) {
+	if !ah.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := ah.hasher.Hash(password)
+	if err != nil {
+		log.Printf("Failed to rehash password for user %d: %v", user.ID, err)
+		return
+	}
+
+	user.Password = hashedPassword
+	if err := ah.userRepo.Update(c.Context(), user); err != nil {
+		log.Printf("Failed to store rehashed password for user %d: %v", user.ID, err)
+	}
+}
+
+func sendVerificationEmail(c *fiber.Ctx, mailer mail.Sender, user *models.User) error {
+	token, err := security.GenerateEmailVerificationToken(user)
+	if err != nil {
+		return err
+	}
+	return mailer.Send(c.Context(), mail.VerificationEmail(user.Email, user.FirstName, token))
+}
+
+func (ah *AuthHandler) sendPasswordResetEmail(ctx context.Context, email string) error {
+	user, err := ah.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := ah.resets.Issue(ctx, user)
+	if err != nil {
+		return err
+	}
+	return ah.mailer.Send(ctx, mail.PasswordResetEmail(user.Email, user.FirstName, token))
+}
+
+// recordUserEvent records an action performed on the given user's account.
+func (ah *AuthHandler) recordUserEvent(c *fiber.Ctx, action string, user *models.User) {
+	ah.recorder.Record(c, audit.Event{
+		Action:       action,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(user.ID),
+	})
+}
+
+// recordLoginFailure keeps the attempted email in the metadata; user is nil
+// when no account matched it.
+func (ah *AuthHandler) recordLoginFailure(c *fiber.Ctx, user *models.User, email, reason string) {
+	event := audit.Event{
+		Action:       audit.ActionLoginFailed,
+		ResourceType: audit.ResourceUser,
+		Metadata:     map[string]interface{}{"email": email, "reason": reason},
+	}
+	if user != nil {
+		event.ResourceID = audit.ID(user.ID)
+	}
+	ah.recorder.Record(c, event)
+}
+
+func recordLogin(c *fiber.Ctx, recorder *audit.Recorder, user *models.User, method string, metadata map[string]interface{}) {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["method"] = method
+	recorder.Record(c, audit.Event{
+		Action:       audit.ActionLogin,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(user.ID),
+		Metadata:     metadata,
+		Actor:        user,
+	})
+}
+
+func respondWithMFAChallenge(c *fiber.Ctx, user *models.User) error {
+	token, err := security.GenerateMFAPendingToken(user)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to generate token"))
+	}
+	return c.Status(fiber.StatusOK).JSON(dtos.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(security.MFAPendingTokenTTL.Seconds()),
+	})
+}
+
 func (ah *AuthHandler) respondWithAuthToken(c *fiber.Ctx, user *models.User, status int) error {
-	token, err := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName)
+	return respondWithSession(c, ah.sessions, user, status)
+}
+
+func respondWithSession(c *fiber.Ctx, sessions *security.SessionManager, user *models.User, status int) error {
+	session, err := sessions.Issue(c.Context(), user)
 	if err != nil {
 		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to generate token"))
 		return nil
 	}
-	return c.Status(status).JSON(buildAuthResponse(user, token))
+	return c.Status(status).JSON(buildAuthResponse(user, session))
 }
@@ -2,12 +2,23 @@ package handlers
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
@@ -20,7 +31,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to open test database: %v", err)
 	}
 
-	err = db.AutoMigrate(&models.User{}, &models.Team{}, &models.Game{}, &models.Tournament{}, &models.News{}, &models.Comment{})
+	err = db.AutoMigrate(&models.User{}, &models.Team{}, &models.Game{}, &models.Tournament{}, &models.News{}, &models.Comment{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.PasswordResetToken{}, &models.RecoveryCode{}, &models.UserIdentity{}, &models.APIKey{}, &models.AuditEvent{}, &models.GameReview{}, &models.GameCopy{}, &models.Loan{}, &models.Tag{}, &models.Play{}, &models.PlayPlayer{}, &models.PlayerRating{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -165,6 +176,30 @@ func TestAuthHandler_Register_ShortPassword(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
+func TestAuthHandler_Register_LongPassword(t *testing.T) {
+	// Given: A registration request with a password longer than bcrypt reads
+	db := setupTestDB(t)
+	app := setupTestApp(db)
+
+	reqBody := dtos.RegisterRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Password:  strings.Repeat("correct horse ", 6),
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the registration request
+	resp, err := app.Test(req)
+
+	// Then: The registration should fail with bad request
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
 func TestAuthHandler_Register_DuplicateEmail(t *testing.T) {
 	// Given: A user already exists with the same email
 	db := setupTestDB(t)
@@ -174,7 +209,7 @@ func TestAuthHandler_Register_DuplicateEmail(t *testing.T) {
 		FirstName: "Existing",
 		LastName:  "User",
 		Email:     "existing@example.com",
-		Password:  hashPassword("password123"),
+		Password:  hashTestPassword("password123"),
 	}
 	db.Create(&existingUser)
 
@@ -250,7 +285,7 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 		FirstName: "Login",
 		LastName:  "User",
 		Email:     "login@example.com",
-		Password:  hashPassword("password123"),
+		Password:  hashTestPassword("password123"),
 	}
 	db.Create(&user)
 
@@ -280,7 +315,7 @@ func TestAuthHandler_Login_WrongPassword(t *testing.T) {
 		FirstName: "Wrong",
 		LastName:  "Password",
 		Email:     "wrong@example.com",
-		Password:  hashPassword("correctpassword"),
+		Password:  hashTestPassword("correctpassword"),
 	}
 	db.Create(&user)
 
@@ -390,7 +425,7 @@ func TestAuthHandler_Login_ReturnsToken(t *testing.T) {
 		FirstName: "Token",
 		LastName:  "Login",
 		Email:     "tokenlogin@example.com",
-		Password:  hashPassword("password123"),
+		Password:  hashTestPassword("password123"),
 	}
 	db.Create(&user)
 
@@ -451,50 +486,1021 @@ func TestAuthHandler_GetCurrentUser_ReturnsUserData(t *testing.T) {
 	assert.Equal(t, "test@example.com", response["email"])
 }
 
-func TestHashPassword(t *testing.T) {
-	// Given: A password string
-	password := "testpassword123"
+func TestAuthHandler_Login_UpgradesLegacyPasswordHash(t *testing.T) {
+	// Given: An existing user whose password is stored as an unsalted SHA-256 hash
+	db := setupTestDB(t)
+	app := setupTestApp(db)
+
+	user := models.User{
+		FirstName: "Legacy",
+		LastName:  "User",
+		Email:     "legacy@example.com",
+		Password:  legacySHA256("password123"),
+	}
+	db.Create(&user)
+
+	reqBody := dtos.LoginRequest{
+		Email:    "legacy@example.com",
+		Password: "password123",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the login request
+	resp, err := app.Test(req)
+
+	// Then: The login should succeed and the stored hash should be upgraded
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var stored models.User
+	db.First(&stored, user.ID)
+	assert.False(t, security.IsLegacyPasswordHash(stored.Password))
+	assert.True(t, security.DefaultPasswordHasher.Verify(stored.Password, "password123"))
+}
+
+func TestAuthHandler_Login_LegacyPasswordHash_WrongPassword(t *testing.T) {
+	// Given: An existing user with a legacy SHA-256 hash
+	db := setupTestDB(t)
+	app := setupTestApp(db)
+
+	legacyHash := legacySHA256("password123")
+	user := models.User{
+		FirstName: "Legacy",
+		LastName:  "Wrong",
+		Email:     "legacywrong@example.com",
+		Password:  legacyHash,
+	}
+	db.Create(&user)
+
+	reqBody := dtos.LoginRequest{
+		Email:    "legacywrong@example.com",
+		Password: "wrongpassword",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the login request with the wrong password
+	resp, err := app.Test(req)
+
+	// Then: The login should fail and the stored hash should stay untouched
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	var stored models.User
+	db.First(&stored, user.ID)
+	assert.Equal(t, legacyHash, stored.Password)
+}
+
+func TestAuthHandler_Register_StoresAdaptiveHash(t *testing.T) {
+	// Given: A valid registration request
+	db := setupTestDB(t)
+	app := setupTestApp(db)
+
+	reqBody := dtos.RegisterRequest{
+		FirstName: "Adaptive",
+		LastName:  "Hash",
+		Email:     "adaptive@example.com",
+		Password:  "password123",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the registration request
+	resp, err := app.Test(req)
+
+	// Then: The stored password should be an adaptive hash, not the plain text or SHA-256
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var stored models.User
+	db.Where("email = ?", "adaptive@example.com").First(&stored)
+	assert.NotEqual(t, "password123", stored.Password)
+	assert.False(t, security.IsLegacyPasswordHash(stored.Password))
+	assert.False(t, security.DefaultPasswordHasher.NeedsRehash(stored.Password))
+}
+
+func hashTestPassword(password string) string {
+	hash, _ := security.DefaultPasswordHasher.Hash(password)
+	return hash
+}
+
+func legacySHA256(password string) string {
+	hash := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(hash[:])
+}
+
+func setupSessionTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	authHandler := NewAuthHandler(db)
+
+	app.Post("/auth/login", authHandler.Login)
+	app.Post("/auth/refresh", authHandler.Refresh)
+	app.Get("/auth/me", middleware.JWTMiddleware(db), authHandler.GetCurrentUser)
+	app.Post("/auth/logout", middleware.JWTMiddleware(db), authHandler.Logout)
+	app.Post("/auth/logout-all", middleware.JWTMiddleware(db), authHandler.LogoutAll)
+
+	return app
+}
+
+func loginForSession(t *testing.T, app *fiber.App, email, password string) dtos.AuthResponse {
+	body, _ := json.Marshal(dtos.LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	return response
+}
+
+func refreshSession(app *fiber.App, refreshToken string) (*http.Response, dtos.AuthResponse) {
+	body, _ := json.Marshal(dtos.RefreshTokenRequest{RefreshToken: refreshToken})
+	req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req)
+
+	var response dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	return resp, response
+}
+
+func authorizedRequest(method, path, token string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func authenticate(req *http.Request, user models.User) {
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+func createSessionTestUser(db *gorm.DB, email string) models.User {
+	user := models.User{
+		FirstName: "Session",
+		LastName:  "User",
+		Email:     email,
+		Password:  hashTestPassword("password123"),
+	}
+	db.Create(&user)
+	return user
+}
+
+func TestAuthHandler_Login_ReturnsRefreshToken(t *testing.T) {
+	// Given: An existing user
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	createSessionTestUser(db, "refresh@example.com")
+
+	// When: Logging in
+	session := loginForSession(t, app, "refresh@example.com", "password123")
+
+	// Then: The response should contain an access token, a refresh token and its lifetime
+	assert.NotEmpty(t, session.Token)
+	assert.NotEmpty(t, session.RefreshToken)
+	assert.Equal(t, int64(security.AccessTokenTTL.Seconds()), session.ExpiresIn)
+
+	var stored models.RefreshToken
+	db.First(&stored)
+	assert.Equal(t, security.HashToken(session.RefreshToken), stored.TokenHash)
+}
+
+func TestAuthHandler_Refresh_RotatesToken(t *testing.T) {
+	// Given: A logged in user
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	createSessionTestUser(db, "rotate@example.com")
+	session := loginForSession(t, app, "rotate@example.com", "password123")
+
+	// When: Refreshing the session
+	resp, refreshed := refreshSession(app, session.RefreshToken)
+
+	// Then: A new access token and a different refresh token should be issued
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, refreshed.Token)
+	assert.NotEmpty(t, refreshed.RefreshToken)
+	assert.NotEqual(t, session.RefreshToken, refreshed.RefreshToken)
+}
+
+func TestAuthHandler_Refresh_ReuseRevokesFamily(t *testing.T) {
+	// Given: A refresh token that has already been rotated
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	createSessionTestUser(db, "reuse@example.com")
+	session := loginForSession(t, app, "reuse@example.com", "password123")
+	_, refreshed := refreshSession(app, session.RefreshToken)
+
+	// When: Reusing the old refresh token
+	reuseResp, _ := refreshSession(app, session.RefreshToken)
+
+	// Then: The reuse should be rejected and the newer token should be revoked too
+	assert.Equal(t, fiber.StatusUnauthorized, reuseResp.StatusCode)
+
+	resp, _ := refreshSession(app, refreshed.RefreshToken)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuthHandler_Refresh_InvalidToken(t *testing.T) {
+	// Given: A refresh token that was never issued
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+
+	// When: Refreshing with it
+	resp, _ := refreshSession(app, "not-a-real-token")
+
+	// Then: The request should be rejected
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuthHandler_Refresh_MissingToken(t *testing.T) {
+	// Given: A refresh request without a token
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+
+	// When: Refreshing
+	resp, _ := refreshSession(app, "")
+
+	// Then: The request should fail with bad request
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAuthHandler_Refresh_ExpiredToken(t *testing.T) {
+	// Given: A refresh token that has expired
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	user := createSessionTestUser(db, "expired@example.com")
+	db.Create(&models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: security.HashToken("expired-token"),
+		FamilyID:  "family",
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+
+	// When: Refreshing with it
+	resp, _ := refreshSession(app, "expired-token")
+
+	// Then: The request should be rejected
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuthHandler_Logout_RevokesAccessAndRefreshToken(t *testing.T) {
+	// Given: A logged in user
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	createSessionTestUser(db, "logout@example.com")
+	session := loginForSession(t, app, "logout@example.com", "password123")
+
+	body, _ := json.Marshal(dtos.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+
+	// When: Logging out
+	resp, err := app.Test(authorizedRequest("POST", "/auth/logout", session.Token, body))
+
+	// Then: The access token and refresh token should no longer work
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	meResp, _ := app.Test(authorizedRequest("GET", "/auth/me", session.Token, nil))
+	assert.Equal(t, fiber.StatusUnauthorized, meResp.StatusCode)
+
+	refreshResp, _ := refreshSession(app, session.RefreshToken)
+	assert.Equal(t, fiber.StatusUnauthorized, refreshResp.StatusCode)
+}
+
+func TestAuthHandler_Logout_RequiresAuthentication(t *testing.T) {
+	// Given: A logout request without a token
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+
+	req := httptest.NewRequest("POST", "/auth/logout", nil)
+
+	// When: Logging out
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuthHandler_LogoutAll_RevokesEverySession(t *testing.T) {
+	// Given: A user logged in on two devices
+	db := setupTestDB(t)
+	app := setupSessionTestApp(db)
+	createSessionTestUser(db, "everywhere@example.com")
+	first := loginForSession(t, app, "everywhere@example.com", "password123")
+	second := loginForSession(t, app, "everywhere@example.com", "password123")
+
+	// When: Logging out everywhere from the first device
+	resp, err := app.Test(authorizedRequest("POST", "/auth/logout-all", first.Token, nil))
+
+	// Then: Refresh tokens from both devices should be revoked and the current token denied
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	firstRefresh, _ := refreshSession(app, first.RefreshToken)
+	secondRefresh, _ := refreshSession(app, second.RefreshToken)
+	assert.Equal(t, fiber.StatusUnauthorized, firstRefresh.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, secondRefresh.StatusCode)
+
+	meResp, _ := app.Test(authorizedRequest("GET", "/auth/me", first.Token, nil))
+	assert.Equal(t, fiber.StatusUnauthorized, meResp.StatusCode)
+
+	var user models.User
+	db.Where("email = ?", "everywhere@example.com").First(&user)
+	assert.NotNil(t, user.SessionsRevokedAt)
+}
+
+func setupVerificationTestApp(db *gorm.DB, sink *mail.MemorySink) *fiber.App {
+	app := fiber.New()
+	authHandler := NewAuthHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		repositories.NewRecoveryCodeRepository(db),
+		newTestLoginThrottle(),
+		sink,
+	)
+
+	app.Post("/auth/register", authHandler.Register)
+	app.Post("/auth/verify-email", authHandler.VerifyEmail)
+	app.Post("/auth/resend-verification", middleware.JWTMiddleware(db), authHandler.ResendVerification)
+
+	return app
+}
+
+func emailedTokenFrom(t *testing.T, msg mail.Message) string {
+	_, query, found := strings.Cut(msg.Body, "?token=")
+	assert.True(t, found)
+	token, err := url.QueryUnescape(strings.Fields(query)[0])
+	assert.NoError(t, err)
+	return token
+}
+
+func verifyEmail(app *fiber.App, token string) *http.Response {
+	body, _ := json.Marshal(dtos.VerifyEmailRequest{Token: token})
+	req := httptest.NewRequest("POST", "/auth/verify-email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func TestAuthHandler_Register_SendsVerificationEmail(t *testing.T) {
+	// Given: A valid registration request
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupVerificationTestApp(db, sink)
+
+	body, _ := json.Marshal(dtos.RegisterRequest{FirstName: "New", LastName: "User", Email: "new@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Registering
+	resp, err := app.Test(req)
+
+	// Then: The account should be unverified and a verification email should be sent
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
 
-	// When: Hashing the password
-	hashed := hashPassword(password)
+	var response dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.False(t, response.EmailVerified)
 
-	// Then: The hash should be non-empty and different from the original
-	assert.NotEmpty(t, hashed)
-	assert.NotEqual(t, password, hashed)
+	msg, ok := sink.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "new@example.com", msg.To)
+	assert.NotEmpty(t, emailedTokenFrom(t, msg))
 }
 
-func TestHashPassword_Consistent(t *testing.T) {
-	// Given: The same password hashed twice
-	password := "samepassword"
+func TestAuthHandler_Register_InvalidEmail(t *testing.T) {
+	// Given: A registration request with a malformed email
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupVerificationTestApp(db, sink)
 
-	// When: Hashing the password twice
-	hash1 := hashPassword(password)
-	hash2 := hashPassword(password)
+	body, _ := json.Marshal(dtos.RegisterRequest{FirstName: "Bad", Email: "not-an-email", Password: "password123"})
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 
-	// Then: The hashes should be identical
-	assert.Equal(t, hash1, hash2)
+	// When: Registering
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected and no email sent
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, sink.Messages())
 }
 
-func TestVerifyPassword_Correct(t *testing.T) {
-	// Given: A hashed password and the correct password
-	password := "correctpassword"
-	hashed := hashPassword(password)
+func TestAuthHandler_VerifyEmail_MarksUserVerified(t *testing.T) {
+	// Given: A registered user holding the emailed token
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupVerificationTestApp(db, sink)
 
-	// When: Verifying the password
-	result := verifyPassword(hashed, password)
+	body, _ := json.Marshal(dtos.RegisterRequest{FirstName: "Verify", Email: "verify@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	app.Test(req)
+	msg, _ := sink.Last()
+
+	// When: Submitting the token
+	resp := verifyEmail(app, emailedTokenFrom(t, msg))
+
+	// Then: The user should be verified
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-	// Then: The verification should succeed
-	assert.True(t, result)
+	var stored models.User
+	db.Where("email = ?", "verify@example.com").First(&stored)
+	assert.True(t, stored.EmailVerified)
+	assert.NotNil(t, stored.EmailVerifiedAt)
 }
 
-func TestVerifyPassword_Incorrect(t *testing.T) {
-	// Given: A hashed password and an incorrect password
-	password := "correctpassword"
-	hashed := hashPassword(password)
+func TestAuthHandler_VerifyEmail_InvalidToken(t *testing.T) {
+	// Given: A token that was not issued for email verification
+	db := setupTestDB(t)
+	app := setupVerificationTestApp(db, mail.NewMemorySink())
+	user := createSessionTestUser(db, "wrongtoken@example.com")
+	accessToken, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+
+	// When: Submitting an access token and a garbage token
+	accessResp := verifyEmail(app, accessToken)
+	garbageResp := verifyEmail(app, "garbage")
+
+	// Then: Both should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, accessResp.StatusCode)
+	assert.Equal(t, fiber.StatusBadRequest, garbageResp.StatusCode)
+}
+
+func TestAuthHandler_VerifyEmail_EmailChangedSinceIssue(t *testing.T) {
+	// Given: A token issued for an address the user no longer has
+	db := setupTestDB(t)
+	app := setupVerificationTestApp(db, mail.NewMemorySink())
+	user := createSessionTestUser(db, "old@example.com")
+	token, _ := security.GenerateEmailVerificationToken(&user)
+	db.Model(&user).Update("email", "new-address@example.com")
+
+	// When: Submitting the token
+	resp := verifyEmail(app, token)
+
+	// Then: The token should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAuthHandler_ResendVerification_SendsNewEmail(t *testing.T) {
+	// Given: An unverified, logged in user
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupVerificationTestApp(db, sink)
+	user := createSessionTestUser(db, "resend@example.com")
+
+	req := httptest.NewRequest("POST", "/auth/resend-verification", nil)
+	authenticate(req, user)
+
+	// When: Asking for a new verification email
+	resp, err := app.Test(req)
+
+	// Then: A new email should be sent
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	assert.Len(t, sink.Messages(), 1)
+}
+
+func TestAuthHandler_ResendVerification_AlreadyVerified(t *testing.T) {
+	// Given: A verified user
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupVerificationTestApp(db, sink)
+	user := createSessionTestUser(db, "done@example.com")
+	db.Model(&user).Update("email_verified", true)
+
+	req := httptest.NewRequest("POST", "/auth/resend-verification", nil)
+	authenticate(req, user)
+
+	// When: Asking for a new verification email
+	resp, err := app.Test(req)
+
+	// Then: The request should conflict and nothing should be sent
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Empty(t, sink.Messages())
+}
+
+func setupPasswordResetTestApp(db *gorm.DB, sink *mail.MemorySink) *fiber.App {
+	app := setupVerificationTestApp(db, sink)
+	authHandler := NewAuthHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		repositories.NewRecoveryCodeRepository(db),
+		newTestLoginThrottle(),
+		sink,
+	)
+	authHandler.background = func(task func()) { task() }
+
+	app.Post("/auth/login", authHandler.Login)
+	app.Post("/auth/refresh", authHandler.Refresh)
+	app.Post("/auth/forgot-password", authHandler.ForgotPassword)
+	app.Post("/auth/reset-password", authHandler.ResetPassword)
+
+	return app
+}
+
+func requestPasswordReset(app *fiber.App, email string) *http.Response {
+	body, _ := json.Marshal(dtos.ForgotPasswordRequest{Email: email})
+	req := httptest.NewRequest("POST", "/auth/forgot-password", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func resetPassword(app *fiber.App, token, password string) *http.Response {
+	body, _ := json.Marshal(dtos.ResetPasswordRequest{Token: token, Password: password})
+	req := httptest.NewRequest("POST", "/auth/reset-password", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func TestAuthHandler_ForgotPassword_UnknownEmail(t *testing.T) {
+	// Given: An email address that has no account
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+
+	// When: Requesting a password reset
+	resp := requestPasswordReset(app, "nobody@example.com")
+
+	// Then: The response should look successful but nothing should be sent
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, sink.Messages())
+}
+
+func TestAuthHandler_ForgotPassword_KnownEmail(t *testing.T) {
+	// Given: An existing user
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+	user := createSessionTestUser(db, "forgot@example.com")
+
+	// When: Requesting a password reset
+	resp := requestPasswordReset(app, "forgot@example.com")
+
+	// Then: A reset email should be sent and only the token hash stored
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	msg, ok := sink.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "forgot@example.com", msg.To)
+
+	token := emailedTokenFrom(t, msg)
+	var stored models.PasswordResetToken
+	db.Where("user_id = ?", user.ID).First(&stored)
+	assert.Equal(t, security.HashToken(token), stored.TokenHash)
+}
+
+func TestAuthHandler_ForgotPassword_SendsAfterResponding(t *testing.T) {
+	// Given: An existing user and a handler that holds back background work
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	createSessionTestUser(db, "later@example.com")
+	authHandler := NewAuthHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		repositories.NewRecoveryCodeRepository(db),
+		newTestLoginThrottle(),
+		sink,
+	)
+	var pending []func()
+	authHandler.background = func(task func()) { pending = append(pending, task) }
+	app := fiber.New()
+	app.Post("/auth/forgot-password", authHandler.ForgotPassword)
+
+	// When: Requesting resets for a known and an unknown address
+	known := requestPasswordReset(app, "later@example.com")
+	unknown := requestPasswordReset(app, "nobody@example.com")
+
+	// Then: Both respond alike before anything is looked up or sent
+	assert.Equal(t, fiber.StatusOK, known.StatusCode)
+	assert.Equal(t, fiber.StatusOK, unknown.StatusCode)
+	assert.Len(t, pending, 2)
+	assert.Empty(t, sink.Messages())
+
+	// When: The background work runs
+	for _, task := range pending {
+		task()
+	}
+
+	// Then: Only the known address gets a reset email
+	assert.Len(t, sink.Messages(), 1)
+	msg, _ := sink.Last()
+	assert.Equal(t, "later@example.com", msg.To)
+}
+
+func TestAuthHandler_ResetPassword_ChangesPasswordAndRevokesSessions(t *testing.T) {
+	// Given: A logged in user who requested a password reset
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+	createSessionTestUser(db, "reset@example.com")
+	session := loginForSession(t, app, "reset@example.com", "password123")
+	requestPasswordReset(app, "reset@example.com")
+	msg, _ := sink.Last()
+
+	// When: Resetting the password with the emailed token
+	resp := resetPassword(app, emailedTokenFrom(t, msg), "new-password")
+
+	// Then: The new password should work and the old refresh token should not
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	loginForSession(t, app, "reset@example.com", "new-password")
+
+	refreshResp, _ := refreshSession(app, session.RefreshToken)
+	assert.Equal(t, fiber.StatusUnauthorized, refreshResp.StatusCode)
+
+	var stored models.User
+	db.Where("email = ?", "reset@example.com").First(&stored)
+	assert.NotNil(t, stored.SessionsRevokedAt)
+	assert.True(t, stored.EmailVerified)
+}
+
+func TestAuthHandler_ResetPassword_TokenIsSingleUse(t *testing.T) {
+	// Given: A reset token that has already been used
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+	createSessionTestUser(db, "single@example.com")
+	requestPasswordReset(app, "single@example.com")
+	msg, _ := sink.Last()
+	token := emailedTokenFrom(t, msg)
+	resetPassword(app, token, "first-password")
+
+	// When: Using it a second time
+	resp := resetPassword(app, token, "second-password")
+
+	// Then: The second reset should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	loginForSession(t, app, "single@example.com", "first-password")
+}
+
+func TestAuthHandler_ResetPassword_NewRequestInvalidatesOldToken(t *testing.T) {
+	// Given: Two reset requests for the same account
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+	createSessionTestUser(db, "twice@example.com")
+	requestPasswordReset(app, "twice@example.com")
+	requestPasswordReset(app, "twice@example.com")
+	messages := sink.Messages()
+
+	// When: Using the token from the first email
+	resp := resetPassword(app, emailedTokenFrom(t, messages[0]), "new-password")
+
+	// Then: It should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAuthHandler_ResetPassword_ShortPassword(t *testing.T) {
+	// Given: A valid reset token
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	app := setupPasswordResetTestApp(db, sink)
+	createSessionTestUser(db, "short@example.com")
+	requestPasswordReset(app, "short@example.com")
+	msg, _ := sink.Last()
+	token := emailedTokenFrom(t, msg)
+
+	// When: Choosing a password that is too short
+	resp := resetPassword(app, token, "123")
+
+	// Then: The request should be rejected without consuming the token
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, resetPassword(app, token, "long-enough").StatusCode)
+}
+
+func setupLoginThrottleTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	authHandler := NewAuthHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		repositories.NewRecoveryCodeRepository(db),
+		newTestLoginThrottle(),
+		mail.NewMemorySink(),
+	)
+
+	app.Post("/auth/login", authHandler.Login)
+	app.Delete("/admin/users/:id/lockout", authHandler.UnlockUser)
+
+	return app
+}
+
+func attemptLogin(app *fiber.App, email, password string) *http.Response {
+	body, _ := json.Marshal(dtos.LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func TestAuthHandler_Login_SameMessageForUnknownEmailAndWrongPassword(t *testing.T) {
+	// Given: An existing user
+	db := setupTestDB(t)
+	app := setupLoginThrottleTestApp(db)
+	createSessionTestUser(db, "generic@example.com")
+
+	// When: Logging in with an unknown email and with a wrong password
+	unknownResp := attemptLogin(app, "unknown@example.com", "password123")
+	wrongResp := attemptLogin(app, "generic@example.com", "wrong-password")
+
+	// Then: Both failures should look identical
+	var unknownBody, wrongBody map[string]interface{}
+	json.NewDecoder(unknownResp.Body).Decode(&unknownBody)
+	json.NewDecoder(wrongResp.Body).Decode(&wrongBody)
+	assert.Equal(t, fiber.StatusUnauthorized, unknownResp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, wrongResp.StatusCode)
+	assert.Equal(t, unknownBody, wrongBody)
+}
+
+func TestAuthHandler_Login_LocksOutAfterRepeatedFailures(t *testing.T) {
+	// Given: An account that failed to log in too many times
+	db := setupTestDB(t)
+	app := setupLoginThrottleTestApp(db)
+	createSessionTestUser(db, "locked@example.com")
+	for i := 0; i < security.DefaultLoginThrottlePolicy.MaxAccountFailures; i++ {
+		attemptLogin(app, "locked@example.com", "wrong-password")
+	}
+
+	// When: Logging in with the correct password
+	resp := attemptLogin(app, "locked@example.com", "password123")
+
+	// Then: The attempt should be rejected until the lockout ends
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestAuthHandler_UnlockUser_AllowsLoginAgain(t *testing.T) {
+	// Given: A locked out account
+	db := setupTestDB(t)
+	app := setupLoginThrottleTestApp(db)
+	user := createSessionTestUser(db, "unlock@example.com")
+	for i := 0; i < security.DefaultLoginThrottlePolicy.MaxAccountFailures; i++ {
+		attemptLogin(app, "unlock@example.com", "wrong-password")
+	}
+
+	// When: An admin unlocks the account
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/admin/users/%d/lockout", user.ID), nil)
+	unlockResp, err := app.Test(req)
+
+	// Then: The user should be able to log in again
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, unlockResp.StatusCode)
+	assert.Equal(t, fiber.StatusOK, attemptLogin(app, "unlock@example.com", "password123").StatusCode)
+}
+
+func TestAuthHandler_UnlockUser_NotFound(t *testing.T) {
+	// Given: No user with the requested ID
+	db := setupTestDB(t)
+	app := setupLoginThrottleTestApp(db)
+
+	// When: Unlocking it
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/admin/users/999/lockout", nil))
+
+	// Then: The user should not be found
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+type mfaTestClock struct {
+	now time.Time
+}
+
+func (c *mfaTestClock) Now() time.Time {
+	return c.now
+}
+
+func setupMFATestApp(db *gorm.DB, clock *mfaTestClock) *fiber.App {
+	app := fiber.New()
+	userRepo := repositories.NewUserRepository(db)
+	recoveryRepo := repositories.NewRecoveryCodeRepository(db)
+	authHandler := NewAuthHandlerWithRepo(
+		userRepo,
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		repositories.NewPasswordResetTokenRepository(db),
+		recoveryRepo,
+		newTestLoginThrottle(),
+		mail.NewMemorySink(),
+	)
+	authHandler.mfa = security.NewMFAManager(userRepo, recoveryRepo, security.NewTOTP(clock.Now))
+
+	authRequired := middleware.JWTMiddleware(db)
+	app.Post("/auth/login", authHandler.Login)
+	app.Post("/auth/mfa/verify", authHandler.VerifyMFA)
+	app.Post("/auth/mfa/enroll", authRequired, authHandler.EnrollMFA)
+	app.Post("/auth/mfa/confirm", authRequired, authHandler.ConfirmMFA)
+	app.Post("/auth/mfa/disable", authRequired, authHandler.DisableMFA)
+
+	return app
+}
+
+func totpCodeAt(secret string, at time.Time) string {
+	totp := security.NewTOTP(func() time.Time { return at })
+	code, _ := totp.CodeAt(secret, totp.Step(at))
+	return code
+}
+
+func enableMFA(t *testing.T, app *fiber.App, clock *mfaTestClock, user models.User) (string, []string) {
+	req := httptest.NewRequest("POST", "/auth/mfa/enroll", nil)
+	authenticate(req, user)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var enrollment dtos.MFAEnrollResponse
+	json.NewDecoder(resp.Body).Decode(&enrollment)
+
+	body, _ := json.Marshal(dtos.MFACodeRequest{Code: totpCodeAt(enrollment.Secret, clock.now)})
+	req = httptest.NewRequest("POST", "/auth/mfa/confirm", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, user)
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var recovery dtos.MFARecoveryCodesResponse
+	json.NewDecoder(resp.Body).Decode(&recovery)
+	return enrollment.Secret, recovery.RecoveryCodes
+}
+
+func loginForMFAChallenge(t *testing.T, app *fiber.App, email string) dtos.MFAChallengeResponse {
+	resp := attemptLogin(app, email, "password123")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var challenge dtos.MFAChallengeResponse
+	json.NewDecoder(resp.Body).Decode(&challenge)
+	return challenge
+}
+
+func verifyMFA(app *fiber.App, req dtos.MFAVerifyRequest) (*http.Response, dtos.AuthResponse) {
+	body, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest("POST", "/auth/mfa/verify", bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(httpReq)
+
+	var response dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	return resp, response
+}
+
+func TestAuthHandler_MFA_TwoStepLogin(t *testing.T) {
+	// Given: An organizer who enabled two-factor authentication
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "mfa@example.com")
+	secret, _ := enableMFA(t, app, clock, user)
+
+	// When: Logging in with the password
+	challenge := loginForMFAChallenge(t, app, "mfa@example.com")
+
+	// Then: Only a pending token should be returned, which the middleware refuses
+	assert.True(t, challenge.MFARequired)
+	assert.NotEmpty(t, challenge.MFAToken)
+	meReq := httptest.NewRequest("POST", "/auth/mfa/enroll", nil)
+	meReq.Header.Set("Authorization", "Bearer "+challenge.MFAToken)
+	meResp, _ := app.Test(meReq)
+	assert.Equal(t, fiber.StatusUnauthorized, meResp.StatusCode)
+
+	// When: Exchanging it with a code from the next time step
+	clock.now = clock.now.Add(30 * time.Second)
+	resp, session := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: totpCodeAt(secret, clock.now)})
+
+	// Then: A full session should be issued
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, session.Token)
+	assert.NotEmpty(t, session.RefreshToken)
+	claims, err := security.ExtractClaims(session.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(user.ID), claims["id"])
+}
+
+func TestAuthHandler_MFA_RejectsWrongAndReplayedCodes(t *testing.T) {
+	// Given: A pending login for a two-factor user
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "replay@example.com")
+	secret, _ := enableMFA(t, app, clock, user)
+	challenge := loginForMFAChallenge(t, app, "replay@example.com")
+
+	// When: Using a wrong code and then the code already used for confirmation
+	wrongResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: "000000"})
+	replayResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: totpCodeAt(secret, clock.now)})
+
+	// Then: Both should be rejected
+	assert.Equal(t, fiber.StatusUnauthorized, wrongResp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, replayResp.StatusCode)
+}
+
+func TestAuthHandler_MFA_PendingTokenWorksOnce(t *testing.T) {
+	// Given: A pending login that was completed with a recovery code
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "once@example.com")
+	_, recoveryCodes := enableMFA(t, app, clock, user)
+	challenge := loginForMFAChallenge(t, app, "once@example.com")
+	firstResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, RecoveryCode: recoveryCodes[0]})
+
+	// When: Using the same pending token with another valid recovery code
+	secondResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, RecoveryCode: recoveryCodes[1]})
+
+	// Then: Only the first exchange opens a session
+	assert.Equal(t, fiber.StatusOK, firstResp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, secondResp.StatusCode)
+}
+
+func TestAuthHandler_MFA_PasswordLoginDoesNotResetFailures(t *testing.T) {
+	// Given: A two-factor user one wrong code away from a lockout
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "guessing@example.com")
+	enableMFA(t, app, clock, user)
+	challenge := loginForMFAChallenge(t, app, "guessing@example.com")
+	for i := 1; i < security.DefaultLoginThrottlePolicy.MaxAccountFailures; i++ {
+		verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: "000000"})
+	}
+
+	// When: Logging in with the password again and guessing once more
+	challenge = loginForMFAChallenge(t, app, "guessing@example.com")
+	verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: "000000"})
+	resp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: "000000"})
+
+	// Then: The earlier failures still count and the account is locked
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestAuthHandler_MFA_RecoveryCodeWorksOnce(t *testing.T) {
+	// Given: A pending login and a recovery code
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "recovery@example.com")
+	_, recoveryCodes := enableMFA(t, app, clock, user)
+	challenge := loginForMFAChallenge(t, app, "recovery@example.com")
+
+	// When: Using the same recovery code twice, in two logins
+	firstResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, RecoveryCode: recoveryCodes[0]})
+	challenge = loginForMFAChallenge(t, app, "recovery@example.com")
+	secondResp, _ := verifyMFA(app, dtos.MFAVerifyRequest{MFAToken: challenge.MFAToken, RecoveryCode: recoveryCodes[0]})
+
+	// Then: Only the first use should succeed
+	assert.Len(t, recoveryCodes, 10)
+	assert.Equal(t, fiber.StatusOK, firstResp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, secondResp.StatusCode)
+}
+
+func TestAuthHandler_MFA_Disable(t *testing.T) {
+	// Given: A user with two-factor authentication enabled
+	db := setupTestDB(t)
+	clock := &mfaTestClock{now: time.Unix(1700000000, 0)}
+	app := setupMFATestApp(db, clock)
+	user := createSessionTestUser(db, "disable@example.com")
+	secret, _ := enableMFA(t, app, clock, user)
+	clock.now = clock.now.Add(30 * time.Second)
+
+	body, _ := json.Marshal(dtos.MFACodeRequest{Code: totpCodeAt(secret, clock.now)})
+	req := httptest.NewRequest("POST", "/auth/mfa/disable", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, user)
+
+	// When: Disabling it with a current code
+	resp, err := app.Test(req)
+
+	// Then: Login should issue a full session again
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
 
-	// When: Verifying with wrong password
-	result := verifyPassword(hashed, "wrongpassword")
+	loginResp := attemptLogin(app, "disable@example.com", "password123")
+	var session dtos.AuthResponse
+	json.NewDecoder(loginResp.Body).Decode(&session)
+	assert.NotEmpty(t, session.Token)
 
-	// Then: The verification should fail
-	assert.False(t, result)
+	var count int64
+	db.Model(&models.RecoveryCode{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Zero(t, count)
 }
@@ -3,12 +3,16 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,13 +22,15 @@ import (
 func TestAuthHandler_Register_Success_Unit(t *testing.T) {
 	// Given: A valid registration request and mock repository
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, mockRefreshTokenRepo, new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
 
 	mockUserRepo.On("FindByEmail", mock.Anything, "john@example.com").Return(nil, gorm.ErrRecordNotFound)
 	mockUserRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
 
 	reqBody := dtos.RegisterRequest{
 		FirstName: "John",
@@ -44,12 +50,13 @@ func TestAuthHandler_Register_Success_Unit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
 	mockUserRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
 }
 
 func TestAuthHandler_Register_MissingEmail_Unit(t *testing.T) {
 	// Given: A registration request without email
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -75,7 +82,7 @@ func TestAuthHandler_Register_MissingEmail_Unit(t *testing.T) {
 func TestAuthHandler_Register_MissingPassword_Unit(t *testing.T) {
 	// Given: A registration request without password
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -101,7 +108,7 @@ func TestAuthHandler_Register_MissingPassword_Unit(t *testing.T) {
 func TestAuthHandler_Register_MissingFirstName_Unit(t *testing.T) {
 	// Given: A registration request without first name
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -127,7 +134,7 @@ func TestAuthHandler_Register_MissingFirstName_Unit(t *testing.T) {
 func TestAuthHandler_Register_ShortPassword_Unit(t *testing.T) {
 	// Given: A registration request with password less than 6 characters
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -154,7 +161,7 @@ func TestAuthHandler_Register_ShortPassword_Unit(t *testing.T) {
 func TestAuthHandler_Register_DuplicateEmail_Unit(t *testing.T) {
 	// Given: A user already exists with the same email
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -190,7 +197,7 @@ func TestAuthHandler_Register_DuplicateEmail_Unit(t *testing.T) {
 func TestAuthHandler_Register_InvalidJSON_Unit(t *testing.T) {
 	// Given: An invalid JSON request body
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -209,7 +216,7 @@ func TestAuthHandler_Register_InvalidJSON_Unit(t *testing.T) {
 func TestAuthHandler_Register_DatabaseError_Unit(t *testing.T) {
 	// Given: A database error occurs when checking for existing user
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -239,7 +246,7 @@ func TestAuthHandler_Register_DatabaseError_Unit(t *testing.T) {
 func TestAuthHandler_Register_CreateFails_Unit(t *testing.T) {
 	// Given: Creating user in database fails
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/register", handler.Register)
@@ -270,7 +277,8 @@ func TestAuthHandler_Register_CreateFails_Unit(t *testing.T) {
 func TestAuthHandler_Login_Success_Unit(t *testing.T) {
 	// Given: An existing user and valid login credentials
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, mockRefreshTokenRepo, new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -280,9 +288,10 @@ func TestAuthHandler_Login_Success_Unit(t *testing.T) {
 		FirstName: "Login",
 		LastName:  "User",
 		Email:     "login@example.com",
-		Password:  hashPassword("password123"),
+		Password:  hashTestPassword("password123"),
 	}
 	mockUserRepo.On("FindByEmail", mock.Anything, "login@example.com").Return(user, nil)
+	mockRefreshTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
 
 	reqBody := dtos.LoginRequest{
 		Email:    "login@example.com",
@@ -300,12 +309,13 @@ func TestAuthHandler_Login_Success_Unit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 	mockUserRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
 }
 
 func TestAuthHandler_Login_WrongPassword_Unit(t *testing.T) {
 	// Given: An existing user and wrong password
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -315,7 +325,7 @@ func TestAuthHandler_Login_WrongPassword_Unit(t *testing.T) {
 		FirstName: "Wrong",
 		LastName:  "Password",
 		Email:     "wrong@example.com",
-		Password:  hashPassword("correctpassword"),
+		Password:  hashTestPassword("correctpassword"),
 	}
 	mockUserRepo.On("FindByEmail", mock.Anything, "wrong@example.com").Return(user, nil)
 
@@ -340,7 +350,7 @@ func TestAuthHandler_Login_WrongPassword_Unit(t *testing.T) {
 func TestAuthHandler_Login_NonexistentUser_Unit(t *testing.T) {
 	// Given: A login request for a non-existent user
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -368,7 +378,7 @@ func TestAuthHandler_Login_NonexistentUser_Unit(t *testing.T) {
 func TestAuthHandler_Login_MissingEmail_Unit(t *testing.T) {
 	// Given: A login request without email
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -392,7 +402,7 @@ func TestAuthHandler_Login_MissingEmail_Unit(t *testing.T) {
 func TestAuthHandler_Login_MissingPassword_Unit(t *testing.T) {
 	// Given: A login request without password
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -416,7 +426,7 @@ func TestAuthHandler_Login_MissingPassword_Unit(t *testing.T) {
 func TestAuthHandler_Login_InvalidJSON_Unit(t *testing.T) {
 	// Given: An invalid JSON request body
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -435,7 +445,7 @@ func TestAuthHandler_Login_InvalidJSON_Unit(t *testing.T) {
 func TestAuthHandler_Login_DatabaseError_Unit(t *testing.T) {
 	// Given: A database error occurs when finding user
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Post("/auth/login", handler.Login)
@@ -463,7 +473,7 @@ func TestAuthHandler_Login_DatabaseError_Unit(t *testing.T) {
 func TestAuthHandler_GetCurrentUser_Success_Unit(t *testing.T) {
 	// Given: A request with authenticated user in context
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewAuthHandlerWithRepo(mockUserRepo)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
 
 	app := fiber.New()
 	app.Get("/auth/me", func(c *fiber.Ctx) error {
@@ -492,3 +502,178 @@ func TestAuthHandler_GetCurrentUser_Success_Unit(t *testing.T) {
 	assert.Equal(t, "User", response["last_name"])
 	assert.Equal(t, "test@example.com", response["email"])
 }
+
+func TestAuthHandler_Login_RehashesLegacyPassword_Unit(t *testing.T) {
+	// Given: An existing user with a legacy SHA-256 password hash
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, mockRefreshTokenRepo, new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
+
+	app := fiber.New()
+	app.Post("/auth/login", handler.Login)
+
+	user := &models.User{
+		Model:     gorm.Model{ID: 1},
+		FirstName: "Legacy",
+		LastName:  "User",
+		Email:     "legacy@example.com",
+		Password:  legacySHA256("password123"),
+	}
+	mockUserRepo.On("FindByEmail", mock.Anything, "legacy@example.com").Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return !security.IsLegacyPasswordHash(u.Password)
+	})).Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	reqBody := dtos.LoginRequest{
+		Email:    "legacy@example.com",
+		Password: "password123",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the login request
+	resp, err := app.Test(req)
+
+	// Then: The login should succeed and the password should be rehashed
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUserRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthHandler_Login_RehashFailureDoesNotBlockLogin_Unit(t *testing.T) {
+	// Given: A legacy user whose rehashed password cannot be stored
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, mockRefreshTokenRepo, new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
+
+	app := fiber.New()
+	app.Post("/auth/login", handler.Login)
+
+	user := &models.User{
+		Model:     gorm.Model{ID: 1},
+		FirstName: "Legacy",
+		LastName:  "User",
+		Email:     "legacy@example.com",
+		Password:  legacySHA256("password123"),
+	}
+	mockUserRepo.On("FindByEmail", mock.Anything, "legacy@example.com").Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.User")).Return(errors.New("database error"))
+	mockRefreshTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	reqBody := dtos.LoginRequest{
+		Email:    "legacy@example.com",
+		Password: "password123",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the login request
+	resp, err := app.Test(req)
+
+	// Then: The login should still succeed
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUserRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthHandler_Register_MailFailureStillRegisters_Unit(t *testing.T) {
+	// Given: A mail sender that cannot deliver
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	sink := mail.NewMemorySink()
+	sink.FailWith(errors.New("smtp unavailable"))
+	handler := NewAuthHandlerWithRepo(mockUserRepo, mockRefreshTokenRepo, new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), sink)
+
+	app := fiber.New()
+	app.Post("/auth/register", handler.Register)
+
+	mockUserRepo.On("FindByEmail", mock.Anything, "mailfail@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+	mockRefreshTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	body, _ := json.Marshal(dtos.RegisterRequest{FirstName: "Mail", Email: "mailfail@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the registration request
+	resp, err := app.Test(req)
+
+	// Then: The account should still be created so the user can resend later
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
+
+func TestAuthHandler_VerifyEmail_MissingToken_Unit(t *testing.T) {
+	// Given: A verification request without a token
+	handler := NewAuthHandlerWithRepo(new(mocks.MockUserRepository), new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
+
+	app := fiber.New()
+	app.Post("/auth/verify-email", handler.VerifyEmail)
+
+	req := httptest.NewRequest("POST", "/auth/verify-email", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAuthHandler_VerifyEmail_UpdateError_Unit(t *testing.T) {
+	// Given: A valid token but a failing repository update
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewAuthHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), mail.NewMemorySink())
+
+	app := fiber.New()
+	app.Post("/auth/verify-email", handler.VerifyEmail)
+
+	user := &models.User{Model: gorm.Model{ID: 7}, Email: "verify@example.com"}
+	token, _ := security.GenerateEmailVerificationToken(user)
+	mockUserRepo.On("FindByID", mock.Anything, uint(7)).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(errors.New("database error"))
+
+	body, _ := json.Marshal(dtos.VerifyEmailRequest{Token: token})
+	req := httptest.NewRequest("POST", "/auth/verify-email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The handler should report a server error
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthHandler_ResendVerification_MailFailure_Unit(t *testing.T) {
+	// Given: An unverified user and a failing mail sender
+	sink := mail.NewMemorySink()
+	sink.FailWith(errors.New("smtp unavailable"))
+	handler := NewAuthHandlerWithRepo(new(mocks.MockUserRepository), new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository), new(mocks.MockPasswordResetTokenRepository), new(mocks.MockRecoveryCodeRepository), newTestLoginThrottle(), sink)
+
+	app := fiber.New()
+	app.Post("/auth/resend-verification", func(c *fiber.Ctx) error {
+		c.Locals("user", &models.User{Model: gorm.Model{ID: 3}, Email: "resend@example.com"})
+		return handler.ResendVerification(c)
+	})
+
+	// When: Asking for a new verification email
+	resp, err := app.Test(httptest.NewRequest("POST", "/auth/resend-verification", nil))
+
+	// Then: The failure should be reported
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func newTestLoginThrottle() *security.LoginThrottle {
+	return security.NewLoginThrottle(redis.NewMemoryAttemptStore(), security.DefaultLoginThrottlePolicy)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -32,6 +33,11 @@ func NewCommentHandlerWithRepo(commentRepo repositories.CommentRepository, userR
 }
 
 func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	var req dtos.CreateCommentRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
@@ -41,15 +47,11 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Content is required"})
 	}
 
-	if req.UserID == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User ID is required"})
-	}
-
 	if req.NewsID == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "News ID is required"})
 	}
 
-	_, err := h.userRepo.FindByID(c.Context(), req.UserID)
+	_, err := h.userRepo.FindByID(c.Context(), userID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
 	}
@@ -59,7 +61,7 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "News not found"})
 	}
 
-	comment := mappers.ToCommentModel(req)
+	comment := mappers.ToCommentModel(req, userID)
 	if err := h.commentRepo.Create(c.Context(), &comment); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create comment"})
 	}
@@ -73,6 +75,11 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 }
 
 func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid comment ID"})
@@ -92,6 +99,10 @@ func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Comment not found"})
 	}
 
+	if comment.UserID != userID && !actorRole(c).CanModerate() {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	comment.Content = req.Content
 
 	if err := h.commentRepo.Update(c.Context(), comment); err != nil {
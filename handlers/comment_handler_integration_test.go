@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -18,8 +19,10 @@ func setupCommentTestApp(db *gorm.DB) *fiber.App {
 	app := fiber.New()
 	commentHandler := NewCommentHandler(db)
 
-	app.Post("/comments", commentHandler.CreateComment)
-	app.Put("/comments/:id", commentHandler.UpdateComment)
+	authRequired := middleware.JWTMiddleware(db)
+
+	app.Post("/comments", authRequired, commentHandler.CreateComment)
+	app.Put("/comments/:id", authRequired, commentHandler.UpdateComment)
 	app.Get("/users/:id/comments", commentHandler.GetCommentsByUserID)
 	app.Get("/news/:id/comments", commentHandler.GetCommentsByNewsID)
 
@@ -46,12 +49,12 @@ func TestCommentHandler_CreateComment_Success(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "This is a test comment",
-		UserID:  user.ID,
 		NewsID:  news.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create comment request
@@ -73,8 +76,10 @@ func TestCommentHandler_CreateComment_InvalidJSON(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.Comment{})
 	app := setupCommentTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader([]byte("invalid")))
+	authenticate(req, actor)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create comment request
@@ -94,12 +99,12 @@ func TestCommentHandler_CreateComment_MissingContent(t *testing.T) {
 	user, news := createTestUserAndNews(db)
 
 	reqBody := dtos.CreateCommentRequest{
-		UserID: user.ID,
 		NewsID: news.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create comment request
@@ -110,8 +115,8 @@ func TestCommentHandler_CreateComment_MissingContent(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestCommentHandler_CreateComment_UserNotFound(t *testing.T) {
-	// Given: A create comment request with non-existent user
+func TestCommentHandler_CreateComment_Unauthenticated(t *testing.T) {
+	// Given: A create request without an authenticated user
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.Comment{})
 	app := setupCommentTestApp(db)
@@ -120,7 +125,6 @@ func TestCommentHandler_CreateComment_UserNotFound(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  999,
 		NewsID:  news.ID,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -131,9 +135,9 @@ func TestCommentHandler_CreateComment_UserNotFound(t *testing.T) {
 	// When: Making the create comment request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with not found
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestCommentHandler_CreateComment_NewsNotFound(t *testing.T) {
@@ -146,12 +150,12 @@ func TestCommentHandler_CreateComment_NewsNotFound(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  user.ID,
 		NewsID:  999,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create comment request
@@ -178,6 +182,7 @@ func TestCommentHandler_UpdateComment_Success(t *testing.T) {
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/comments/%d", comment.ID), bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update comment request
@@ -197,11 +202,13 @@ func TestCommentHandler_UpdateComment_NotFound(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.Comment{})
 	app := setupCommentTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	reqBody := dtos.UpdateCommentRequest{Content: "Updated"}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", "/comments/999", bytes.NewReader(body))
+	authenticate(req, actor)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update request for non-existent comment
@@ -217,11 +224,13 @@ func TestCommentHandler_UpdateComment_InvalidID(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.Comment{})
 	app := setupCommentTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	reqBody := dtos.UpdateCommentRequest{Content: "Updated"}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", "/comments/invalid", bytes.NewReader(body))
+	authenticate(req, actor)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update request with invalid ID
@@ -246,6 +255,7 @@ func TestCommentHandler_UpdateComment_MissingContent(t *testing.T) {
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/comments/%d", comment.ID), bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update comment request
@@ -440,12 +450,12 @@ func TestCommentHandler_CreateComment_ReturnsUserAndNewsInfo(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  user.ID,
 		NewsID:  news.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create comment request
@@ -460,3 +470,31 @@ func TestCommentHandler_CreateComment_ReturnsUserAndNewsInfo(t *testing.T) {
 	assert.Equal(t, "Test User", response.UserName)
 	assert.Equal(t, "Test News", response.NewsTitle)
 }
+
+func TestCommentHandler_UpdateComment_ByOtherUserForbidden(t *testing.T) {
+	// Given: A comment written by one user
+	db := setupTestDB(t)
+	db.AutoMigrate(&models.Comment{})
+	app := setupCommentTestApp(db)
+
+	user, news := createTestUserAndNews(db)
+	other := createSessionTestUser(db, "other@example.com")
+	comment := models.Comment{Content: "Original content", UserID: user.ID, NewsID: news.ID}
+	db.Create(&comment)
+
+	body, _ := json.Marshal(dtos.UpdateCommentRequest{Content: "Tampered"})
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/comments/%d", comment.ID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, other)
+
+	// When: Another user tries to edit it
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden and the comment unchanged
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var stored models.Comment
+	db.First(&stored, comment.ID)
+	assert.Equal(t, "Original content", stored.Content)
+}
@@ -25,7 +25,7 @@ func TestCommentHandler_CreateComment_Success_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	user := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	news := &models.News{Model: gorm.Model{ID: 1}, Title: "Test News"}
@@ -43,7 +43,6 @@ func TestCommentHandler_CreateComment_Success_Unit(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  1,
 		NewsID:  1,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -70,7 +69,7 @@ func TestCommentHandler_CreateComment_InvalidJSON_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	req := httptest.NewRequest("POST", "/comments", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -91,10 +90,9 @@ func TestCommentHandler_CreateComment_MissingContent_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	reqBody := dtos.CreateCommentRequest{
-		UserID: 1,
 		NewsID: 1,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -110,8 +108,8 @@ func TestCommentHandler_CreateComment_MissingContent_Unit(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestCommentHandler_CreateComment_MissingUserID_Unit(t *testing.T) {
-	// Given: A request without user ID
+func TestCommentHandler_CreateComment_Unauthenticated_Unit(t *testing.T) {
+	// Given: A request without an authenticated user
 	mockCommentRepo := new(mocks.MockCommentRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNewsRepo := new(mocks.MockNewsRepository)
@@ -132,9 +130,9 @@ func TestCommentHandler_CreateComment_MissingUserID_Unit(t *testing.T) {
 	// When: Making the create comment request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with bad request
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestCommentHandler_CreateComment_MissingNewsID_Unit(t *testing.T) {
@@ -145,11 +143,10 @@ func TestCommentHandler_CreateComment_MissingNewsID_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  1,
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -172,13 +169,12 @@ func TestCommentHandler_CreateComment_UserNotFound_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(999, models.RoleMember), handler.CreateComment)
 
 	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  999,
 		NewsID:  1,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -203,7 +199,7 @@ func TestCommentHandler_CreateComment_NewsNotFound_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	user := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(user, nil)
@@ -211,7 +207,6 @@ func TestCommentHandler_CreateComment_NewsNotFound_Unit(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  1,
 		NewsID:  999,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -237,7 +232,7 @@ func TestCommentHandler_CreateComment_DatabaseError_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Post("/comments", handler.CreateComment)
+	app.Post("/comments", withActor(1, models.RoleMember), handler.CreateComment)
 
 	user := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	news := &models.News{Model: gorm.Model{ID: 1}, Title: "Test News"}
@@ -247,7 +242,6 @@ func TestCommentHandler_CreateComment_DatabaseError_Unit(t *testing.T) {
 
 	reqBody := dtos.CreateCommentRequest{
 		Content: "Test comment",
-		UserID:  1,
 		NewsID:  1,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -272,7 +266,7 @@ func TestCommentHandler_UpdateComment_Success_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Put("/comments/:id", handler.UpdateComment)
+	app.Put("/comments/:id", withActor(1, models.RoleMember), handler.UpdateComment)
 
 	user := models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	news := models.News{Model: gorm.Model{ID: 1}, Title: "Test News"}
@@ -312,7 +306,7 @@ func TestCommentHandler_UpdateComment_NotFound_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Put("/comments/:id", handler.UpdateComment)
+	app.Put("/comments/:id", withActor(1, models.RoleMember), handler.UpdateComment)
 
 	mockCommentRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -341,7 +335,7 @@ func TestCommentHandler_UpdateComment_InvalidID_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Put("/comments/:id", handler.UpdateComment)
+	app.Put("/comments/:id", withActor(1, models.RoleMember), handler.UpdateComment)
 
 	reqBody := dtos.UpdateCommentRequest{
 		Content: "Updated content",
@@ -367,13 +361,15 @@ func TestCommentHandler_UpdateComment_MissingContent_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Put("/comments/:id", handler.UpdateComment)
+	app.Put("/comments/:id", withActor(1, models.RoleMember), handler.UpdateComment)
 
 	user := models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	news := models.News{Model: gorm.Model{ID: 1}, Title: "Test News"}
 	existingComment := &models.Comment{
 		Model:   gorm.Model{ID: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 		Content: "Old content",
+		UserID:  1,
+		NewsID:  1,
 		User:    user,
 		News:    news,
 	}
@@ -403,13 +399,15 @@ func TestCommentHandler_UpdateComment_DatabaseError_Unit(t *testing.T) {
 	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
 
 	app := fiber.New()
-	app.Put("/comments/:id", handler.UpdateComment)
+	app.Put("/comments/:id", withActor(1, models.RoleMember), handler.UpdateComment)
 
 	user := models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	news := models.News{Model: gorm.Model{ID: 1}, Title: "Test News"}
 	existingComment := &models.Comment{
 		Model:   gorm.Model{ID: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 		Content: "Old content",
+		UserID:  1,
+		NewsID:  1,
 		User:    user,
 		News:    news,
 	}
@@ -593,3 +591,56 @@ func TestNewCommentHandler_Unit(t *testing.T) {
 	// Then: The handler should be created
 	assert.NotNil(t, handler)
 }
+
+func TestCommentHandler_UpdateComment_NotAuthor_Unit(t *testing.T) {
+	// Given: A comment written by another user
+	mockCommentRepo := new(mocks.MockCommentRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNewsRepo := new(mocks.MockNewsRepository)
+	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
+
+	app := fiber.New()
+	app.Put("/comments/:id", withActor(2, models.RoleMember), handler.UpdateComment)
+
+	existingComment := &models.Comment{Model: gorm.Model{ID: 1}, Content: "Old content", UserID: 1, NewsID: 1}
+	mockCommentRepo.On("FindByID", mock.Anything, uint(1)).Return(existingComment, nil)
+
+	body, _ := json.Marshal(dtos.UpdateCommentRequest{Content: "Updated content"})
+	req := httptest.NewRequest("PUT", "/comments/1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update comment request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockCommentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCommentHandler_UpdateComment_ModeratorCanEdit_Unit(t *testing.T) {
+	// Given: A comment written by another user and an admin editing it
+	mockCommentRepo := new(mocks.MockCommentRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNewsRepo := new(mocks.MockNewsRepository)
+	handler := NewCommentHandlerWithRepo(mockCommentRepo, mockUserRepo, mockNewsRepo)
+
+	app := fiber.New()
+	app.Put("/comments/:id", withActor(2, models.RoleAdmin), handler.UpdateComment)
+
+	existingComment := &models.Comment{Model: gorm.Model{ID: 1}, Content: "Old content", UserID: 1, NewsID: 1}
+	mockCommentRepo.On("FindByID", mock.Anything, uint(1)).Return(existingComment, nil)
+	mockCommentRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Comment")).Return(nil)
+
+	body, _ := json.Marshal(dtos.UpdateCommentRequest{Content: "Moderated content"})
+	req := httptest.NewRequest("PUT", "/comments/1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update comment request
+	resp, err := app.Test(req)
+
+	// Then: The request should succeed
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockCommentRepo.AssertExpectations(t)
+}
@@ -41,24 +41,25 @@ func NewFriendRequestHandlerWithRepo(friendRequestRepo repositories.FriendReques
 }
 
 func (h *FriendRequestHandler) CreateFriendRequest(c *fiber.Ctx) error {
+	senderID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	var req dtos.CreateFriendRequestRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
 	}
 
-	if req.SenderID == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Sender ID is required"))
-	}
-
 	if req.ReceiverID == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Receiver ID is required"))
 	}
 
-	if req.SenderID == req.ReceiverID {
+	if senderID == req.ReceiverID {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Cannot send friend request to yourself"))
 	}
 
-	_, err := h.userRepo.FindByID(c.Context(), req.SenderID)
+	_, err := h.userRepo.FindByID(c.Context(), senderID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.BadRequest("Sender not found"))
 	}
@@ -68,7 +69,7 @@ func (h *FriendRequestHandler) CreateFriendRequest(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(utils.BadRequest("Receiver not found"))
 	}
 
-	existingRequest, _ := h.friendRequestRepo.FindByUsers(c.Context(), req.SenderID, req.ReceiverID)
+	existingRequest, _ := h.friendRequestRepo.FindByUsers(c.Context(), senderID, req.ReceiverID)
 	if existingRequest != nil {
 		if existingRequest.Status == models.StatusPending {
 			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Friend request already pending"))
@@ -78,7 +79,7 @@ func (h *FriendRequestHandler) CreateFriendRequest(c *fiber.Ctx) error {
 		}
 	}
 
-	friendRequest := mappers.ToFriendRequestModel(req)
+	friendRequest := mappers.ToFriendRequestModel(req, senderID)
 	if err := h.friendRequestRepo.Create(c.Context(), &friendRequest); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create friend request"))
 	}
@@ -92,6 +93,11 @@ func (h *FriendRequestHandler) CreateFriendRequest(c *fiber.Ctx) error {
 }
 
 func (h *FriendRequestHandler) AcceptFriendRequest(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidFriendRequestID))
@@ -102,6 +108,10 @@ func (h *FriendRequestHandler) AcceptFriendRequest(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(utils.BadRequest(errFriendRequestNotFound))
 	}
 
+	if friendRequest.ReceiverID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	if friendRequest.Status != models.StatusPending {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errFriendRequestNotPending))
 	}
@@ -120,6 +130,11 @@ func (h *FriendRequestHandler) AcceptFriendRequest(c *fiber.Ctx) error {
 }
 
 func (h *FriendRequestHandler) DeclineFriendRequest(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidFriendRequestID))
@@ -130,6 +145,10 @@ func (h *FriendRequestHandler) DeclineFriendRequest(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(utils.BadRequest(errFriendRequestNotFound))
 	}
 
+	if friendRequest.ReceiverID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	if friendRequest.Status != models.StatusPending {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errFriendRequestNotPending))
 	}
@@ -148,16 +167,25 @@ func (h *FriendRequestHandler) DeclineFriendRequest(c *fiber.Ctx) error {
 }
 
 func (h *FriendRequestHandler) DeleteFriendRequest(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidFriendRequestID))
 	}
 
-	_, err = h.friendRequestRepo.FindByID(c.Context(), uint(id))
+	friendRequest, err := h.friendRequestRepo.FindByID(c.Context(), uint(id))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.BadRequest(errFriendRequestNotFound))
 	}
 
+	if friendRequest.SenderID != userID && friendRequest.ReceiverID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	if err := h.friendRequestRepo.Delete(c.Context(), uint(id)); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete friend request"))
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -18,10 +19,12 @@ func setupFriendRequestTestApp(db *gorm.DB) *fiber.App {
 	app := fiber.New()
 	friendRequestHandler := NewFriendRequestHandler(db)
 
-	app.Post("/friend-requests", friendRequestHandler.CreateFriendRequest)
-	app.Put("/friend-requests/:id/accept", friendRequestHandler.AcceptFriendRequest)
-	app.Put("/friend-requests/:id/decline", friendRequestHandler.DeclineFriendRequest)
-	app.Delete("/friend-requests/:id", friendRequestHandler.DeleteFriendRequest)
+	authRequired := middleware.JWTMiddleware(db)
+
+	app.Post("/friend-requests", authRequired, friendRequestHandler.CreateFriendRequest)
+	app.Put("/friend-requests/:id/accept", authRequired, friendRequestHandler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/decline", authRequired, friendRequestHandler.DeclineFriendRequest)
+	app.Delete("/friend-requests/:id", authRequired, friendRequestHandler.DeleteFriendRequest)
 	app.Get("/users/:id/friend-requests/sent", friendRequestHandler.GetSentFriendRequests)
 	app.Get("/users/:id/friend-requests/received", friendRequestHandler.GetReceivedFriendRequests)
 	app.Get("/users/:id/friends", friendRequestHandler.GetFriends)
@@ -48,12 +51,12 @@ func TestFriendRequestHandler_CreateFriendRequest_Success(t *testing.T) {
 	user1, user2 := createTestUsers(db)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: user2.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -77,8 +80,10 @@ func TestFriendRequestHandler_CreateFriendRequest_InvalidJSON(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader([]byte("invalid")))
+	authenticate(req, actor)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -89,8 +94,8 @@ func TestFriendRequestHandler_CreateFriendRequest_InvalidJSON(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestFriendRequestHandler_CreateFriendRequest_MissingSenderID(t *testing.T) {
-	// Given: A request without sender ID
+func TestFriendRequestHandler_CreateFriendRequest_Unauthenticated(t *testing.T) {
+	// Given: A create request without an authenticated user
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
@@ -108,9 +113,9 @@ func TestFriendRequestHandler_CreateFriendRequest_MissingSenderID(t *testing.T)
 	// When: Making the create friend request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with bad request
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestFriendRequestHandler_CreateFriendRequest_MissingReceiverID(t *testing.T) {
@@ -121,12 +126,11 @@ func TestFriendRequestHandler_CreateFriendRequest_MissingReceiverID(t *testing.T
 
 	user1, _ := createTestUsers(db)
 
-	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID: user1.ID,
-	}
+	reqBody := dtos.CreateFriendRequestRequest{}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -146,12 +150,12 @@ func TestFriendRequestHandler_CreateFriendRequest_SelfRequest(t *testing.T) {
 	user1, _ := createTestUsers(db)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: user1.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -162,31 +166,6 @@ func TestFriendRequestHandler_CreateFriendRequest_SelfRequest(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestFriendRequestHandler_CreateFriendRequest_SenderNotFound(t *testing.T) {
-	// Given: A non-existent sender
-	db := setupTestDB(t)
-	db.AutoMigrate(&models.FriendRequest{})
-	app := setupFriendRequestTestApp(db)
-
-	_, user2 := createTestUsers(db)
-
-	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   999,
-		ReceiverID: user2.ID,
-	}
-	body, _ := json.Marshal(reqBody)
-
-	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-
-	// When: Making the create friend request
-	resp, err := app.Test(req)
-
-	// Then: The request should fail with not found
-	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
-}
-
 func TestFriendRequestHandler_CreateFriendRequest_ReceiverNotFound(t *testing.T) {
 	// Given: A non-existent receiver
 	db := setupTestDB(t)
@@ -196,12 +175,12 @@ func TestFriendRequestHandler_CreateFriendRequest_ReceiverNotFound(t *testing.T)
 	user1, _ := createTestUsers(db)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: 999,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -222,12 +201,12 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyPending(t *testing.T) {
 	db.Create(&models.FriendRequest{SenderID: user1.ID, ReceiverID: user2.ID, Status: models.StatusPending})
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: user2.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -248,12 +227,12 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyFriends(t *testing.T) {
 	db.Create(&models.FriendRequest{SenderID: user1.ID, ReceiverID: user2.ID, Status: models.StatusAccepted})
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: user2.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -274,12 +253,12 @@ func TestFriendRequestHandler_CreateFriendRequest_ReverseAlreadyPending(t *testi
 	db.Create(&models.FriendRequest{SenderID: user2.ID, ReceiverID: user1.ID, Status: models.StatusPending})
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   user1.ID,
 		ReceiverID: user2.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create friend request
@@ -301,6 +280,7 @@ func TestFriendRequestHandler_AcceptFriendRequest_Success(t *testing.T) {
 	db.Create(&friendRequest)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/friend-requests/%d/accept", friendRequest.ID), nil)
+	authenticate(req, user2)
 
 	// When: Making the accept friend request
 	resp, err := app.Test(req)
@@ -319,8 +299,10 @@ func TestFriendRequestHandler_AcceptFriendRequest_NotFound(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("PUT", "/friend-requests/999/accept", nil)
+	authenticate(req, actor)
 
 	// When: Making the accept friend request
 	resp, err := app.Test(req)
@@ -335,8 +317,10 @@ func TestFriendRequestHandler_AcceptFriendRequest_InvalidID(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("PUT", "/friend-requests/invalid/accept", nil)
+	authenticate(req, actor)
 
 	// When: Making the accept friend request
 	resp, err := app.Test(req)
@@ -357,6 +341,7 @@ func TestFriendRequestHandler_AcceptFriendRequest_NotPending(t *testing.T) {
 	db.Create(&friendRequest)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/friend-requests/%d/accept", friendRequest.ID), nil)
+	authenticate(req, user2)
 
 	// When: Making the accept friend request
 	resp, err := app.Test(req)
@@ -377,6 +362,7 @@ func TestFriendRequestHandler_DeclineFriendRequest_Success(t *testing.T) {
 	db.Create(&friendRequest)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/friend-requests/%d/decline", friendRequest.ID), nil)
+	authenticate(req, user2)
 
 	// When: Making the decline friend request
 	resp, err := app.Test(req)
@@ -395,8 +381,10 @@ func TestFriendRequestHandler_DeclineFriendRequest_NotFound(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("PUT", "/friend-requests/999/decline", nil)
+	authenticate(req, actor)
 
 	// When: Making the decline friend request
 	resp, err := app.Test(req)
@@ -411,8 +399,10 @@ func TestFriendRequestHandler_DeclineFriendRequest_InvalidID(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("PUT", "/friend-requests/invalid/decline", nil)
+	authenticate(req, actor)
 
 	// When: Making the decline friend request
 	resp, err := app.Test(req)
@@ -433,6 +423,7 @@ func TestFriendRequestHandler_DeclineFriendRequest_NotPending(t *testing.T) {
 	db.Create(&friendRequest)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/friend-requests/%d/decline", friendRequest.ID), nil)
+	authenticate(req, user2)
 
 	// When: Making the decline friend request
 	resp, err := app.Test(req)
@@ -453,6 +444,7 @@ func TestFriendRequestHandler_DeleteFriendRequest_Success(t *testing.T) {
 	db.Create(&friendRequest)
 
 	req := httptest.NewRequest("DELETE", fmt.Sprintf("/friend-requests/%d", friendRequest.ID), nil)
+	authenticate(req, user1)
 
 	// When: Making the delete friend request
 	resp, err := app.Test(req)
@@ -472,8 +464,10 @@ func TestFriendRequestHandler_DeleteFriendRequest_NotFound(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("DELETE", "/friend-requests/999", nil)
+	authenticate(req, actor)
 
 	// When: Making the delete friend request
 	resp, err := app.Test(req)
@@ -488,8 +482,10 @@ func TestFriendRequestHandler_DeleteFriendRequest_InvalidID(t *testing.T) {
 	db := setupTestDB(t)
 	db.AutoMigrate(&models.FriendRequest{})
 	app := setupFriendRequestTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("DELETE", "/friend-requests/invalid", nil)
+	authenticate(req, actor)
 
 	// When: Making the delete friend request
 	resp, err := app.Test(req)
@@ -791,3 +787,49 @@ func TestNewFriendRequestHandler(t *testing.T) {
 	// Then: The handler should be created
 	assert.NotNil(t, handler)
 }
+
+func TestFriendRequestHandler_AcceptFriendRequest_BySenderForbidden(t *testing.T) {
+	// Given: A pending friend request
+	db := setupTestDB(t)
+	db.AutoMigrate(&models.FriendRequest{})
+	app := setupFriendRequestTestApp(db)
+
+	user1, user2 := createTestUsers(db)
+	friendRequest := models.FriendRequest{SenderID: user1.ID, ReceiverID: user2.ID, Status: models.StatusPending}
+	db.Create(&friendRequest)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/friend-requests/%d/accept", friendRequest.ID), nil)
+	authenticate(req, user1)
+
+	// When: The sender tries to accept their own request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden and remain pending
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var stored models.FriendRequest
+	db.First(&stored, friendRequest.ID)
+	assert.Equal(t, models.StatusPending, stored.Status)
+}
+
+func TestFriendRequestHandler_CreateFriendRequest_UsesAuthenticatedSender(t *testing.T) {
+	// Given: Two users and a body that tries to set another sender
+	db := setupTestDB(t)
+	db.AutoMigrate(&models.FriendRequest{})
+	app := setupFriendRequestTestApp(db)
+
+	user1, user2 := createTestUsers(db)
+	body := []byte(fmt.Sprintf(`{"sender_id": %d, "receiver_id": %d}`, user2.ID, user1.ID))
+
+	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, user1)
+
+	// When: Making the create friend request
+	resp, err := app.Test(req)
+
+	// Then: The token user should be the sender, making this a self request
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
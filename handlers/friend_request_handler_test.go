@@ -24,7 +24,7 @@ func TestFriendRequestHandler_CreateFriendRequest_Success_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	sender := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	receiver := &models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane", LastName: "Smith"}
@@ -42,7 +42,6 @@ func TestFriendRequestHandler_CreateFriendRequest_Success_Unit(t *testing.T) {
 	}, nil)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 2,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -67,7 +66,7 @@ func TestFriendRequestHandler_CreateFriendRequest_InvalidJSON_Unit(t *testing.T)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -80,8 +79,8 @@ func TestFriendRequestHandler_CreateFriendRequest_InvalidJSON_Unit(t *testing.T)
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestFriendRequestHandler_CreateFriendRequest_MissingSenderID_Unit(t *testing.T) {
-	// Given: A request without sender ID
+func TestFriendRequestHandler_CreateFriendRequest_Unauthenticated_Unit(t *testing.T) {
+	// Given: A request without an authenticated user
 	mockFriendRequestRepo := new(mocks.MockFriendRequestRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
@@ -100,9 +99,9 @@ func TestFriendRequestHandler_CreateFriendRequest_MissingSenderID_Unit(t *testin
 	// When: Making the create friend request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with bad request
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestFriendRequestHandler_CreateFriendRequest_MissingReceiverID_Unit(t *testing.T) {
@@ -112,11 +111,9 @@ func TestFriendRequestHandler_CreateFriendRequest_MissingReceiverID_Unit(t *test
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
-	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID: 1,
-	}
+	reqBody := dtos.CreateFriendRequestRequest{}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/friend-requests", bytes.NewReader(body))
@@ -137,10 +134,9 @@ func TestFriendRequestHandler_CreateFriendRequest_SelfRequest_Unit(t *testing.T)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 1,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -163,12 +159,11 @@ func TestFriendRequestHandler_CreateFriendRequest_SenderNotFound_Unit(t *testing
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(999, models.RoleMember), handler.CreateFriendRequest)
 
 	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   999,
 		ReceiverID: 2,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -192,14 +187,13 @@ func TestFriendRequestHandler_CreateFriendRequest_ReceiverNotFound_Unit(t *testi
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	sender := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(sender, nil)
 	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 999,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -223,7 +217,7 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyPending_Unit(t *testing
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	sender := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	receiver := &models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane"}
@@ -234,7 +228,6 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyPending_Unit(t *testing
 	}, nil)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 2,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -257,7 +250,7 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyFriends_Unit(t *testing
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	sender := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	receiver := &models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane"}
@@ -268,7 +261,6 @@ func TestFriendRequestHandler_CreateFriendRequest_AlreadyFriends_Unit(t *testing
 	}, nil)
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 2,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -291,7 +283,7 @@ func TestFriendRequestHandler_CreateFriendRequest_DatabaseError_Unit(t *testing.
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/friend-requests", handler.CreateFriendRequest)
+	app.Post("/friend-requests", withActor(1, models.RoleMember), handler.CreateFriendRequest)
 
 	sender := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John"}
 	receiver := &models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane"}
@@ -301,7 +293,6 @@ func TestFriendRequestHandler_CreateFriendRequest_DatabaseError_Unit(t *testing.
 	mockFriendRequestRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.FriendRequest")).Return(errors.New("database error"))
 
 	reqBody := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 2,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -325,7 +316,7 @@ func TestFriendRequestHandler_AcceptFriendRequest_Success_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/accept", handler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/accept", withActor(2, models.RoleMember), handler.AcceptFriendRequest)
 
 	sender := models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	receiver := models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane", LastName: "Smith"}
@@ -358,7 +349,7 @@ func TestFriendRequestHandler_AcceptFriendRequest_NotFound_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/accept", handler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/accept", withActor(2, models.RoleMember), handler.AcceptFriendRequest)
 
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -380,7 +371,7 @@ func TestFriendRequestHandler_AcceptFriendRequest_InvalidID_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/accept", handler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/accept", withActor(2, models.RoleMember), handler.AcceptFriendRequest)
 
 	req := httptest.NewRequest("PUT", "/friend-requests/invalid/accept", nil)
 
@@ -399,11 +390,13 @@ func TestFriendRequestHandler_AcceptFriendRequest_NotPending_Unit(t *testing.T)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/accept", handler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/accept", withActor(2, models.RoleMember), handler.AcceptFriendRequest)
 
 	friendRequest := &models.FriendRequest{
-		Model:  gorm.Model{ID: 1},
-		Status: models.StatusAccepted,
+		Model:      gorm.Model{ID: 1},
+		SenderID:   1,
+		ReceiverID: 2,
+		Status:     models.StatusAccepted,
 	}
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
 
@@ -424,11 +417,13 @@ func TestFriendRequestHandler_AcceptFriendRequest_DatabaseError_Unit(t *testing.
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/accept", handler.AcceptFriendRequest)
+	app.Put("/friend-requests/:id/accept", withActor(2, models.RoleMember), handler.AcceptFriendRequest)
 
 	friendRequest := &models.FriendRequest{
-		Model:  gorm.Model{ID: 1},
-		Status: models.StatusPending,
+		Model:      gorm.Model{ID: 1},
+		SenderID:   1,
+		ReceiverID: 2,
+		Status:     models.StatusPending,
 	}
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
 	mockFriendRequestRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.FriendRequest")).Return(errors.New("database error"))
@@ -451,7 +446,7 @@ func TestFriendRequestHandler_DeclineFriendRequest_Success_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/decline", handler.DeclineFriendRequest)
+	app.Put("/friend-requests/:id/decline", withActor(2, models.RoleMember), handler.DeclineFriendRequest)
 
 	sender := models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	receiver := models.User{Model: gorm.Model{ID: 2}, FirstName: "Jane", LastName: "Smith"}
@@ -484,7 +479,7 @@ func TestFriendRequestHandler_DeclineFriendRequest_NotFound_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/decline", handler.DeclineFriendRequest)
+	app.Put("/friend-requests/:id/decline", withActor(2, models.RoleMember), handler.DeclineFriendRequest)
 
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -506,7 +501,7 @@ func TestFriendRequestHandler_DeclineFriendRequest_InvalidID_Unit(t *testing.T)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/decline", handler.DeclineFriendRequest)
+	app.Put("/friend-requests/:id/decline", withActor(2, models.RoleMember), handler.DeclineFriendRequest)
 
 	req := httptest.NewRequest("PUT", "/friend-requests/invalid/decline", nil)
 
@@ -525,11 +520,13 @@ func TestFriendRequestHandler_DeclineFriendRequest_NotPending_Unit(t *testing.T)
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Put("/friend-requests/:id/decline", handler.DeclineFriendRequest)
+	app.Put("/friend-requests/:id/decline", withActor(2, models.RoleMember), handler.DeclineFriendRequest)
 
 	friendRequest := &models.FriendRequest{
-		Model:  gorm.Model{ID: 1},
-		Status: models.StatusDeclined,
+		Model:      gorm.Model{ID: 1},
+		SenderID:   1,
+		ReceiverID: 2,
+		Status:     models.StatusDeclined,
 	}
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
 
@@ -550,9 +547,9 @@ func TestFriendRequestHandler_DeleteFriendRequest_Success_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Delete("/friend-requests/:id", handler.DeleteFriendRequest)
+	app.Delete("/friend-requests/:id", withActor(2, models.RoleMember), handler.DeleteFriendRequest)
 
-	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}}
+	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}, SenderID: 1, ReceiverID: 2}
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
 	mockFriendRequestRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
 
@@ -574,7 +571,7 @@ func TestFriendRequestHandler_DeleteFriendRequest_NotFound_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Delete("/friend-requests/:id", handler.DeleteFriendRequest)
+	app.Delete("/friend-requests/:id", withActor(2, models.RoleMember), handler.DeleteFriendRequest)
 
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -596,7 +593,7 @@ func TestFriendRequestHandler_DeleteFriendRequest_InvalidID_Unit(t *testing.T) {
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Delete("/friend-requests/:id", handler.DeleteFriendRequest)
+	app.Delete("/friend-requests/:id", withActor(2, models.RoleMember), handler.DeleteFriendRequest)
 
 	req := httptest.NewRequest("DELETE", "/friend-requests/invalid", nil)
 
@@ -615,9 +612,9 @@ func TestFriendRequestHandler_DeleteFriendRequest_DatabaseError_Unit(t *testing.
 	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Delete("/friend-requests/:id", handler.DeleteFriendRequest)
+	app.Delete("/friend-requests/:id", withActor(2, models.RoleMember), handler.DeleteFriendRequest)
 
-	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}}
+	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}, SenderID: 1, ReceiverID: 2}
 	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
 	mockFriendRequestRepo.On("Delete", mock.Anything, uint(1)).Return(errors.New("database error"))
 
@@ -879,3 +876,72 @@ func TestNewFriendRequestHandlerWithRepo_Unit(t *testing.T) {
 	// Then: The handler should be created
 	assert.NotNil(t, handler)
 }
+
+func TestFriendRequestHandler_AcceptFriendRequest_NotReceiver_Unit(t *testing.T) {
+	// Given: A pending friend request and the sender trying to accept it
+	mockFriendRequestRepo := new(mocks.MockFriendRequestRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
+
+	app := fiber.New()
+	app.Put("/friend-requests/:id/accept", withActor(1, models.RoleMember), handler.AcceptFriendRequest)
+
+	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}, SenderID: 1, ReceiverID: 2, Status: models.StatusPending}
+	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
+
+	req := httptest.NewRequest("PUT", "/friend-requests/1/accept", nil)
+
+	// When: Making the accept request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockFriendRequestRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestFriendRequestHandler_DeclineFriendRequest_NotReceiver_Unit(t *testing.T) {
+	// Given: A pending friend request and an unrelated user trying to decline it
+	mockFriendRequestRepo := new(mocks.MockFriendRequestRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
+
+	app := fiber.New()
+	app.Put("/friend-requests/:id/decline", withActor(3, models.RoleMember), handler.DeclineFriendRequest)
+
+	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}, SenderID: 1, ReceiverID: 2, Status: models.StatusPending}
+	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
+
+	req := httptest.NewRequest("PUT", "/friend-requests/1/decline", nil)
+
+	// When: Making the decline request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockFriendRequestRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestFriendRequestHandler_DeleteFriendRequest_NotParticipant_Unit(t *testing.T) {
+	// Given: A friend request between two other users
+	mockFriendRequestRepo := new(mocks.MockFriendRequestRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewFriendRequestHandlerWithRepo(mockFriendRequestRepo, mockUserRepo)
+
+	app := fiber.New()
+	app.Delete("/friend-requests/:id", withActor(3, models.RoleMember), handler.DeleteFriendRequest)
+
+	friendRequest := &models.FriendRequest{Model: gorm.Model{ID: 1}, SenderID: 1, ReceiverID: 2}
+	mockFriendRequestRepo.On("FindByID", mock.Anything, uint(1)).Return(friendRequest, nil)
+
+	req := httptest.NewRequest("DELETE", "/friend-requests/1", nil)
+
+	// When: Making the delete request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockFriendRequestRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
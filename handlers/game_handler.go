@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
 	"github.com/gofiber/fiber/v2"
@@ -11,23 +18,160 @@ import (
 
 type GameHandler struct {
 	gameRepo repositories.GameRepository
+	recorder *audit.Recorder
 }
 
 func NewGameHandler(db *gorm.DB) *GameHandler {
-	return &GameHandler{gameRepo: repositories.NewGameRepository(db)}
+	return NewGameHandlerWithRepo(repositories.NewGameRepository(db))
 }
 
 func NewGameHandlerWithRepo(gameRepo repositories.GameRepository) *GameHandler {
-	return &GameHandler{gameRepo: gameRepo}
+	return &GameHandler{gameRepo: gameRepo, recorder: audit.DefaultRecorder}
 }
 
+const (
+	defaultGamePageLimit   = 20
+	maxGamePageLimit       = 100
+	defaultGameSearchLimit = 10
+	maxGameSearchLimit     = 50
+)
+
+// GetAllGames lists one page of games. See parseGameQuery for the
+// supported filters and sort keys.
 func (h *GameHandler) GetAllGames(c *fiber.Ctx) error {
-	games, err := h.gameRepo.FindAll(c.Context())
+	query, err := parseGameQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	games, total, err := h.gameRepo.FindPage(c.Context(), query)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch games"))
 	}
 
-	return c.JSON(mappers.ToGameResponseList(games))
+	return c.JSON(dtos.GamePageResponse{
+		Items: mappers.ToGameResponseList(games),
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: total,
+	})
+}
+
+// parseGameQuery reads page, limit, the filters category, complexity,
+// players, maxPlaytime, minAge, publisher, minYear, maxYear, minRating,
+// maxRating and tags, a comma-separated list of tag names a game must all
+// carry, and sort as a comma-separated list of fields, each optionally
+// prefixed with "-" for descending order.
+func parseGameQuery(c *fiber.Ctx) (models.GameQuery, error) {
+	query := models.GameQuery{
+		Category:   c.Query("category"),
+		Complexity: c.Query("complexity"),
+		Publisher:  strings.TrimSpace(c.Query("publisher")),
+	}
+
+	if query.Category != "" && !models.IsValidCategory(models.GameCategory(query.Category)) {
+		return query, fmt.Errorf("unknown category %q", query.Category)
+	}
+	if query.Complexity != "" && !models.IsValidComplexity(models.GameComplexity(query.Complexity)) {
+		return query, fmt.Errorf("unknown complexity %q", query.Complexity)
+	}
+
+	ints := []struct {
+		name     string
+		target   *int
+		fallback int
+		min      int
+		max      int
+	}{
+		{"page", &query.Page, 1, 1, 0},
+		{"limit", &query.Limit, defaultGamePageLimit, 1, maxGamePageLimit},
+		{"players", &query.Players, 0, 1, 0},
+		{"maxPlaytime", &query.MaxPlaytime, 0, 1, 0},
+		{"minAge", &query.MinAge, 0, 1, 0},
+		{"minYear", &query.MinYear, 0, 1, 0},
+		{"maxYear", &query.MaxYear, 0, 1, 0},
+	}
+	for _, param := range ints {
+		*param.target = param.fallback
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < param.min || (param.max != 0 && parsed > param.max) {
+			if param.max != 0 {
+				return query, fmt.Errorf("%s must be between %d and %d", param.name, param.min, param.max)
+			}
+			return query, fmt.Errorf("%s must be a number of at least %d", param.name, param.min)
+		}
+		*param.target = parsed
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *float64
+	}{{"minRating", &query.MinRating}, {"maxRating", &query.MaxRating}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 10 {
+			return query, fmt.Errorf("%s must be between 0 and 10", param.name)
+		}
+		*param.target = parsed
+	}
+
+	if tags := c.Query("tags"); tags != "" {
+		seen := map[string]bool{}
+		for _, tag := range strings.Split(tags, ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				query.Tags = append(query.Tags, tag)
+			}
+		}
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(field, "-")
+			if _, ok := models.GameSortColumns[field]; !ok {
+				return query, fmt.Errorf("cannot sort by %q", field)
+			}
+			query.Sort = append(query.Sort, models.GameSort{Field: field, Desc: desc})
+		}
+	}
+
+	return query, nil
+}
+
+// SearchGames ranks games by how well their name, publisher and description
+// match q. Words match by prefix and small typos are corrected, so it can
+// back an autocomplete field.
+func (h *GameHandler) SearchGames(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("q is required"))
+	}
+
+	limit := c.QueryInt("limit", defaultGameSearchLimit)
+	if limit < 1 || limit > maxGameSearchLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(fmt.Sprintf("limit must be between 1 and %d", maxGameSearchLimit)))
+	}
+
+	games, corrected, err := h.gameRepo.Search(c.Context(), query, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to search games"))
+	}
+
+	return c.JSON(dtos.GameSearchResponse{
+		Query:          query,
+		CorrectedQuery: corrected,
+		Items:          mappers.ToGameResponseList(games),
+	})
 }
 
 func (h *GameHandler) GetGameByID(c *fiber.Ctx) error {
@@ -51,9 +195,14 @@ func (h *GameHandler) CreateGame(c *fiber.Ctx) error {
 	game := mappers.ToGameModel(req)
 
 	if err := h.gameRepo.Create(c.Context(), &game); err != nil {
+		if message, ok := baseGameError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(message))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create game"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionCreated, game.ID, nil, &game)
+
 	return c.Status(fiber.StatusCreated).JSON(mappers.ToGameResponse(&game))
 }
 
@@ -64,6 +213,7 @@ func (h *GameHandler) UpdateGame(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
 	}
+	before := audit.Snapshot(game)
 
 	var req dtos.CreateGameRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -73,9 +223,14 @@ func (h *GameHandler) UpdateGame(c *fiber.Ctx) error {
 	updatedGame := mappers.UpdateGameFromRequest(game, req)
 
 	if err := h.gameRepo.Update(c.Context(), updatedGame); err != nil {
+		if message, ok := baseGameError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(message))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update game"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionUpdated, updatedGame.ID, before, updatedGame)
+
 	return c.JSON(mappers.ToGameResponse(updatedGame))
 }
 
@@ -88,8 +243,27 @@ func (h *GameHandler) DeleteGame(c *fiber.Ctx) error {
 	}
 
 	if err := h.gameRepo.Delete(c.Context(), game.ID); err != nil {
+		if errors.Is(err, repositories.ErrGameHasExpansions) {
+			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Delete or detach the game's expansions first"))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete game"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionDeleted, game.ID, game, nil)
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// baseGameError explains why the repository refused a game's base game.
+func baseGameError(err error) (string, bool) {
+	for _, rule := range []error{
+		repositories.ErrBaseGameNotFound,
+		repositories.ErrBaseGameIsExpansion,
+		repositories.ErrBaseGameIsSelf,
+	} {
+		if errors.Is(err, rule) {
+			return rule.Error(), true
+		}
+	}
+	return "", false
+}
@@ -41,9 +41,10 @@ func TestGameHandler_GetAllGames_Empty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-	var games []dtos.GameResponse
-	json.NewDecoder(resp.Body).Decode(&games)
-	assert.Empty(t, games)
+	var page dtos.GamePageResponse
+	json.NewDecoder(resp.Body).Decode(&page)
+	assert.Empty(t, page.Items)
+	assert.Zero(t, page.Total)
 }
 
 func TestGameHandler_GetAllGames_WithGames(t *testing.T) {
@@ -63,9 +64,10 @@ func TestGameHandler_GetAllGames_WithGames(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-	var games []dtos.GameResponse
-	json.NewDecoder(resp.Body).Decode(&games)
-	assert.Len(t, games, 2)
+	var page dtos.GamePageResponse
+	json.NewDecoder(resp.Body).Decode(&page)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, int64(2), page.Total)
 }
 
 func TestGameHandler_GetGameByID_Found(t *testing.T) {
@@ -204,6 +206,30 @@ func TestGameHandler_UpdateGame_Success(t *testing.T) {
 	assert.Equal(t, "Updated", response.Name)
 }
 
+func TestGameHandler_UpdateGame_KeepsReviewRating(t *testing.T) {
+	// Given: A game rated by its reviews
+	db := setupTestDB(t)
+	app := setupGameTestApp(db)
+
+	game := models.Game{Name: "Reviewed", Rating: 7.5, RatingCount: 2}
+	db.Create(&game)
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Reviewed", "numberOfPlayers": 4, "rating": 1})
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/games/%d", game.ID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Updating the game, even with a rating in the body
+	resp, err := app.Test(req)
+
+	// Then: The rating computed from reviews should be kept
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var stored models.Game
+	db.First(&stored, game.ID)
+	assert.Equal(t, 7.5, stored.Rating)
+	assert.Equal(t, 2, stored.RatingCount)
+}
+
 func TestGameHandler_UpdateGame_NotFound(t *testing.T) {
 	// Given: An empty database
 	db := setupTestDB(t)
@@ -305,3 +331,89 @@ func TestNewGameHandler(t *testing.T) {
 	// Then: The handler should be created
 	assert.NotNil(t, handler)
 }
+
+func getGamePage(t *testing.T, app *fiber.App, query string) (*dtos.GamePageResponse, int) {
+	resp, err := app.Test(httptest.NewRequest("GET", "/games?"+query, nil))
+	assert.NoError(t, err)
+
+	var page dtos.GamePageResponse
+	json.NewDecoder(resp.Body).Decode(&page)
+	return &page, resp.StatusCode
+}
+
+func gameNames(page *dtos.GamePageResponse) []string {
+	names := make([]string, len(page.Items))
+	for i, game := range page.Items {
+		names[i] = game.Name
+	}
+	return names
+}
+
+func TestGameHandler_GetAllGames_FiltersAndSorts(t *testing.T) {
+	// Given: Games of different kinds
+	db := setupTestDB(t)
+	app := setupGameTestApp(db)
+	db.Create(models.NewGameBuilder().SetName("Catan").SetCategory(models.CategoryStrategy).SetPlayerRange(3, 4).SetPlaytimeMinutes(90).SetPublisher("Kosmos").SetYearPublished(1995).SetRating(7.1).Build())
+	db.Create(models.NewGameBuilder().SetName("Codenames").SetCategory(models.CategoryParty).SetPlayerRange(2, 8).SetPlaytimeMinutes(15).SetPublisher("CGE").SetYearPublished(2015).SetRating(7.6).Build())
+	db.Create(models.NewGameBuilder().SetName("Azul").SetCategory(models.CategoryStrategy).SetPlayerRange(2, 4).SetPlaytimeMinutes(45).SetPublisher("Plan B").SetYearPublished(2017).SetRating(7.8).Build())
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "category", query: "category=Strategy&sort=name", want: []string{"Azul", "Catan"}},
+		{name: "player count", query: "players=2&sort=name", want: []string{"Azul", "Codenames"}},
+		{name: "max playtime", query: "maxPlaytime=45&sort=name", want: []string{"Azul", "Codenames"}},
+		{name: "publisher ignores case", query: "publisher=kosmos", want: []string{"Catan"}},
+		{name: "year range", query: "minYear=2000&maxYear=2016", want: []string{"Codenames"}},
+		{name: "rating range", query: "minRating=7.5&sort=-rating", want: []string{"Azul", "Codenames"}},
+		{name: "descending sort", query: "sort=-yearPublished", want: []string{"Azul", "Codenames", "Catan"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Listing games with the query
+			page, status := getGamePage(t, app, tt.query)
+
+			// Then: Only matching games should be returned in order
+			assert.Equal(t, fiber.StatusOK, status)
+			assert.Equal(t, tt.want, gameNames(page))
+			assert.Equal(t, int64(len(tt.want)), page.Total)
+		})
+	}
+}
+
+func TestGameHandler_GetAllGames_Paginates(t *testing.T) {
+	// Given: Five games
+	db := setupTestDB(t)
+	app := setupGameTestApp(db)
+	for i := 1; i <= 5; i++ {
+		db.Create(&models.Game{Name: fmt.Sprintf("Game %d", i)})
+	}
+
+	// When: Requesting the last page of two
+	page, status := getGamePage(t, app, "page=3&limit=2&sort=name")
+
+	// Then: The remaining game and the full total should be returned
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []string{"Game 5"}, gameNames(page))
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 3, page.Page)
+	assert.Equal(t, 2, page.Limit)
+}
+
+func TestGameHandler_GetAllGames_RejectsInvalidQuery(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupGameTestApp(db)
+
+	for _, query := range []string{"page=0", "limit=500", "players=many", "category=Chess", "minRating=11", "sort=password"} {
+		t.Run(query, func(t *testing.T) {
+			// When: Listing games with an invalid parameter
+			_, status := getGamePage(t, app, query)
+
+			// Then: The request should be rejected
+			assert.Equal(t, fiber.StatusBadRequest, status)
+		})
+	}
+}
@@ -28,7 +28,7 @@ func TestGameHandler_GetAllGames_Success_Unit(t *testing.T) {
 		{Model: gorm.Model{ID: 1}, Name: "Game 1", Category: models.CategoryStrategy},
 		{Model: gorm.Model{ID: 2}, Name: "Game 2", Category: models.CategoryParty},
 	}
-	mockGameRepo.On("FindAll", mock.Anything).Return(games, nil)
+	mockGameRepo.On("FindPage", mock.Anything, mock.Anything).Return(games, int64(2), nil)
 
 	req := httptest.NewRequest("GET", "/games", nil)
 
@@ -49,7 +49,7 @@ func TestGameHandler_GetAllGames_Empty_Unit(t *testing.T) {
 	app := fiber.New()
 	app.Get("/games", handler.GetAllGames)
 
-	mockGameRepo.On("FindAll", mock.Anything).Return([]models.Game{}, nil)
+	mockGameRepo.On("FindPage", mock.Anything, mock.Anything).Return([]models.Game{}, int64(0), nil)
 
 	req := httptest.NewRequest("GET", "/games", nil)
 
@@ -70,7 +70,7 @@ func TestGameHandler_GetAllGames_DatabaseError_Unit(t *testing.T) {
 	app := fiber.New()
 	app.Get("/games", handler.GetAllGames)
 
-	mockGameRepo.On("FindAll", mock.Anything).Return(nil, errors.New("database error"))
+	mockGameRepo.On("FindPage", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("database error"))
 
 	req := httptest.NewRequest("GET", "/games", nil)
 
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/bgg"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Fields a BGG import never sets, so they are left out of its preview.
+var importIgnoredFields = map[string]bool{
+	"ID":          true,
+	"Rating":      true,
+	"RatingCount": true,
+}
+
+// ImportBGGGames upserts games by name from a BoardGameGeek thing document,
+// uploaded as the multipart field "file" or sent as the request body. With
+// dryRun=true nothing is written and the response previews the changes.
+func (h *GameHandler) ImportBGGGames(c *fiber.Ctx) error {
+	document, err := importDocument(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	things, err := bgg.Parse(document)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	names := make([]string, 0, len(things))
+	for _, thing := range things {
+		if thing.Game != nil {
+			names = append(names, thing.Name)
+		}
+	}
+	existing, err := h.gameRepo.FindByNames(c.Context(), names)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to import games"))
+	}
+	byName := make(map[string]*models.Game, len(existing))
+	for i := range existing {
+		byName[strings.ToLower(existing[i].Name)] = &existing[i]
+	}
+
+	response := dtos.GameImportResponse{DryRun: c.QueryBool("dryRun"), Items: make([]dtos.GameImportItem, len(things))}
+	var writes []*models.Game
+	var written []int
+	seen := make(map[string]bool, len(things))
+	for i, thing := range things {
+		item := dtos.GameImportItem{BggID: thing.ID, Name: thing.Name}
+		key := strings.ToLower(thing.Name)
+
+		switch {
+		case thing.Game == nil:
+			item.Action, item.Reason = dtos.GameImportSkipped, thing.Skipped
+		case seen[key]:
+			item.Action, item.Reason = dtos.GameImportSkipped, "game is listed more than once"
+		case byName[key] == nil:
+			item.Action = dtos.GameImportCreate
+			item.Changes = importChanges(nil, thing.Game)
+			writes, written = append(writes, thing.Game), append(written, i)
+		default:
+			current := byName[key]
+			merged := bgg.Merge(current, thing.Game)
+			item.Name, item.GameID = current.Name, current.ID
+			item.Changes = importChanges(current, merged)
+			if len(item.Changes) == 0 {
+				item.Action = dtos.GameImportUnchanged
+				break
+			}
+			item.Action = dtos.GameImportUpdate
+			writes, written = append(writes, merged), append(written, i)
+		}
+		seen[key] = true
+
+		switch item.Action {
+		case dtos.GameImportCreate:
+			response.Created++
+		case dtos.GameImportUpdate:
+			response.Updated++
+		case dtos.GameImportUnchanged:
+			response.Unchanged++
+		default:
+			response.Skipped++
+		}
+		response.Items[i] = item
+	}
+
+	if response.DryRun || len(writes) == 0 {
+		return c.JSON(response)
+	}
+
+	if err := h.gameRepo.Upsert(c.Context(), writes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to import games"))
+	}
+
+	for n, game := range writes {
+		item := &response.Items[written[n]]
+		item.GameID = game.ID
+		if item.Action == dtos.GameImportCreate {
+			h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionCreated, game.ID, nil, game)
+		} else {
+			h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionUpdated, game.ID, byName[strings.ToLower(game.Name)], game)
+		}
+	}
+
+	return c.JSON(response)
+}
+
+func importDocument(c *fiber.Ctx) (io.Reader, error) {
+	if header, err := c.FormFile("file"); err == nil {
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(content), nil
+	}
+	if len(bytes.TrimSpace(c.Body())) == 0 {
+		return nil, errors.New("upload a BoardGameGeek thing document as \"file\" or send it as the request body")
+	}
+	return bytes.NewReader(c.Body()), nil
+}
+
+// importChanges lists the fields an import changes, keyed like the game's
+// JSON representation.
+func importChanges(before, after *models.Game) map[string]dtos.GameImportFieldChange {
+	var diff map[string]audit.Change
+	if before == nil {
+		diff = audit.Diff(nil, after)
+	} else {
+		diff = audit.Diff(before, after)
+	}
+
+	changes := make(map[string]dtos.GameImportFieldChange, len(diff))
+	for field, change := range diff {
+		if importIgnoredFields[field] {
+			continue
+		}
+		changes[jsonFieldName(field)] = dtos.GameImportFieldChange{Before: change.Before, After: change.After}
+	}
+	return changes
+}
+
+func jsonFieldName(field string) string {
+	first, size := utf8.DecodeRuneInString(field)
+	return string(unicode.ToLower(first)) + field[size:]
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const bggImportDocument = `<?xml version="1.0" encoding="utf-8"?>
+<items>
+	<item type="boardgame" id="13">
+		<name type="primary" value="CATAN" />
+		<description>Trade, build and settle.</description>
+		<yearpublished value="1995" />
+		<minplayers value="3" />
+		<maxplayers value="4" />
+		<playingtime value="120" />
+		<minage value="10" />
+		<link type="boardgamepublisher" value="KOSMOS" />
+		<statistics><ratings><averageweight value="2.29" /></ratings></statistics>
+	</item>
+	<item type="boardgame" id="30549">
+		<name type="primary" value="Pandemic" />
+		<yearpublished value="2008" />
+		<minplayers value="2" />
+		<maxplayers value="4" />
+		<playingtime value="45" />
+		<minage value="8" />
+		<link type="boardgamemechanic" value="Cooperative Game" />
+		<link type="boardgamepublisher" value="Z-Man Games" />
+	</item>
+	<item type="boardgameexpansion" id="926">
+		<name type="primary" value="CATAN: Seafarers" />
+	</item>
+</items>`
+
+func setupGameImportTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	gameHandler := NewGameHandler(db)
+	gameHandler.recorder = audit.NewRecorder(store)
+
+	authRequired := middleware.JWTMiddleware(db)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	app.Post("/games/import/bgg", authRequired, organizerOnly, gameHandler.ImportBGGGames)
+	return app
+}
+
+func postBGGImport(t *testing.T, app *fiber.App, token, query string) (dtos.GameImportResponse, int) {
+	resp, err := app.Test(authorizedRequest("POST", "/games/import/bgg"+query, token, []byte(bggImportDocument)))
+	assert.NoError(t, err)
+
+	var result dtos.GameImportResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result, resp.StatusCode
+}
+
+func createImportTestGame(db *gorm.DB) *models.Game {
+	game := models.NewGameBuilder().SetName("Catan").SetPlayerRange(3, 4).SetPlaytimeMinutes(90).
+		SetMinAge(10).SetYearPublished(1995).SetRating(7.5).SetRatingCount(2).Build()
+	db.Create(game)
+	return game
+}
+
+func TestGameHandler_ImportBGGGames_DryRun(t *testing.T) {
+	// Given: An existing game that the document also lists
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupGameImportTestApp(db, store)
+	existing := createImportTestGame(db)
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	// When: Previewing the import
+	result, status := postBGGImport(t, app, token, "?dryRun=true")
+
+	// Then: The preview shows what would change and nothing is written
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Skipped)
+
+	update := result.Items[0]
+	assert.Equal(t, dtos.GameImportUpdate, update.Action)
+	assert.Equal(t, "Catan", update.Name)
+	assert.Equal(t, existing.ID, update.GameID)
+	assert.Equal(t, float64(90), update.Changes["playtimeMinutes"].Before)
+	assert.Equal(t, float64(120), update.Changes["playtimeMinutes"].After)
+	assert.Equal(t, "KOSMOS", update.Changes["publisher"].After)
+	assert.NotContains(t, update.Changes, "rating")
+	assert.NotContains(t, update.Changes, "name")
+
+	create := result.Items[1]
+	assert.Equal(t, dtos.GameImportCreate, create.Action)
+	assert.Equal(t, "Cooperative", create.Changes["category"].After)
+
+	assert.Equal(t, dtos.GameImportSkipped, result.Items[2].Action)
+
+	var count int64
+	db.Model(&models.Game{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+	assert.Empty(t, store.Actions())
+}
+
+func TestGameHandler_ImportBGGGames_Upserts(t *testing.T) {
+	// Given: An existing, reviewed game
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupGameImportTestApp(db, store)
+	existing := createImportTestGame(db)
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	// When: Importing the document
+	result, status := postBGGImport(t, app, token, "")
+
+	// Then: The existing game is updated in place and the new one created
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.NotZero(t, result.Items[1].GameID)
+
+	var catan models.Game
+	db.First(&catan, existing.ID)
+	assert.Equal(t, "Catan", catan.Name)
+	assert.Equal(t, 120, catan.PlaytimeMinutes)
+	assert.Equal(t, "KOSMOS", catan.Publisher)
+	assert.Equal(t, 7.5, catan.Rating)
+	assert.Equal(t, 2, catan.RatingCount)
+
+	var pandemic models.Game
+	db.Where("name = ?", "Pandemic").First(&pandemic)
+	assert.Equal(t, models.CategoryCooperative, pandemic.Category)
+	assert.Equal(t, "Z-Man Games", pandemic.Publisher)
+	assert.Equal(t, []string{"game.updated", "game.created"}, store.Actions())
+
+	// When: Importing the same document again
+	again, _ := postBGGImport(t, app, token, "")
+
+	// Then: Nothing changes
+	assert.Equal(t, 2, again.Unchanged)
+	assert.Zero(t, again.Created+again.Updated)
+}
+
+func TestGameHandler_ImportBGGGames_FileUpload(t *testing.T) {
+	// Given: The document as a multipart file upload
+	db := setupTestDB(t)
+	app := setupGameImportTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "things.xml")
+	part.Write([]byte(bggImportDocument))
+	writer.Close()
+
+	req := authorizedRequest("POST", "/games/import/bgg", token, body.Bytes())
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// When: Importing it
+	resp, err := app.Test(req)
+
+	// Then: Both games are created
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var result dtos.GameImportResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, 2, result.Created)
+}
+
+func TestGameHandler_ImportBGGGames_RejectsBadRequests(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupGameImportTestApp(db, audit.NewMemoryStore())
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	_, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	tests := []struct {
+		name  string
+		token string
+		body  string
+		want  int
+	}{
+		{name: "empty body", token: organizerToken, body: "", want: fiber.StatusBadRequest},
+		{name: "not a thing document", token: organizerToken, body: `{"name":"Catan"}`, want: fiber.StatusBadRequest},
+		{name: "member", token: memberToken, body: bggImportDocument, want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Posting the import
+			resp, err := app.Test(authorizedRequest("POST", "/games/import/bgg", tt.token, []byte(tt.body)))
+
+			// Then: It should be rejected
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/recommend"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRecommendationLimit = 5
+	maxRecommendationLimit     = 20
+	maxRecommendationPlayers   = 12
+)
+
+type RecommendationHandler struct {
+	gameRepo   repositories.GameRepository
+	reviewRepo repositories.GameReviewRepository
+}
+
+func NewRecommendationHandler(db *gorm.DB) *RecommendationHandler {
+	return NewRecommendationHandlerWithRepo(repositories.NewGameRepository(db), repositories.NewGameReviewRepository(db))
+}
+
+func NewRecommendationHandlerWithRepo(gameRepo repositories.GameRepository, reviewRepo repositories.GameReviewRepository) *RecommendationHandler {
+	return &RecommendationHandler{gameRepo: gameRepo, reviewRepo: reviewRepo}
+}
+
+// RecommendGames suggests what a group should play. Games the listed users
+// reviewed count for or against according to their scores.
+func (h *RecommendationHandler) RecommendGames(c *fiber.Ctx) error {
+	table, userIDs, limit, err := parseRecommendationQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	scores := recommend.Scores{}
+	for _, userID := range userIDs {
+		reviews, err := h.reviewRepo.FindByUserID(c.Context(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch reviews"))
+		}
+		for _, review := range reviews {
+			scores[review.GameID] = append(scores[review.GameID], review.Score)
+		}
+	}
+
+	games, err := h.gameRepo.FindPlayable(c.Context(), table.Players, table.YoungestAge)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch games"))
+	}
+
+	recommendations := recommend.Rank(games, table, scores, limit)
+	items := make([]dtos.GameRecommendation, len(recommendations))
+	for i, recommendation := range recommendations {
+		items[i] = dtos.GameRecommendation{
+			Game:    mappers.ToGameResponse(&recommendation.Game),
+			Score:   recommendation.Score,
+			Reasons: recommendation.Reasons,
+		}
+	}
+	return c.JSON(dtos.GameRecommendationResponse{Items: items})
+}
+
+func parseRecommendationQuery(c *fiber.Ctx) (recommend.Table, []uint, int, error) {
+	table := recommend.Table{Complexity: models.GameComplexity(c.Query("complexity"))}
+	if table.Complexity != "" && !models.IsValidComplexity(table.Complexity) {
+		return table, nil, 0, fmt.Errorf("unknown complexity %q", table.Complexity)
+	}
+
+	limit := defaultRecommendationLimit
+	ints := []struct {
+		name     string
+		target   *int
+		required bool
+		max      int
+	}{
+		{"players", &table.Players, true, 0},
+		{"minutes", &table.Minutes, true, 0},
+		{"youngestAge", &table.YoungestAge, false, 0},
+		{"limit", &limit, false, maxRecommendationLimit},
+	}
+	for _, param := range ints {
+		value := c.Query(param.name)
+		if value == "" {
+			if param.required {
+				return table, nil, 0, fmt.Errorf("%s is required", param.name)
+			}
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || (param.max != 0 && parsed > param.max) {
+			if param.max != 0 {
+				return table, nil, 0, fmt.Errorf("%s must be between 1 and %d", param.name, param.max)
+			}
+			return table, nil, 0, fmt.Errorf("%s must be a number of at least 1", param.name)
+		}
+		*param.target = parsed
+	}
+
+	var userIDs []uint
+	if value := c.Query("userIds"); value != "" {
+		seen := map[uint]bool{}
+		for _, raw := range strings.Split(value, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 32)
+			if err != nil || id == 0 {
+				return table, nil, 0, fmt.Errorf("invalid user ID %q", strings.TrimSpace(raw))
+			}
+			if !seen[uint(id)] {
+				seen[uint(id)] = true
+				userIDs = append(userIDs, uint(id))
+			}
+		}
+		if len(userIDs) > maxRecommendationPlayers {
+			return table, nil, 0, fmt.Errorf("at most %d user IDs may be listed", maxRecommendationPlayers)
+		}
+	}
+
+	return table, userIDs, limit, nil
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupRecommendationTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	handler := NewRecommendationHandler(db)
+	app.Get("/games/recommend", handler.RecommendGames)
+	return app
+}
+
+func createRecommendationTestGame(db *gorm.DB, name string, minPlayers, maxPlayers, playtime, minAge int) *models.Game {
+	game := models.NewGameBuilder().
+		SetName(name).
+		SetPlayerRange(minPlayers, maxPlayers).
+		SetPlaytimeMinutes(playtime).
+		SetMinAge(minAge).
+		SetComplexity(models.ComplexityMedium).
+		Build()
+	db.Create(game)
+	return game
+}
+
+func TestRecommendationHandler_RecommendGames(t *testing.T) {
+	// Given: A small catalog with an expansion and a player who loved one of the games
+	db := setupTestDB(t)
+	app := setupRecommendationTestApp(db)
+	base := createRecommendationTestGame(db, "Carcassonne", 2, 5, 45, 7)
+	expansion := models.NewGameBuilder().SetName("Inns & Cathedrals").SetPlayerRange(2, 6).SetPlaytimeMinutes(45).Build()
+	expansion.BaseGameID = &base.ID
+	db.Create(expansion)
+	loved := createRecommendationTestGame(db, "Kingdomino", 2, 4, 45, 8)
+	createRecommendationTestGame(db, "Twilight Struggle", 2, 2, 180, 13)
+	player, _ := createRoleTestUser(db, "player@example.com", models.RoleMember)
+	db.Create(&models.GameReview{GameID: loved.ID, UserID: player.ID, Score: 10})
+
+	// When: Asking for a game for three players, including the ten year old
+	path := fmt.Sprintf("/games/recommend?players=3&minutes=60&youngestAge=10&complexity=Medium&userIds=%d", player.ID)
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+
+	// Then: The playable base games are ranked with the loved one first
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result dtos.GameRecommendationResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, "Kingdomino", result.Items[0].Game.Name)
+	assert.Equal(t, "Carcassonne", result.Items[1].Game.Name)
+	assert.Contains(t, result.Items[0].Reasons, "Rated 10.0/10 by 1 of the players")
+	assert.NotEmpty(t, result.Items[1].Reasons)
+}
+
+func TestRecommendationHandler_RecommendGames_RejectsInvalidQuery(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupRecommendationTestApp(db)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing players", query: "minutes=60"},
+		{name: "missing minutes", query: "players=4"},
+		{name: "zero players", query: "players=0&minutes=60"},
+		{name: "unknown complexity", query: "players=4&minutes=60&complexity=Brutal"},
+		{name: "invalid user ID", query: "players=4&minutes=60&userIds=1,abc"},
+		{name: "limit too high", query: "players=4&minutes=60&limit=50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Asking for recommendations
+			resp, _ := app.Test(httptest.NewRequest("GET", "/games/recommend?"+tt.query, nil))
+
+			// Then: The query is rejected
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
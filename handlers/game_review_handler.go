@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const invalidReviewScoreMessage = "Score must be between 1 and 10"
+
+type GameReviewHandler struct {
+	reviewRepo repositories.GameReviewRepository
+	gameRepo   repositories.GameRepository
+	recorder   *audit.Recorder
+}
+
+func NewGameReviewHandler(db *gorm.DB) *GameReviewHandler {
+	return NewGameReviewHandlerWithRepo(repositories.NewGameReviewRepository(db), repositories.NewGameRepository(db))
+}
+
+func NewGameReviewHandlerWithRepo(reviewRepo repositories.GameReviewRepository, gameRepo repositories.GameRepository) *GameReviewHandler {
+	return &GameReviewHandler{reviewRepo: reviewRepo, gameRepo: gameRepo, recorder: audit.DefaultRecorder}
+}
+
+func (h *GameReviewHandler) GetGameReviews(c *fiber.Ctx) error {
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	reviews, err := h.reviewRepo.FindByGameID(c.Context(), game.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch reviews"))
+	}
+
+	return c.JSON(mappers.ToGameReviewResponseList(reviews))
+}
+
+// CreateGameReview adds the caller's review of a game. A member reviews each
+// game once and edits that review afterwards.
+func (h *GameReviewHandler) CreateGameReview(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	var req dtos.GameReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	if !models.IsValidReviewScore(req.Score) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(invalidReviewScoreMessage))
+	}
+
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	if existing, _ := h.reviewRepo.FindByGameAndUser(c.Context(), game.ID, userID); existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("You have already reviewed this game"))
+	}
+
+	review := mappers.ToGameReviewModel(req, game.ID, userID)
+	if err := h.reviewRepo.Create(c.Context(), &review); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create review"))
+	}
+
+	created, err := h.reviewRepo.FindByID(c.Context(), review.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to retrieve created review"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToGameReviewResponse(created))
+}
+
+// UpdateGameReview changes the score or text of the caller's own review.
+func (h *GameReviewHandler) UpdateGameReview(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid review ID"))
+	}
+
+	var req dtos.GameReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	if !models.IsValidReviewScore(req.Score) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(invalidReviewScoreMessage))
+	}
+
+	review, err := h.reviewRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	if review.UserID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	updated := mappers.ToGameReviewModel(req, review.GameID, review.UserID)
+	review.Score = updated.Score
+	review.Text = updated.Text
+	if err := h.reviewRepo.Update(c.Context(), review); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update review"))
+	}
+
+	return c.JSON(mappers.ToGameReviewResponse(review))
+}
+
+// DeleteGameReview removes a review. Authors can delete their own reviews and
+// moderators can remove anyone's; the latter is audited.
+func (h *GameReviewHandler) DeleteGameReview(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid review ID"))
+	}
+
+	review, err := h.reviewRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	ownReview := review.UserID == userID
+	if !ownReview && !actorRole(c).CanModerate() {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	if err := h.reviewRepo.Delete(c.Context(), review); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete review"))
+	}
+
+	if !ownReview {
+		h.recorder.RecordChange(c, audit.ResourceGameReview, audit.ActionDeleted, review.ID, review, nil)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
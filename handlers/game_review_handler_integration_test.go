@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupGameReviewTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	reviewHandler := NewGameReviewHandler(db)
+	reviewHandler.recorder = audit.NewRecorder(store)
+	gameHandler := NewGameHandler(db)
+
+	authRequired := middleware.JWTMiddleware(db)
+	app.Get("/games/:id", gameHandler.GetGameByID)
+	app.Get("/games/:id/reviews", reviewHandler.GetGameReviews)
+	app.Post("/games/:id/reviews", authRequired, reviewHandler.CreateGameReview)
+	app.Put("/reviews/:id", authRequired, reviewHandler.UpdateGameReview)
+	app.Delete("/reviews/:id", authRequired, reviewHandler.DeleteGameReview)
+	return app
+}
+
+func postReview(t *testing.T, app *fiber.App, token string, gameID uint, score int) (*dtos.GameReviewResponse, int) {
+	body, _ := json.Marshal(dtos.GameReviewRequest{Score: score, Text: "Played it twice"})
+	resp, err := app.Test(authorizedRequest("POST", fmt.Sprintf("/games/%d/reviews", gameID), token, body))
+	assert.NoError(t, err)
+
+	var review dtos.GameReviewResponse
+	json.NewDecoder(resp.Body).Decode(&review)
+	return &review, resp.StatusCode
+}
+
+func getRatedGame(t *testing.T, app *fiber.App, gameID uint) dtos.GameResponse {
+	resp, err := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/games/%d", gameID), nil))
+	assert.NoError(t, err)
+
+	var game dtos.GameResponse
+	json.NewDecoder(resp.Body).Decode(&game)
+	return game
+}
+
+func createReviewTestGame(db *gorm.DB) *models.Game {
+	game := models.NewGameBuilder().SetName("Brass").Build()
+	db.Create(game)
+	return game
+}
+
+func TestGameReviewHandler_CreateGameReview_UpdatesRating(t *testing.T) {
+	// Given: A game and two members
+	db := setupTestDB(t)
+	app := setupGameReviewTestApp(db, audit.NewMemoryStore())
+	game := createReviewTestGame(db)
+	_, firstToken := createRoleTestUser(db, "first@example.com", models.RoleMember)
+	_, secondToken := createRoleTestUser(db, "second@example.com", models.RoleMember)
+
+	// When: Both review the game
+	review, status := postReview(t, app, firstToken, game.ID, 8)
+	_, secondStatus := postReview(t, app, secondToken, game.ID, 5)
+
+	// Then: The reviews are stored and the game shows their average
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, fiber.StatusCreated, secondStatus)
+	assert.Equal(t, 8, review.Score)
+	assert.Equal(t, "Role User", review.UserName)
+
+	rated := getRatedGame(t, app, game.ID)
+	assert.Equal(t, 6.5, rated.Rating)
+	assert.Equal(t, 2, rated.RatingCount)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/games/%d/reviews", game.ID), nil))
+	var reviews []dtos.GameReviewResponse
+	json.NewDecoder(resp.Body).Decode(&reviews)
+	assert.Len(t, reviews, 2)
+}
+
+func TestGameReviewHandler_CreateGameReview_OncePerGame(t *testing.T) {
+	// Given: A member who already reviewed the game
+	db := setupTestDB(t)
+	app := setupGameReviewTestApp(db, audit.NewMemoryStore())
+	game := createReviewTestGame(db)
+	_, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	postReview(t, app, token, game.ID, 7)
+
+	// When: Reviewing it again
+	_, status := postReview(t, app, token, game.ID, 3)
+
+	// Then: The second review is refused and the rating is unchanged
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, 7.0, getRatedGame(t, app, game.ID).Rating)
+}
+
+func TestGameReviewHandler_CreateGameReview_RejectsInvalidInput(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupGameReviewTestApp(db, audit.NewMemoryStore())
+	game := createReviewTestGame(db)
+	_, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	tests := []struct {
+		name   string
+		gameID uint
+		score  int
+		want   int
+	}{
+		{name: "score too low", gameID: game.ID, score: 0, want: fiber.StatusBadRequest},
+		{name: "score too high", gameID: game.ID, score: 11, want: fiber.StatusBadRequest},
+		{name: "unknown game", gameID: game.ID + 100, score: 5, want: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Posting the review
+			_, status := postReview(t, app, token, tt.gameID, tt.score)
+
+			// Then: It should be rejected
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestGameReviewHandler_UpdateGameReview(t *testing.T) {
+	// Given: A review by one member
+	db := setupTestDB(t)
+	app := setupGameReviewTestApp(db, audit.NewMemoryStore())
+	game := createReviewTestGame(db)
+	_, authorToken := createRoleTestUser(db, "author@example.com", models.RoleMember)
+	_, otherToken := createRoleTestUser(db, "other@example.com", models.RoleMember)
+	review, _ := postReview(t, app, authorToken, game.ID, 4)
+	path := fmt.Sprintf("/reviews/%d", review.ID)
+	body, _ := json.Marshal(dtos.GameReviewRequest{Score: 9, Text: "Grew on me"})
+
+	// When: Another member and then the author edit it
+	otherResp, _ := app.Test(authorizedRequest("PUT", path, otherToken, body))
+	authorResp, _ := app.Test(authorizedRequest("PUT", path, authorToken, body))
+
+	// Then: Only the author's edit is applied and the rating follows it
+	assert.Equal(t, fiber.StatusForbidden, otherResp.StatusCode)
+	assert.Equal(t, fiber.StatusOK, authorResp.StatusCode)
+	var updated dtos.GameReviewResponse
+	json.NewDecoder(authorResp.Body).Decode(&updated)
+	assert.Equal(t, 9, updated.Score)
+	assert.Equal(t, "Grew on me", updated.Text)
+	assert.Equal(t, 9.0, getRatedGame(t, app, game.ID).Rating)
+}
+
+func TestGameReviewHandler_DeleteGameReview(t *testing.T) {
+	// Given: Two reviews of a game
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupGameReviewTestApp(db, store)
+	game := createReviewTestGame(db)
+	_, authorToken := createRoleTestUser(db, "author@example.com", models.RoleMember)
+	_, otherToken := createRoleTestUser(db, "other@example.com", models.RoleMember)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	own, _ := postReview(t, app, authorToken, game.ID, 10)
+	other, _ := postReview(t, app, otherToken, game.ID, 2)
+
+	// When: A member tries to delete someone else's review
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/reviews/%d", other.ID), authorToken, nil))
+
+	// Then: It is refused
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// When: The author deletes their own review and an admin removes the other
+	ownResp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/reviews/%d", own.ID), authorToken, nil))
+	adminResp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/reviews/%d", other.ID), adminToken, nil))
+
+	// Then: Both are gone, the rating is reset and only the removal is audited
+	assert.Equal(t, fiber.StatusNoContent, ownResp.StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, adminResp.StatusCode)
+	rated := getRatedGame(t, app, game.ID)
+	assert.Equal(t, 0.0, rated.Rating)
+	assert.Equal(t, 0, rated.RatingCount)
+	assert.Equal(t, []string{"game_review.deleted"}, store.Actions())
+
+	// And: The author can review the game again
+	_, status := postReview(t, app, authorToken, game.ID, 6)
+	assert.Equal(t, fiber.StatusCreated, status)
+}
@@ -0,0 +1,152 @@
+//go:build sqlite_fts5
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupGameSearchTestApp(t *testing.T) (*gorm.DB, *fiber.App) {
+	db := setupTestDB(t)
+	if err := repositories.SetupGameSearch(db); err != nil {
+		t.Fatalf("Failed to set up game search: %v", err)
+	}
+
+	app := fiber.New()
+	gameHandler := NewGameHandler(db)
+	app.Get("/games/search", gameHandler.SearchGames)
+	app.Put("/games/:id", gameHandler.UpdateGame)
+	app.Delete("/games/:id", gameHandler.DeleteGame)
+	return db, app
+}
+
+func searchGames(t *testing.T, app *fiber.App, query string) (*dtos.GameSearchResponse, int) {
+	resp, err := app.Test(httptest.NewRequest("GET", "/games/search?q="+url.QueryEscape(query), nil))
+	assert.NoError(t, err)
+
+	var result dtos.GameSearchResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	return &result, resp.StatusCode
+}
+
+func seedSearchGames(db *gorm.DB) {
+	db.Create(models.NewGameBuilder().SetName("Catan").SetDescription("Trade and build settlements on an island").SetPublisher("Kosmos").Build())
+	db.Create(models.NewGameBuilder().SetName("Carcassonne").SetDescription("Tile laying around a medieval city").SetPublisher("Hans im Glück").Build())
+	db.Create(models.NewGameBuilder().SetName("Lost Cities").SetDescription("A two player card game").SetPublisher("Kosmos").Build())
+	db.Create(models.NewGameBuilder().SetName("Island Builders").SetDescription("Build bridges between islands").SetPublisher("Plan B").Build())
+}
+
+func searchNames(result *dtos.GameSearchResponse) []string {
+	names := make([]string, len(result.Items))
+	for i, game := range result.Items {
+		names[i] = game.Name
+	}
+	return names
+}
+
+func TestGameHandler_SearchGames_RanksNameMatchesFirst(t *testing.T) {
+	// Given: Games mentioning "island" in their name or only in the description
+	db, app := setupGameSearchTestApp(t)
+	seedSearchGames(db)
+
+	// When: Searching for "island"
+	result, status := searchGames(t, app, "island")
+
+	// Then: The name match should rank above the description match
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []string{"Island Builders", "Catan"}, searchNames(result))
+	assert.Empty(t, result.CorrectedQuery)
+}
+
+func TestGameHandler_SearchGames_PrefixAndPublisher(t *testing.T) {
+	// Given: Searchable games
+	db, app := setupGameSearchTestApp(t)
+	seedSearchGames(db)
+
+	// When: Typing the start of a word and searching by publisher
+	prefix, _ := searchGames(t, app, "carc")
+	publisher, _ := searchGames(t, app, "kosmos")
+	combined, _ := searchGames(t, app, "kosmos card")
+
+	// Then: Prefixes, publishers and multiple terms should all match
+	assert.Equal(t, []string{"Carcassonne"}, searchNames(prefix))
+	assert.ElementsMatch(t, []string{"Catan", "Lost Cities"}, searchNames(publisher))
+	assert.Equal(t, []string{"Lost Cities"}, searchNames(combined))
+}
+
+func TestGameHandler_SearchGames_CorrectsTypos(t *testing.T) {
+	// Given: Searchable games
+	db, app := setupGameSearchTestApp(t)
+	seedSearchGames(db)
+
+	// When: Searching with a typo
+	result, status := searchGames(t, app, "carcasone")
+
+	// Then: The corrected query should find the game
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []string{"Carcassonne"}, searchNames(result))
+	assert.Equal(t, "carcassonne", result.CorrectedQuery)
+}
+
+func TestGameHandler_SearchGames_FollowsWrites(t *testing.T) {
+	// Given: A searchable game
+	db, app := setupGameSearchTestApp(t)
+	game := models.NewGameBuilder().SetName("Azul").SetPublisher("Plan B").Build()
+	db.Create(game)
+
+	// When: The game is renamed and then deleted through the API
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/games/%d", game.ID), bytes.NewBufferString(`{"name":"Azul Summer Pavilion"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	renamed, _ := searchGames(t, app, "pavilion")
+
+	app.Test(httptest.NewRequest("DELETE", fmt.Sprintf("/games/%d", game.ID), nil))
+	deleted, _ := searchGames(t, app, "azul")
+
+	// Then: The index should reflect each write
+	assert.Equal(t, []string{"Azul Summer Pavilion"}, searchNames(renamed))
+	assert.Empty(t, deleted.Items)
+}
+
+func TestGameHandler_SearchGames_CorrectionsFollowWrites(t *testing.T) {
+	// Given: A typo that nothing in the catalog is close to yet
+	db, app := setupGameSearchTestApp(t)
+	game := models.NewGameBuilder().SetName("Azul").Build()
+	db.Create(game)
+	before, _ := searchGames(t, app, "pavilon")
+
+	// When: A game gains the word through the API and the typo is searched again
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/games/%d", game.ID), bytes.NewBufferString(`{"name":"Azul Summer Pavilion"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.Test(req)
+	after, _ := searchGames(t, app, "pavilon")
+
+	// Then: The cached vocabulary was refreshed and the typo is corrected
+	assert.Empty(t, before.Items)
+	assert.Equal(t, []string{"Azul Summer Pavilion"}, searchNames(after))
+	assert.Equal(t, "pavilion", after.CorrectedQuery)
+}
+
+func TestGameHandler_SearchGames_RequiresQuery(t *testing.T) {
+	// Given: A search endpoint
+	_, app := setupGameSearchTestApp(t)
+
+	// When: Searching without a query
+	_, status := searchGames(t, app, "")
+
+	// Then: The request should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, status)
+}
@@ -0,0 +1,23 @@
+//go:build !sqlite_fts5
+
+package handlers
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// Without the sqlite_fts5 tag the driver has no FTS5, so the search tests
+// are left out and setup has to say why instead of half-working.
+func TestSetupGameSearch_WithoutFTS5(t *testing.T) {
+	// Given: A SQLite database built without FTS5
+	db := setupTestDB(t)
+
+	// When: Setting up game search
+	err := repositories.SetupGameSearch(db)
+
+	// Then: It should fail with a clear error
+	assert.ErrorIs(t, err, repositories.ErrFTS5Unavailable)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	gameTransferCSV  = "csv"
+	gameTransferJSON = "json"
+
+	maxGameImportRows = 1000
+)
+
+// ExportGames downloads the whole catalog as format=json (the default) or
+// format=csv. Both can be imported again with ImportGames.
+func (h *GameHandler) ExportGames(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", gameTransferJSON))
+	if format != gameTransferCSV && format != gameTransferJSON {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("format must be csv or json"))
+	}
+
+	games, err := h.gameRepo.FindAll(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export games"))
+	}
+
+	c.Attachment("games." + format)
+	if format == gameTransferJSON {
+		return c.JSON(mappers.ToGameResponseList(games))
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(mappers.GameCSVHeader)
+	for i := range games {
+		writer.Write(mappers.ToGameCSVRecord(&games[i]))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to export games"))
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
+
+type gameImportRow struct {
+	req dtos.CreateGameRequest
+	err error
+}
+
+// ImportGames creates or updates games by name from an uploaded CSV or JSON
+// file, in the layout ExportGames produces. The format comes from the format
+// query parameter or the file extension. Rows are validated and saved
+// independently and failures are reported per row; with atomic=true any
+// failure rejects the whole file and nothing is saved.
+func (h *GameHandler) ImportGames(c *fiber.Ctx) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("upload the games as \"file\""))
+	}
+
+	format := strings.ToLower(c.Query("format", strings.TrimPrefix(filepath.Ext(header.Filename), ".")))
+	if format != gameTransferCSV && format != gameTransferJSON {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("format must be csv or json"))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid file"))
+	}
+	defer file.Close()
+
+	rows, err := readGameImportRows(format, file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	names := make([]string, 0, len(rows))
+	for i := range rows {
+		if rows[i].err == nil {
+			rows[i].err = validateGameRequest(&rows[i].req)
+		}
+		if rows[i].err == nil {
+			names = append(names, rows[i].req.Name)
+		}
+	}
+	existing, err := h.gameRepo.FindByNames(c.Context(), names)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to import games"))
+	}
+	byName := make(map[string]*models.Game, len(existing))
+	for i := range existing {
+		byName[strings.ToLower(existing[i].Name)] = &existing[i]
+	}
+
+	response := dtos.GameBulkImportResponse{Atomic: c.QueryBool("atomic"), Rows: make([]dtos.GameBulkImportRow, len(rows))}
+	var writes []*models.Game
+	var written []int
+	firstRow := make(map[string]int, len(rows))
+	for i, row := range rows {
+		result := dtos.GameBulkImportRow{Row: i + 1, Name: row.req.Name}
+		key := strings.ToLower(row.req.Name)
+
+		switch {
+		case row.err != nil:
+			result.Action, result.Error = dtos.GameImportFailed, row.err.Error()
+		case firstRow[key] != 0:
+			result.Action, result.Error = dtos.GameImportFailed, fmt.Sprintf("duplicate of row %d", firstRow[key])
+		case byName[key] == nil:
+			game := mappers.ToGameModel(row.req)
+			result.Action = dtos.GameImportCreate
+			writes, written = append(writes, &game), append(written, i)
+		default:
+			current := byName[key]
+			result.GameID = current.ID
+			// Empty text cells keep the stored value, as a partial update would.
+			if row.req.Description == "" {
+				row.req.Description = current.Description
+			}
+			if row.req.Publisher == "" {
+				row.req.Publisher = current.Publisher
+			}
+			updated := mappers.UpdateGameFromRequest(current, row.req)
+			if len(importChanges(current, updated)) == 0 {
+				result.Action = dtos.GameImportUnchanged
+				break
+			}
+			result.Action = dtos.GameImportUpdate
+			writes, written = append(writes, updated), append(written, i)
+		}
+		if row.err == nil && firstRow[key] == 0 {
+			firstRow[key] = i + 1
+		}
+		response.Rows[i] = result
+	}
+
+	if response.Atomic {
+		if countGameImportFailures(response.Rows) > 0 {
+			tallyGameImport(&response)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		if len(writes) > 0 {
+			if err := h.gameRepo.Upsert(c.Context(), writes); err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to import games"))
+			}
+		}
+	} else if len(writes) > 0 {
+		for n, err := range h.gameRepo.UpsertEach(c.Context(), writes) {
+			if err != nil {
+				result := &response.Rows[written[n]]
+				result.Action, result.Error = dtos.GameImportFailed, "could not be saved"
+			}
+		}
+	}
+
+	for n, game := range writes {
+		result := &response.Rows[written[n]]
+		switch result.Action {
+		case dtos.GameImportCreate:
+			result.GameID = game.ID
+			h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionCreated, game.ID, nil, game)
+		case dtos.GameImportUpdate:
+			h.recorder.RecordChange(c, audit.ResourceGame, audit.ActionUpdated, game.ID, byName[strings.ToLower(rows[written[n]].req.Name)], game)
+		}
+	}
+
+	tallyGameImport(&response)
+	return c.JSON(response)
+}
+
+func countGameImportFailures(rows []dtos.GameBulkImportRow) int {
+	failed := 0
+	for _, row := range rows {
+		if row.Action == dtos.GameImportFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+func tallyGameImport(response *dtos.GameBulkImportResponse) {
+	for _, row := range response.Rows {
+		switch row.Action {
+		case dtos.GameImportCreate:
+			response.Created++
+		case dtos.GameImportUpdate:
+			response.Updated++
+		case dtos.GameImportUnchanged:
+			response.Unchanged++
+		case dtos.GameImportFailed:
+			response.Failed++
+		}
+	}
+}
+
+// readGameImportRows fails only when the file as a whole cannot be read.
+// Problems with a single row are kept on that row.
+func readGameImportRows(format string, r io.Reader) ([]gameImportRow, error) {
+	var rows []gameImportRow
+	if format == gameTransferJSON {
+		var items []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&items); err != nil {
+			return nil, errors.New("the file must hold a JSON array of games")
+		}
+		if len(items) > maxGameImportRows {
+			return nil, fmt.Errorf("a file may hold at most %d games", maxGameImportRows)
+		}
+		rows = make([]gameImportRow, len(items))
+		for i, item := range items {
+			if err := json.Unmarshal(item, &rows[i].req); err != nil {
+				var typeErr *json.UnmarshalTypeError
+				if errors.As(err, &typeErr) && typeErr.Field != "" {
+					rows[i].err = fmt.Errorf("%s has the wrong type", typeErr.Field)
+				} else {
+					rows[i].err = errors.New("row is not a JSON object")
+				}
+			}
+		}
+		return rows, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("the file must start with a CSV header row")
+	}
+	columns, err := mappers.ParseGameCSVHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		if len(rows) == maxGameImportRows {
+			return nil, fmt.Errorf("a file may hold at most %d games", maxGameImportRows)
+		}
+
+		var row gameImportRow
+		if len(record) > len(columns) {
+			row.err = errors.New("row has more cells than the header")
+		} else {
+			row.req, row.err = mappers.ToGameRequestFromCSV(columns, record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// validateGameRequest applies the rules declared on CreateGameRequest. Name
+// and numberOfPlayers are required; the other numbers fall back to the
+// game defaults when zero, so they are only checked when set.
+func validateGameRequest(req *dtos.CreateGameRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return errors.New("name is required")
+	case req.NumberOfPlayers < 1:
+		return errors.New("numberOfPlayers must be at least 1")
+	case req.MinPlayers < 0 || req.MaxPlayers < 0:
+		return errors.New("minPlayers and maxPlayers must be at least 1")
+	case req.MinPlayers > 0 && req.MaxPlayers > 0 && req.MinPlayers > req.MaxPlayers:
+		return errors.New("minPlayers cannot be more than maxPlayers")
+	case req.PlaytimeMinutes < 0:
+		return errors.New("playtimeMinutes must be at least 1")
+	case req.MinAge != 0 && req.MinAge < 3:
+		return errors.New("minAge must be at least 3")
+	case req.YearPublished != 0 && (req.YearPublished < 1900 || req.YearPublished > 2100):
+		return errors.New("yearPublished must be between 1900 and 2100")
+	case req.Complexity != "" && !models.IsValidComplexity(models.GameComplexity(req.Complexity)):
+		return fmt.Errorf("unknown complexity %q", req.Complexity)
+	case req.Category != "" && !models.IsValidCategory(models.GameCategory(req.Category)):
+		return fmt.Errorf("unknown category %q", req.Category)
+	}
+	return nil
+}
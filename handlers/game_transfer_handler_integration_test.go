@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupGameTransferTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	gameHandler := NewGameHandler(db)
+	gameHandler.recorder = audit.NewRecorder(store)
+
+	authRequired := middleware.JWTMiddleware(db)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	app.Get("/games/export", gameHandler.ExportGames)
+	app.Post("/games/import", authRequired, organizerOnly, gameHandler.ImportGames)
+	return app
+}
+
+func postGameImport(t *testing.T, app *fiber.App, token, query, filename, content string) (dtos.GameBulkImportResponse, int) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := authorizedRequest("POST", "/games/import"+query, token, body.Bytes())
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var result dtos.GameBulkImportResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result, resp.StatusCode
+}
+
+func countGames(db *gorm.DB) int64 {
+	var count int64
+	db.Model(&models.Game{}).Count(&count)
+	return count
+}
+
+func TestGameHandler_ExportGames(t *testing.T) {
+	// Given: A rated game in the catalog
+	db := setupTestDB(t)
+	app := setupGameTransferTestApp(db, audit.NewMemoryStore())
+	db.Create(models.NewGameBuilder().SetName("Azul").SetDescription("Tiles, walls\nand points").
+		SetPlayerRange(2, 4).SetRating(8.25).SetRatingCount(4).Build())
+
+	// When: Exporting it as CSV and as JSON
+	csvResp, _ := app.Test(httptest.NewRequest("GET", "/games/export?format=csv", nil))
+	jsonResp, _ := app.Test(httptest.NewRequest("GET", "/games/export", nil))
+	badResp, _ := app.Test(httptest.NewRequest("GET", "/games/export?format=xlsx", nil))
+
+	// Then: Both downloads hold the game
+	assert.Equal(t, fiber.StatusOK, csvResp.StatusCode)
+	assert.Contains(t, csvResp.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, csvResp.Header.Get("Content-Disposition"), "games.csv")
+	records, err := csv.NewReader(csvResp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "name", records[0][1])
+	assert.Equal(t, "Azul", records[1][1])
+	assert.Equal(t, "Tiles, walls\nand points", records[1][2])
+	assert.Equal(t, "8.25", records[1][12])
+
+	var games []dtos.GameResponse
+	json.NewDecoder(jsonResp.Body).Decode(&games)
+	assert.Len(t, games, 1)
+	assert.Equal(t, 4, games[0].RatingCount)
+
+	assert.Equal(t, fiber.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestGameHandler_ImportGames_CSVReportsRowErrors(t *testing.T) {
+	// Given: A reviewed game and a sheet that updates it, adds games and has bad rows
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupGameTransferTestApp(db, store)
+	existing := models.NewGameBuilder().SetName("Azul").SetPublisher("Plan B").SetPlayerRange(2, 4).
+		SetRating(8.0).SetRatingCount(3).Build()
+	db.Create(existing)
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	sheet := "id,name,numberOfPlayers,minPlayers,maxPlayers,playtimeMinutes,minAge,category,publisher,rating\n" +
+		"1,azul,4,2,4,45,,Family,,2.0\n" +
+		",Patchwork,2,2,2,30,,,Lookout,\n" +
+		",Broken,two,,,,,,,\n" +
+		",Too Young,4,,,,2,Party,,\n" +
+		",patchwork,2,,,,,,,\n" +
+		",Hive,2,2,2,20,,Strategy,Gen42,\n" +
+		",Nameless,,,,,,,,\n" +
+		",,3,,,,,,,\n"
+
+	// When: Importing it
+	result, status := postGameImport(t, app, token, "", "games.csv", sheet)
+
+	// Then: The good rows are saved and each bad row says what is wrong
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 5, result.Failed)
+
+	assert.Equal(t, dtos.GameImportUpdate, result.Rows[0].Action)
+	assert.Equal(t, existing.ID, result.Rows[0].GameID)
+	assert.Equal(t, "numberOfPlayers must be a whole number", result.Rows[2].Error)
+	assert.Equal(t, "minAge must be at least 3", result.Rows[3].Error)
+	assert.Equal(t, "duplicate of row 2", result.Rows[4].Error)
+	assert.Equal(t, "numberOfPlayers must be at least 1", result.Rows[6].Error)
+	assert.Equal(t, "name is required", result.Rows[7].Error)
+	assert.Equal(t, dtos.GameImportCreate, result.Rows[5].Action)
+	assert.NotZero(t, result.Rows[5].GameID)
+
+	var azul models.Game
+	db.First(&azul, existing.ID)
+	assert.Equal(t, "azul", azul.Name)
+	assert.Equal(t, 45, azul.PlaytimeMinutes)
+	assert.Equal(t, models.CategoryFamily, azul.Category)
+	assert.Equal(t, "Plan B", azul.Publisher)
+	assert.Equal(t, 8.0, azul.Rating)
+	assert.Equal(t, 3, azul.RatingCount)
+	assert.Equal(t, int64(3), countGames(db))
+	assert.Equal(t, []string{"game.updated", "game.created", "game.created"}, store.Actions())
+}
+
+func TestGameHandler_ImportGames_AtomicRejectsWholeFile(t *testing.T) {
+	// Given: A JSON file with one invalid game
+	db := setupTestDB(t)
+	app := setupGameTransferTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	file := `[{"name":"Hive","numberOfPlayers":2},{"name":"Azul","numberOfPlayers":"four"}]`
+
+	// When: Importing it atomically
+	result, status := postGameImport(t, app, token, "?atomic=true", "games.json", file)
+
+	// Then: Nothing is saved and the failing row is reported
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.True(t, result.Atomic)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "numberOfPlayers has the wrong type", result.Rows[1].Error)
+	assert.Equal(t, int64(0), countGames(db))
+
+	// When: The file is fixed and imported again
+	fixed := `[{"name":"Hive","numberOfPlayers":2},{"name":"Azul","numberOfPlayers":4}]`
+	result, status = postGameImport(t, app, token, "?atomic=true", "games.json", fixed)
+
+	// Then: Every game is saved
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, int64(2), countGames(db))
+}
+
+func TestGameHandler_ImportGames_ExportRoundTrip(t *testing.T) {
+	// Given: A catalog exported as CSV
+	db := setupTestDB(t)
+	app := setupGameTransferTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	db.Create(models.NewGameBuilder().SetName("Azul").SetDescription("Tiles").SetPublisher("Plan B").Build())
+	db.Create(models.NewGameBuilder().SetName("Hive").SetPlayerRange(2, 2).Build())
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/games/export?format=csv", nil))
+	var exported bytes.Buffer
+	exported.ReadFrom(resp.Body)
+
+	// When: Importing the export unchanged
+	result, status := postGameImport(t, app, token, "", "export.csv", exported.String())
+
+	// Then: Nothing changes
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 2, result.Unchanged)
+	assert.Zero(t, result.Created+result.Updated+result.Failed)
+}
+
+func TestGameHandler_ImportGames_RejectsUnreadableFiles(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupGameTransferTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	tests := []struct {
+		name     string
+		query    string
+		filename string
+		content  string
+	}{
+		{name: "unknown format", filename: "games.xlsx", content: "name\nAzul"},
+		{name: "unknown column", filename: "games.csv", content: "name,price\nAzul,30"},
+		{name: "json object instead of array", filename: "games.json", content: `{"name":"Azul"}`},
+		{name: "format overrides extension", query: "?format=json", filename: "games.csv", content: "name\nAzul"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Importing the file
+			_, status := postGameImport(t, app, token, tt.query, tt.filename, tt.content)
+
+			// Then: It should be rejected as a whole
+			assert.Equal(t, fiber.StatusBadRequest, status)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupImpersonationTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	userHandler := NewUserHandler(db)
+	userHandler.recorder = audit.NewRecorder(store)
+	accountHandler := NewAccountHandler(db)
+
+	authRequired := middleware.JWTMiddleware(db)
+	app.Post("/admin/users/:id/impersonate", authRequired, middleware.RequireRole(models.RoleAdmin), userHandler.ImpersonateUser)
+	app.Put("/users/:id", authRequired, userHandler.UpdateUser)
+	app.Delete("/users/me", authRequired, middleware.DenyImpersonation(), accountHandler.DeleteAccount)
+	app.Get("/users/:id", userHandler.GetUserByID)
+	return app
+}
+
+func impersonate(t *testing.T, app *fiber.App, adminToken string, userID uint) (*dtos.ImpersonationResponse, int) {
+	resp, err := app.Test(authorizedRequest("POST", fmt.Sprintf("/admin/users/%d/impersonate", userID), adminToken, nil))
+	assert.NoError(t, err)
+
+	var body dtos.ImpersonationResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	return &body, resp.StatusCode
+}
+
+func TestUserHandler_ImpersonateUser_IssuesRestrictedToken(t *testing.T) {
+	// Given: An admin and a member
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupImpersonationTestApp(db, store)
+	admin, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	// When: The admin impersonates the member
+	session, status := impersonate(t, app, adminToken, member.ID)
+
+	// Then: A short-lived token for the member should be issued and audited
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.NotEmpty(t, session.Token)
+	assert.Equal(t, member.ID, session.User.ID)
+	assert.Positive(t, session.ExpiresIn)
+	events := store.Events()
+	assert.Equal(t, []string{audit.ActionImpersonationStarted}, store.Actions())
+	assert.Equal(t, admin.ID, *events[0].ActorID)
+	assert.Equal(t, fmt.Sprint(member.ID), events[0].ResourceID)
+
+	// And: Sensitive actions should be refused with that token
+	resp, _ := app.Test(authorizedRequest("PUT", fmt.Sprintf("/users/%d", member.ID), session.Token, []byte(`{"password":"hijacked"}`)))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp, _ = app.Test(authorizedRequest("DELETE", "/users/me", session.Token, nil))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var unchanged models.User
+	db.First(&unchanged, member.ID)
+	assert.False(t, unchanged.DeletedAt.Valid)
+	assert.Equal(t, "hashed", unchanged.Password)
+}
+
+func TestUserHandler_ImpersonateUser_Rejections(t *testing.T) {
+	// Given: Two admins and a member
+	db := setupTestDB(t)
+	app := setupImpersonationTestApp(db, audit.NewMemoryStore())
+	admin, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	otherAdmin, _ := createRoleTestUser(db, "other-admin@example.com", models.RoleAdmin)
+	_, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	// When / Then: Admins, oneself and missing users cannot be impersonated
+	_, status := impersonate(t, app, adminToken, otherAdmin.ID)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	_, status = impersonate(t, app, adminToken, admin.ID)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	_, status = impersonate(t, app, adminToken, 999)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	// And: Members cannot impersonate anyone
+	_, status = impersonate(t, app, memberToken, otherAdmin.ID)
+	assert.Equal(t, fiber.StatusForbidden, status)
+}
+
+func TestUserHandler_UpdateUser_WhileImpersonating(t *testing.T) {
+	// Given: An admin impersonating a member
+	db := setupTestDB(t)
+	app := setupImpersonationTestApp(db, audit.NewMemoryStore())
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	session, _ := impersonate(t, app, adminToken, member.ID)
+	path := fmt.Sprintf("/users/%d", member.ID)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "name", body: `{"first_name":"Renamed","last_name":"Member"}`, want: fiber.StatusOK},
+		{name: "same email", body: `{"email":"member@example.com"}`, want: fiber.StatusOK},
+		{name: "new email", body: `{"email":"taken-over@example.com"}`, want: fiber.StatusForbidden},
+		{name: "password", body: `{"password":"hijacked","current_password":"hashed"}`, want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Updating the profile through the impersonation token
+			resp, _ := app.Test(authorizedRequest("PUT", path, session.Token, []byte(tt.body)))
+
+			// Then: Only the credentials are off limits
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+
+	var stored models.User
+	db.First(&stored, member.ID)
+	assert.Equal(t, "Renamed", stored.FirstName)
+	assert.Equal(t, "member@example.com", stored.Email)
+	assert.Equal(t, "hashed", stored.Password)
+}
+
+func TestUserHandler_UpdateUser_TagsImpersonatedAuditEvents(t *testing.T) {
+	// Given: An admin impersonating a member who updates their name
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := fiber.New()
+	userHandler := NewUserHandler(db)
+	userHandler.recorder = audit.NewRecorder(store)
+	app.Put("/users/:id", middleware.JWTMiddleware(db), userHandler.UpdateUser)
+	admin, _ := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	session, _, _ := security.GenerateImpersonationToken(&member, admin.ID)
+
+	// When: Updating the profile through the impersonation token
+	resp, _ := app.Test(authorizedRequest("PUT", fmt.Sprintf("/users/%d", member.ID), session, []byte(`{"first_name":"Renamed"}`)))
+
+	// Then: The audit event should name the member and carry the admin
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	events := store.Events()
+	assert.Len(t, events, 1)
+	assert.Equal(t, member.ID, *events[0].ActorID)
+	assert.JSONEq(t, fmt.Sprintf(`{"impersonator_id":%d}`, admin.ID), events[0].Metadata)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+const jwksCacheControl = "public, max-age=300"
+
+type JWKSHandler struct {
+	keyRing *security.KeyRing
+}
+
+func NewJWKSHandler(keyRing *security.KeyRing) *JWKSHandler {
+	return &JWKSHandler{keyRing: keyRing}
+}
+
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, jwksCacheControl)
+	return c.JSON(h.keyRing.JWKS())
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSHandler_GetJWKS_Unit(t *testing.T) {
+	// Given: A key ring with an Ed25519 key
+	_, private, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := security.NewPrivateSigningKey(private)
+	keyRing, _ := security.NewKeyRing(key)
+	handler := NewJWKSHandler(keyRing)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", handler.GetJWKS)
+
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+	// When: Requesting the JWKS
+	resp, err := app.Test(req)
+
+	// Then: The public key should be published with its key ID
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=300", resp.Header.Get("Cache-Control"))
+
+	var set security.JWKSet
+	json.NewDecoder(resp.Body).Decode(&set)
+	assert.Len(t, set.Keys, 1)
+	assert.Equal(t, key.ID, set.Keys[0].KeyID)
+	assert.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+}
+
+func TestJWKSHandler_GetJWKS_SymmetricKeysHidden_Unit(t *testing.T) {
+	// Given: A key ring with only an HMAC secret
+	key, _ := security.NewHMACKey([]byte("0123456789abcdef0123456789abcdef"))
+	keyRing, _ := security.NewKeyRing(key)
+	handler := NewJWKSHandler(keyRing)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", handler.GetJWKS)
+
+	// When: Requesting the JWKS
+	resp, err := app.Test(httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	// Then: An empty key set should be returned
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var set security.JWKSet
+	json.NewDecoder(resp.Body).Decode(&set)
+	assert.Empty(t, set.Keys)
+}
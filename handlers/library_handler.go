@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// LoanPolicy bounds lending: how many copies a member may hold at once and
+// how long a loan lasts when no due date is given.
+type LoanPolicy struct {
+	MaxOpenLoans int
+	LoanPeriod   time.Duration
+}
+
+var DefaultLoanPolicy = LoanPolicy{MaxOpenLoans: 3, LoanPeriod: 14 * 24 * time.Hour}
+
+// LibraryHandler manages the club's physical copies of games and lending
+// them to members.
+type LibraryHandler struct {
+	copyRepo repositories.GameCopyRepository
+	loanRepo repositories.LoanRepository
+	gameRepo repositories.GameRepository
+	userRepo repositories.UserRepository
+	policy   LoanPolicy
+	recorder *audit.Recorder
+	now      func() time.Time
+}
+
+func NewLibraryHandler(db *gorm.DB, policy LoanPolicy) *LibraryHandler {
+	return NewLibraryHandlerWithRepo(
+		repositories.NewGameCopyRepository(db),
+		repositories.NewLoanRepository(db),
+		repositories.NewGameRepository(db),
+		repositories.NewUserRepository(db),
+		policy,
+	)
+}
+
+func NewLibraryHandlerWithRepo(copyRepo repositories.GameCopyRepository, loanRepo repositories.LoanRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository, policy LoanPolicy) *LibraryHandler {
+	return &LibraryHandler{
+		copyRepo: copyRepo,
+		loanRepo: loanRepo,
+		gameRepo: gameRepo,
+		userRepo: userRepo,
+		policy:   policy,
+		recorder: audit.DefaultRecorder,
+		now:      time.Now,
+	}
+}
+
+func (h *LibraryHandler) GetGameCopies(c *fiber.Ctx) error {
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	copies, err := h.copyRepo.FindByGameID(c.Context(), game.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch copies"))
+	}
+
+	return c.JSON(mappers.ToGameCopyResponseList(copies, h.now()))
+}
+
+func (h *LibraryHandler) CreateGameCopy(c *fiber.Ctx) error {
+	var req dtos.GameCopyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	acquiredAt, err := validateGameCopyRequest(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	if existing, _ := h.copyRepo.FindByBarcode(c.Context(), req.Barcode); existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Barcode already in use"))
+	}
+
+	gameCopy := models.GameCopy{GameID: game.ID}
+	mappers.ApplyGameCopyRequest(&gameCopy, req, acquiredAt)
+	if err := h.copyRepo.Create(c.Context(), &gameCopy); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create copy"))
+	}
+	gameCopy.Game = *game
+
+	h.recorder.RecordChange(c, audit.ResourceGameCopy, audit.ActionCreated, gameCopy.ID, nil, &gameCopy)
+
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToGameCopyResponse(&gameCopy, h.now()))
+}
+
+func (h *LibraryHandler) GetGameCopy(c *fiber.Ctx) error {
+	gameCopy, err := h.findCopy(c)
+	if gameCopy == nil {
+		return err
+	}
+
+	return c.JSON(mappers.ToGameCopyResponse(gameCopy, h.now()))
+}
+
+func (h *LibraryHandler) UpdateGameCopy(c *fiber.Ctx) error {
+	gameCopy, err := h.findCopy(c)
+	if gameCopy == nil {
+		return err
+	}
+
+	var req dtos.GameCopyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	acquiredAt, err := validateGameCopyRequest(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	if req.Barcode != gameCopy.Barcode {
+		if existing, _ := h.copyRepo.FindByBarcode(c.Context(), req.Barcode); existing != nil {
+			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Barcode already in use"))
+		}
+	}
+
+	before := audit.Snapshot(gameCopy)
+	mappers.ApplyGameCopyRequest(gameCopy, req, acquiredAt)
+	if err := h.copyRepo.Update(c.Context(), gameCopy); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update copy"))
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceGameCopy, audit.ActionUpdated, gameCopy.ID, before, gameCopy)
+
+	return c.JSON(mappers.ToGameCopyResponse(gameCopy, h.now()))
+}
+
+// DeleteGameCopy retires a copy. Its loan history is kept; a copy that is
+// out on loan has to be returned first.
+func (h *LibraryHandler) DeleteGameCopy(c *fiber.Ctx) error {
+	gameCopy, err := h.findCopy(c)
+	if gameCopy == nil {
+		return err
+	}
+
+	err = h.copyRepo.Delete(c.Context(), gameCopy.ID)
+	switch {
+	case errors.Is(err, repositories.ErrCopyOnLoan):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Copy is on loan"))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete copy"))
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceGameCopy, audit.ActionDeleted, gameCopy.ID, gameCopy, nil)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *LibraryHandler) GetCopyLoans(c *fiber.Ctx) error {
+	gameCopy, err := h.findCopy(c)
+	if gameCopy == nil {
+		return err
+	}
+
+	loans, err := h.loanRepo.FindByCopyID(c.Context(), gameCopy.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch loans"))
+	}
+
+	return c.JSON(mappers.ToLoanResponseList(loans, h.now()))
+}
+
+// CheckOut lends a copy, given by ID or barcode, to a member.
+func (h *LibraryHandler) CheckOut(c *fiber.Ctx) error {
+	var req dtos.CheckOutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	if req.UserID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("user_id is required"))
+	}
+
+	var gameCopy *models.GameCopy
+	var err error
+	switch {
+	case req.CopyID != 0:
+		gameCopy, err = h.copyRepo.FindByID(c.Context(), req.CopyID)
+	case req.Barcode != "":
+		gameCopy, err = h.copyRepo.FindByBarcode(c.Context(), req.Barcode)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("copy_id or barcode is required"))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	user, err := h.userRepo.FindByID(c.Context(), req.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	now := h.now()
+	dueAt := now.Add(h.policy.LoanPeriod)
+	if req.DueAt != nil {
+		if !req.DueAt.After(now) {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("due_at must be in the future"))
+		}
+		dueAt = *req.DueAt
+	}
+
+	loan := models.Loan{CopyID: gameCopy.ID, UserID: user.ID, CheckedOutAt: now, DueAt: dueAt}
+	err = h.loanRepo.CheckOut(c.Context(), &loan, h.policy.MaxOpenLoans)
+	switch {
+	case errors.Is(err, repositories.ErrLoanLimitReached):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict(fmt.Sprintf("Member already holds %d loans", h.policy.MaxOpenLoans)))
+	case errors.Is(err, repositories.ErrCopyOnLoan):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Copy is already on loan"))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to check out copy"))
+	}
+	loan.Copy = *gameCopy
+	loan.User = *user
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionLoanCheckedOut,
+		ResourceType: audit.ResourceLoan,
+		ResourceID:   audit.ID(loan.ID),
+		Metadata: map[string]interface{}{
+			"copy_id": gameCopy.ID,
+			"user_id": user.ID,
+			"due_at":  dueAt.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToLoanResponse(&loan, now))
+}
+
+// ReturnLoan closes a loan. An optional condition records the state the
+// copy came back in.
+func (h *LibraryHandler) ReturnLoan(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid loan ID"))
+	}
+
+	var req dtos.ReturnLoanRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+		}
+	}
+	condition := models.CopyCondition(req.Condition)
+	if condition != "" && !models.IsValidCopyCondition(condition) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(fmt.Sprintf("unknown condition %q", req.Condition)))
+	}
+
+	loan, err := h.loanRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	now := h.now()
+	overdue := loan.IsOverdue(now)
+	err = h.loanRepo.Return(c.Context(), loan, now, condition)
+	if errors.Is(err, repositories.ErrLoanAlreadyReturned) {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Loan has already been returned"))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to return loan"))
+	}
+
+	metadata := map[string]interface{}{"copy_id": loan.CopyID, "user_id": loan.UserID, "overdue": overdue}
+	if condition != "" {
+		metadata["condition"] = string(condition)
+	}
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionLoanReturned,
+		ResourceType: audit.ResourceLoan,
+		ResourceID:   audit.ID(loan.ID),
+		Metadata:     metadata,
+	})
+
+	return c.JSON(mappers.ToLoanResponse(loan, now))
+}
+
+func (h *LibraryHandler) GetOverdueLoans(c *fiber.Ctx) error {
+	now := h.now()
+	loans, err := h.loanRepo.FindOverdue(c.Context(), now)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch loans"))
+	}
+
+	return c.JSON(mappers.ToLoanResponseList(loans, now))
+}
+
+// GetUserLoans lists a member's loan history. Members see their own;
+// organizers see everyone's.
+func (h *LibraryHandler) GetUserLoans(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+	if userID != uint(id) && !actorRole(c).HasAnyOf(models.RoleOrganizer) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	if _, err := h.userRepo.FindByID(c.Context(), uint(id)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	loans, err := h.loanRepo.FindByUserID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch loans"))
+	}
+
+	return c.JSON(mappers.ToLoanResponseList(loans, h.now()))
+}
+
+// findCopy loads the copy named by the id route parameter. When it returns
+// nil, the error response has already been written.
+func (h *LibraryHandler) findCopy(c *fiber.Ctx) (*models.GameCopy, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid copy ID"))
+	}
+
+	gameCopy, err := h.copyRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	return gameCopy, nil
+}
+
+// validateGameCopyRequest trims the barcode and returns the parsed
+// acquisition date.
+func validateGameCopyRequest(req *dtos.GameCopyRequest) (*time.Time, error) {
+	req.Barcode = strings.TrimSpace(req.Barcode)
+	if req.Barcode == "" {
+		return nil, errors.New("barcode is required")
+	}
+	if req.Condition != "" && !models.IsValidCopyCondition(models.CopyCondition(req.Condition)) {
+		return nil, fmt.Errorf("unknown condition %q", req.Condition)
+	}
+	acquiredAt, err := mappers.ParseAcquiredAt(req.AcquiredAt)
+	if err != nil {
+		return nil, errors.New("acquired_at must be a date such as 2024-05-01")
+	}
+	return acquiredAt, nil
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupLibraryTestApp(db *gorm.DB, policy LoanPolicy) (*fiber.App, *LibraryHandler) {
+	app := fiber.New()
+	libraryHandler := NewLibraryHandler(db, policy)
+	libraryHandler.recorder = audit.NewRecorder(audit.NewMemoryStore())
+
+	authRequired := middleware.JWTMiddleware(db)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	app.Get("/games/:id/copies", libraryHandler.GetGameCopies)
+	app.Post("/games/:id/copies", authRequired, organizerOnly, libraryHandler.CreateGameCopy)
+	app.Get("/copies/:id", libraryHandler.GetGameCopy)
+	app.Put("/copies/:id", authRequired, organizerOnly, libraryHandler.UpdateGameCopy)
+	app.Delete("/copies/:id", authRequired, organizerOnly, libraryHandler.DeleteGameCopy)
+	app.Get("/copies/:id/loans", authRequired, organizerOnly, libraryHandler.GetCopyLoans)
+	app.Post("/loans", authRequired, organizerOnly, libraryHandler.CheckOut)
+	app.Get("/loans/overdue", authRequired, organizerOnly, libraryHandler.GetOverdueLoans)
+	app.Post("/loans/:id/return", authRequired, organizerOnly, libraryHandler.ReturnLoan)
+	app.Get("/users/:id/loans", authRequired, libraryHandler.GetUserLoans)
+	return app, libraryHandler
+}
+
+func createLibraryCopies(db *gorm.DB, barcodes ...string) []models.GameCopy {
+	game := models.NewGameBuilder().SetName("Terraforming Mars").Build()
+	db.Create(game)
+
+	copies := make([]models.GameCopy, len(barcodes))
+	for i, barcode := range barcodes {
+		copies[i] = models.GameCopy{GameID: game.ID, Barcode: barcode, Condition: models.ConditionGood}
+		db.Create(&copies[i])
+	}
+	return copies
+}
+
+func checkOut(t *testing.T, app *fiber.App, token string, req dtos.CheckOutRequest) (*dtos.LoanResponse, int) {
+	body, _ := json.Marshal(req)
+	resp, err := app.Test(authorizedRequest("POST", "/loans", token, body))
+	assert.NoError(t, err)
+
+	var loan dtos.LoanResponse
+	json.NewDecoder(resp.Body).Decode(&loan)
+	return &loan, resp.StatusCode
+}
+
+func getLoans(t *testing.T, app *fiber.App, token, path string) ([]dtos.LoanResponse, int) {
+	resp, err := app.Test(authorizedRequest("GET", path, token, nil))
+	assert.NoError(t, err)
+
+	var loans []dtos.LoanResponse
+	json.NewDecoder(resp.Body).Decode(&loans)
+	return loans, resp.StatusCode
+}
+
+func TestLibraryHandler_CreateGameCopy(t *testing.T) {
+	// Given: A game and an organizer
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, DefaultLoanPolicy)
+	game := models.NewGameBuilder().SetName("Wingspan").Build()
+	db.Create(game)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	_, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	path := fmt.Sprintf("/games/%d/copies", game.ID)
+	body := []byte(`{"barcode":" WS-001 ","condition":"New","location":"Shelf B","acquired_at":"2024-05-01"}`)
+
+	// When: The organizer adds a copy
+	resp, err := app.Test(authorizedRequest("POST", path, organizerToken, body))
+
+	// Then: It is stored and listed as available
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var created dtos.GameCopyResponse
+	json.NewDecoder(resp.Body).Decode(&created)
+	assert.Equal(t, "WS-001", created.Barcode)
+	assert.Equal(t, "New", created.Condition)
+	assert.Equal(t, "2024-05-01", created.AcquiredAt)
+	assert.True(t, created.Available)
+
+	listResp, _ := app.Test(httptest.NewRequest("GET", path, nil))
+	var copies []dtos.GameCopyResponse
+	json.NewDecoder(listResp.Body).Decode(&copies)
+	assert.Len(t, copies, 1)
+	assert.Equal(t, "Wingspan", copies[0].GameName)
+
+	// And: Duplicates, invalid values and members are refused
+	for _, tt := range []struct {
+		token string
+		body  string
+		want  int
+	}{
+		{token: organizerToken, body: `{"barcode":"WS-001"}`, want: fiber.StatusConflict},
+		{token: organizerToken, body: `{"barcode":"WS-002","condition":"Mint"}`, want: fiber.StatusBadRequest},
+		{token: organizerToken, body: `{"barcode":"WS-002","acquired_at":"May 2024"}`, want: fiber.StatusBadRequest},
+		{token: organizerToken, body: `{"location":"Shelf B"}`, want: fiber.StatusBadRequest},
+		{token: memberToken, body: `{"barcode":"WS-002"}`, want: fiber.StatusForbidden},
+	} {
+		resp, _ := app.Test(authorizedRequest("POST", path, tt.token, []byte(tt.body)))
+		assert.Equal(t, tt.want, resp.StatusCode, tt.body)
+	}
+}
+
+func TestLibraryHandler_CheckOutAndReturn(t *testing.T) {
+	// Given: A copy, an organizer and two members
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, DefaultLoanPolicy)
+	copies := createLibraryCopies(db, "TM-001")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	other, _ := createRoleTestUser(db, "other@example.com", models.RoleMember)
+
+	// When: The copy is checked out by barcode
+	loan, status := checkOut(t, app, organizerToken, dtos.CheckOutRequest{Barcode: "TM-001", UserID: member.ID})
+
+	// Then: The loan is due after the default loan period and the copy is out
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, copies[0].ID, loan.CopyID)
+	assert.Equal(t, "Terraforming Mars", loan.GameName)
+	assert.WithinDuration(t, time.Now().Add(DefaultLoanPolicy.LoanPeriod), loan.DueAt, time.Minute)
+	assert.Nil(t, loan.ReturnedAt)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/copies/%d", copies[0].ID), nil))
+	var out dtos.GameCopyResponse
+	json.NewDecoder(resp.Body).Decode(&out)
+	assert.False(t, out.Available)
+	assert.Equal(t, loan.ID, out.CurrentLoan.ID)
+
+	// And: The copy cannot be lent twice or retired while out
+	_, status = checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: other.ID})
+	assert.Equal(t, fiber.StatusConflict, status)
+	resp, _ = app.Test(authorizedRequest("DELETE", fmt.Sprintf("/copies/%d", copies[0].ID), organizerToken, nil))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// When: It comes back worn
+	returnPath := fmt.Sprintf("/loans/%d/return", loan.ID)
+	resp, _ = app.Test(authorizedRequest("POST", returnPath, organizerToken, []byte(`{"condition":"Worn"}`)))
+
+	// Then: The loan is closed and the copy's condition updated
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var returned dtos.LoanResponse
+	json.NewDecoder(resp.Body).Decode(&returned)
+	assert.NotNil(t, returned.ReturnedAt)
+	var stored models.GameCopy
+	db.First(&stored, copies[0].ID)
+	assert.Equal(t, models.ConditionWorn, stored.Condition)
+
+	resp, _ = app.Test(authorizedRequest("POST", returnPath, organizerToken, nil))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// And: The copy can be lent again and both loans are in its history
+	_, status = checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: other.ID})
+	assert.Equal(t, fiber.StatusCreated, status)
+	history, status := getLoans(t, app, organizerToken, fmt.Sprintf("/copies/%d/loans", copies[0].ID))
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, history, 2)
+}
+
+func TestLibraryHandler_CheckOut_EnforcesLoanLimit(t *testing.T) {
+	// Given: A limit of two loans and a member holding two copies
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, LoanPolicy{MaxOpenLoans: 2, LoanPeriod: 24 * time.Hour})
+	copies := createLibraryCopies(db, "TM-001", "TM-002", "TM-003")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	first, _ := checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID})
+	checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[1].ID, UserID: member.ID})
+
+	// When: Lending them a third copy
+	_, status := checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[2].ID, UserID: member.ID})
+
+	// Then: It is refused until one is returned
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	app.Test(authorizedRequest("POST", fmt.Sprintf("/loans/%d/return", first.ID), organizerToken, nil))
+	_, status = checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[2].ID, UserID: member.ID})
+	assert.Equal(t, fiber.StatusCreated, status)
+}
+
+func TestLibraryHandler_CheckOut_RejectsInvalidRequests(t *testing.T) {
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, DefaultLoanPolicy)
+	copies := createLibraryCopies(db, "TM-001")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	member, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		token string
+		req   dtos.CheckOutRequest
+		want  int
+	}{
+		{name: "no copy", token: organizerToken, req: dtos.CheckOutRequest{UserID: member.ID}, want: fiber.StatusBadRequest},
+		{name: "no member", token: organizerToken, req: dtos.CheckOutRequest{CopyID: copies[0].ID}, want: fiber.StatusBadRequest},
+		{name: "due in the past", token: organizerToken, req: dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID, DueAt: &past}, want: fiber.StatusBadRequest},
+		{name: "unknown barcode", token: organizerToken, req: dtos.CheckOutRequest{Barcode: "nope", UserID: member.ID}, want: fiber.StatusNotFound},
+		{name: "unknown member", token: organizerToken, req: dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID + 100}, want: fiber.StatusNotFound},
+		{name: "member lending to themselves", token: memberToken, req: dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID}, want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Checking out with the request
+			_, status := checkOut(t, app, tt.token, tt.req)
+
+			// Then: It should be rejected
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestLibraryHandler_GetOverdueLoans(t *testing.T) {
+	// Given: One loan checked out three weeks ago and one yesterday
+	db := setupTestDB(t)
+	app, handler := setupLibraryTestApp(db, DefaultLoanPolicy)
+	copies := createLibraryCopies(db, "TM-001", "TM-002")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	now := time.Now()
+	handler.now = func() time.Time { return now.Add(-21 * 24 * time.Hour) }
+	late, _ := checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID})
+	handler.now = func() time.Time { return now.Add(-24 * time.Hour) }
+	checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[1].ID, UserID: member.ID})
+	handler.now = func() time.Time { return now }
+
+	// When: Listing overdue loans
+	overdue, status := getLoans(t, app, organizerToken, "/loans/overdue")
+
+	// Then: Only the loan past its due date is listed
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, overdue, 1)
+	assert.Equal(t, late.ID, overdue[0].ID)
+	assert.True(t, overdue[0].Overdue)
+	assert.Equal(t, "Role User", overdue[0].UserName)
+}
+
+func TestLibraryHandler_GetUserLoans_OwnOrOrganizer(t *testing.T) {
+	// Given: A member with a loan
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, DefaultLoanPolicy)
+	copies := createLibraryCopies(db, "TM-001")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	member, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	_, otherToken := createRoleTestUser(db, "other@example.com", models.RoleMember)
+	checkOut(t, app, organizerToken, dtos.CheckOutRequest{CopyID: copies[0].ID, UserID: member.ID})
+	path := fmt.Sprintf("/users/%d/loans", member.ID)
+
+	// When: The member, an organizer and another member ask for the history
+	own, ownStatus := getLoans(t, app, memberToken, path)
+	_, organizerStatus := getLoans(t, app, organizerToken, path)
+	_, otherStatus := getLoans(t, app, otherToken, path)
+
+	// Then: Only the member and the organizer may see it
+	assert.Equal(t, fiber.StatusOK, ownStatus)
+	assert.Len(t, own, 1)
+	assert.Equal(t, "TM-001", own[0].Barcode)
+	assert.Equal(t, fiber.StatusOK, organizerStatus)
+	assert.Equal(t, fiber.StatusForbidden, otherStatus)
+}
+
+func TestLoan_OneOpenLoanPerCopy(t *testing.T) {
+	// Given: A copy with an open loan
+	db := setupTestDB(t)
+	copies := createLibraryCopies(db, "TM-001")
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	now := time.Now()
+	assert.NoError(t, db.Create(&models.Loan{CopyID: copies[0].ID, UserID: member.ID, CheckedOutAt: now, DueAt: now}).Error)
+
+	// When: Inserting a second open loan directly
+	err := db.Create(&models.Loan{CopyID: copies[0].ID, UserID: member.ID, CheckedOutAt: now, DueAt: now}).Error
+
+	// Then: The partial unique index refuses it, but closed loans are allowed
+	assert.Error(t, err)
+	assert.NoError(t, db.Create(&models.Loan{CopyID: copies[0].ID, UserID: member.ID, CheckedOutAt: now, DueAt: now, ReturnedAt: &now}).Error)
+}
+
+func TestLibraryHandler_CreateGameCopy_ReusesRetiredBarcode(t *testing.T) {
+	// Given: A copy that has been retired
+	db := setupTestDB(t)
+	app, _ := setupLibraryTestApp(db, DefaultLoanPolicy)
+	copies := createLibraryCopies(db, "TM-001")
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/copies/%d", copies[0].ID), organizerToken, nil))
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	// When: A new copy is labelled with the same barcode
+	path := fmt.Sprintf("/games/%d/copies", copies[0].GameID)
+	resp, err := app.Test(authorizedRequest("POST", path, organizerToken, []byte(`{"barcode":"TM-001"}`)))
+
+	// Then: It is accepted, but only once
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	resp, _ = app.Test(authorizedRequest("POST", path, organizerToken, []byte(`{"barcode":"TM-001"}`)))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestGameCopyRepository_Delete_ChecksLoansUnderLock(t *testing.T) {
+	// Given: A copy lent out after it was loaded, as when a desk checks it
+	// out while an organizer retires it
+	db := setupTestDB(t)
+	copies := createLibraryCopies(db, "TM-001", "TM-002")
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	now := time.Now()
+	db.Create(&models.Loan{CopyID: copies[0].ID, UserID: member.ID, CheckedOutAt: now, DueAt: now.Add(time.Hour)})
+	copyRepo := repositories.NewGameCopyRepository(db)
+	loanRepo := repositories.NewLoanRepository(db)
+
+	// When: Retiring it, and lending a copy that has been retired
+	deleteErr := copyRepo.Delete(context.Background(), copies[0].ID)
+	assert.NoError(t, copyRepo.Delete(context.Background(), copies[1].ID))
+	checkOutErr := loanRepo.CheckOut(context.Background(), &models.Loan{CopyID: copies[1].ID, UserID: member.ID, CheckedOutAt: now, DueAt: now.Add(time.Hour)}, 3)
+
+	// Then: Both are refused
+	assert.ErrorIs(t, deleteErr, repositories.ErrCopyOnLoan)
+	assert.ErrorIs(t, checkOutErr, gorm.ErrRecordNotFound)
+}
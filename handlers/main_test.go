@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Handler tests hash passwords on almost every request. At bcrypt's default
+// cost, the race detector slows that past app.Test's one-second timeout.
+func TestMain(m *testing.M) {
+	security.DefaultPasswordHasher = security.NewBcryptHasher(bcrypt.MinCost)
+	os.Exit(m.Run())
+}
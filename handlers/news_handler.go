@@ -3,6 +3,7 @@ package handlers
 import (
 	"strconv"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
@@ -14,12 +15,14 @@ import (
 type NewsHandler struct {
 	newsRepo repositories.NewsRepository
 	userRepo repositories.UserRepository
+	recorder *audit.Recorder
 }
 
 func NewNewsHandler(db *gorm.DB) *NewsHandler {
 	return &NewsHandler{
 		newsRepo: repositories.NewNewsRepository(db),
 		userRepo: repositories.NewUserRepository(db),
+		recorder: audit.DefaultRecorder,
 	}
 }
 
@@ -27,6 +30,7 @@ func NewNewsHandlerWithRepo(newsRepo repositories.NewsRepository, userRepo repos
 	return &NewsHandler{
 		newsRepo: newsRepo,
 		userRepo: userRepo,
+		recorder: audit.DefaultRecorder,
 	}
 }
 
@@ -43,25 +47,28 @@ func (h *NewsHandler) GetNews(c *fiber.Ctx) error {
 func (h *NewsHandler) CreateNews(c *fiber.Ctx) error {
 	ctx := c.Context()
 
+	authorID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
 	var req dtos.CreateNewsRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
 	}
 
-	if req.AuthorId == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Author ID is required"))
-	}
-
-	_, err := h.userRepo.FindByID(ctx, req.AuthorId)
+	_, err := h.userRepo.FindByID(ctx, authorID)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid author ID"))
 	}
 
-	news := mappers.ToNewsModel(req, req.AuthorId)
+	news := mappers.ToNewsModel(req, authorID)
 	if err := h.newsRepo.Create(ctx, &news); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create news"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceNews, audit.ActionCreated, news.ID, nil, &news)
+
 	createdNews, _ := h.newsRepo.FindByID(ctx, int(news.ID))
 
 	response := mappers.ToNewsResponse(createdNews, createdNews.Author.FirstName)
@@ -86,6 +93,7 @@ func (h *NewsHandler) UpdateNews(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
 	}
 
+	before := audit.Snapshot(news)
 	news.Title = req.Title
 	news.Description = req.Description
 
@@ -93,6 +101,8 @@ func (h *NewsHandler) UpdateNews(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update news"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceNews, audit.ActionUpdated, news.ID, before, news)
+
 	response := mappers.ToNewsResponse(news, news.Author.FirstName)
 	return c.JSON(response)
 }
@@ -105,7 +115,7 @@ func (h *NewsHandler) DeleteNews(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid news ID"))
 	}
 
-	_, err = h.newsRepo.FindByID(ctx, id)
+	news, err := h.newsRepo.FindByID(ctx, id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
 	}
@@ -114,5 +124,7 @@ func (h *NewsHandler) DeleteNews(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete news"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceNews, audit.ActionDeleted, news.ID, news, nil)
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
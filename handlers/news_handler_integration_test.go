@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func setupNewsTestApp(db *gorm.DB) *fiber.App {
 	newsHandler := NewNewsHandler(db)
 
 	app.Get("/news", newsHandler.GetNews)
-	app.Post("/news", newsHandler.CreateNews)
+	app.Post("/news", middleware.JWTMiddleware(db), newsHandler.CreateNews)
 	app.Put("/news/:id", newsHandler.UpdateNews)
 	app.Delete("/news/:id", newsHandler.DeleteNews)
 
@@ -80,11 +81,11 @@ func TestNewsHandler_CreateNews_Success(t *testing.T) {
 	reqBody := dtos.CreateNewsRequest{
 		Title:       "New Article",
 		Description: "Article content",
-		AuthorId:    user.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/news", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create news request
@@ -99,8 +100,10 @@ func TestNewsHandler_CreateNews_InvalidJSON(t *testing.T) {
 	// Given: An invalid JSON request body
 	db := setupTestDB(t)
 	app := setupNewsTestApp(db)
+	actor := createSessionTestUser(db, "actor@example.com")
 
 	req := httptest.NewRequest("POST", "/news", bytes.NewReader([]byte("invalid")))
+	authenticate(req, actor)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create news request
@@ -111,8 +114,8 @@ func TestNewsHandler_CreateNews_InvalidJSON(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestNewsHandler_CreateNews_MissingAuthorId(t *testing.T) {
-	// Given: A create news request without author ID
+func TestNewsHandler_CreateNews_Unauthenticated(t *testing.T) {
+	// Given: A create request without an authenticated user
 	db := setupTestDB(t)
 	app := setupNewsTestApp(db)
 
@@ -128,32 +131,9 @@ func TestNewsHandler_CreateNews_MissingAuthorId(t *testing.T) {
 	// When: Making the create news request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with bad request
-	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
-}
-
-func TestNewsHandler_CreateNews_InvalidAuthorId(t *testing.T) {
-	// Given: A create news request with non-existent author
-	db := setupTestDB(t)
-	app := setupNewsTestApp(db)
-
-	reqBody := dtos.CreateNewsRequest{
-		Title:       "New Article",
-		Description: "Content",
-		AuthorId:    999,
-	}
-	body, _ := json.Marshal(reqBody)
-
-	req := httptest.NewRequest("POST", "/news", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-
-	// When: Making the create news request
-	resp, err := app.Test(req)
-
-	// Then: The request should fail with bad request
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestNewsHandler_CreateNews_ReturnsCreatedNews(t *testing.T) {
@@ -167,11 +147,11 @@ func TestNewsHandler_CreateNews_ReturnsCreatedNews(t *testing.T) {
 	reqBody := dtos.CreateNewsRequest{
 		Title:       "Created News",
 		Description: "Created Description",
-		AuthorId:    user.ID,
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("POST", "/news", bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the create news request
@@ -93,7 +93,7 @@ func TestNewsHandler_CreateNews_Success_Unit(t *testing.T) {
 	handler := NewNewsHandlerWithRepo(mockNewsRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/news", handler.CreateNews)
+	app.Post("/news", withActor(1, models.RoleMember), handler.CreateNews)
 
 	author := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(author, nil)
@@ -108,7 +108,6 @@ func TestNewsHandler_CreateNews_Success_Unit(t *testing.T) {
 	reqBody := dtos.CreateNewsRequest{
 		Title:       "New News",
 		Description: "News Description",
-		AuthorId:    1,
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -132,7 +131,7 @@ func TestNewsHandler_CreateNews_InvalidJSON_Unit(t *testing.T) {
 	handler := NewNewsHandlerWithRepo(mockNewsRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/news", handler.CreateNews)
+	app.Post("/news", withActor(1, models.RoleMember), handler.CreateNews)
 
 	req := httptest.NewRequest("POST", "/news", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -145,8 +144,8 @@ func TestNewsHandler_CreateNews_InvalidJSON_Unit(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
-func TestNewsHandler_CreateNews_MissingAuthorID_Unit(t *testing.T) {
-	// Given: A request without author ID
+func TestNewsHandler_CreateNews_Unauthenticated_Unit(t *testing.T) {
+	// Given: A request without an authenticated user
 	mockNewsRepo := new(mocks.MockNewsRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	handler := NewNewsHandlerWithRepo(mockNewsRepo, mockUserRepo)
@@ -166,9 +165,9 @@ func TestNewsHandler_CreateNews_MissingAuthorID_Unit(t *testing.T) {
 	// When: Making the create news request
 	resp, err := app.Test(req)
 
-	// Then: The request should fail with bad request
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestNewsHandler_CreateNews_AuthorNotFound_Unit(t *testing.T) {
@@ -178,14 +177,13 @@ func TestNewsHandler_CreateNews_AuthorNotFound_Unit(t *testing.T) {
 	handler := NewNewsHandlerWithRepo(mockNewsRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/news", handler.CreateNews)
+	app.Post("/news", withActor(999, models.RoleMember), handler.CreateNews)
 
 	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
 	reqBody := dtos.CreateNewsRequest{
 		Title:       "New News",
 		Description: "News Description",
-		AuthorId:    999,
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -208,7 +206,7 @@ func TestNewsHandler_CreateNews_DatabaseError_Unit(t *testing.T) {
 	handler := NewNewsHandlerWithRepo(mockNewsRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/news", handler.CreateNews)
+	app.Post("/news", withActor(1, models.RoleMember), handler.CreateNews)
 
 	author := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(author, nil)
@@ -217,7 +215,6 @@ func TestNewsHandler_CreateNews_DatabaseError_Unit(t *testing.T) {
 	reqBody := dtos.CreateNewsRequest{
 		Title:       "New News",
 		Description: "News Description",
-		AuthorId:    1,
 	}
 	body, _ := json.Marshal(reqBody)
 
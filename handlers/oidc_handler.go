@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/oidc"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const oidcFlowCookie = "oidc_flow"
+
+type OIDCHandler struct {
+	userRepo     repositories.UserRepository
+	identityRepo repositories.UserIdentityRepository
+	sessions     *security.SessionManager
+	providers    map[string]*oidc.Provider
+	recorder     *audit.Recorder
+}
+
+func NewOIDCHandler(db *gorm.DB) *OIDCHandler {
+	return NewOIDCHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewUserIdentityRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewDefaultRevokedTokenRepository(db),
+		oidc.DefaultProviders,
+	)
+}
+
+func NewOIDCHandlerWithRepo(userRepo repositories.UserRepository, identityRepo repositories.UserIdentityRepository, refreshTokenRepo repositories.RefreshTokenRepository, revokedTokenRepo repositories.RevokedTokenRepository, providers map[string]*oidc.Provider) *OIDCHandler {
+	return &OIDCHandler{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		sessions:     security.NewSessionManager(userRepo, refreshTokenRepo, revokedTokenRepo),
+		providers:    providers,
+		recorder:     audit.DefaultRecorder,
+	}
+}
+
+// Login starts an authorization code flow with PKCE and redirects the
+// browser to the provider.
+func (oh *OIDCHandler) Login(c *fiber.Ctx) error {
+	provider, ok := oh.providers[c.Params("provider")]
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	flow, challenge, err := oidc.NewFlowState(provider.Name())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to start login"))
+	}
+
+	authURL, err := provider.AuthCodeURL(c.Context(), flow.State, flow.Nonce, challenge)
+	if err != nil {
+		log.Printf("Failed to build authorization URL for %s: %v", provider.Name(), err)
+		return c.Status(fiber.StatusBadGateway).JSON(utils.BadGateway("Identity provider is unavailable"))
+	}
+
+	cookieValue, err := flow.Encode()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to start login"))
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    cookieValue,
+		Path:     strings.TrimSuffix(c.Path(), "/login"),
+		Expires:  time.Now().Add(oidc.FlowTTL),
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback finishes the flow: it checks state, redeems the code with the
+// PKCE verifier, verifies the ID token and signs the linked user in.
+func (oh *OIDCHandler) Callback(c *fiber.Ctx) error {
+	provider, ok := oh.providers[c.Params("provider")]
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	cookieValue := c.Cookies(oidcFlowCookie)
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcFlowCookie,
+		Path:     strings.TrimSuffix(c.Path(), "/callback"),
+		Expires:  time.Unix(0, 0),
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	if reason := c.Query("error"); reason != "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Login was cancelled or denied by the identity provider"))
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Authorization code is required"))
+	}
+
+	flow, err := oidc.DecodeFlowState(cookieValue, provider.Name(), c.Query("state"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	claims, err := provider.Exchange(c.Context(), code, flow.CodeVerifier, flow.Nonce)
+	if err != nil {
+		log.Printf("OIDC login with %s failed: %v", provider.Name(), err)
+		oh.recorder.Record(c, audit.Event{
+			Action:       audit.ActionLoginFailed,
+			ResourceType: audit.ResourceUser,
+			Metadata:     map[string]interface{}{"provider": provider.Name(), "reason": "oidc_exchange_failed"},
+		})
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Login with the identity provider failed"))
+	}
+
+	user, err := oh.resolveUser(c, provider.Name(), claims)
+	if err != nil {
+		return nil
+	}
+
+	if user.TOTPEnabled {
+		return respondWithMFAChallenge(c, user)
+	}
+	recordLogin(c, oh.recorder, user, "oidc", map[string]interface{}{"provider": provider.Name()})
+	return respondWithSession(c, oh.sessions, user, fiber.StatusOK)
+}
+
+// resolveUser finds the user linked to the external identity. An unknown
+// identity is linked to an existing account only when both sides have
+// verified the email, so nobody can take over an account by registering
+// its address at a provider that does not check ownership.
+func (oh *OIDCHandler) resolveUser(c *fiber.Ctx, provider string, claims *oidc.IDTokenClaims) (*models.User, error) {
+	ctx := c.Context()
+	identity, err := oh.identityRepo.FindByProviderSubject(ctx, provider, claims.Subject)
+	if err == nil {
+		user, err := oh.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
+			return nil, errResponseSent
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
+		return nil, errResponseSent
+	}
+
+	if claims.Email == "" || !isValidEmail(claims.Email) {
+		c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Identity provider did not share a valid email address"))
+		return nil, errResponseSent
+	}
+
+	user, err := oh.userRepo.FindByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		if !claims.EmailVerified || !user.EmailVerified {
+			c.Status(fiber.StatusConflict).JSON(utils.Conflict("An account with this email already exists; sign in with your password and verify your email first"))
+			return nil, errResponseSent
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if user, err = oh.createUser(c, claims); err != nil {
+			return nil, err
+		}
+	default:
+		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Database access error"))
+		return nil, errResponseSent
+	}
+
+	if err := oh.identityRepo.Create(ctx, &models.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to link identity"))
+		return nil, errResponseSent
+	}
+	return user, nil
+}
+
+// createUser registers an account without a password; the user can set one
+// later through the password reset flow.
+func (oh *OIDCHandler) createUser(c *fiber.Ctx, claims *oidc.IDTokenClaims) (*models.User, error) {
+	user := &models.User{
+		FirstName:     claims.GivenName,
+		LastName:      claims.FamilyName,
+		Email:         claims.Email,
+		Role:          models.RoleMember,
+		EmailVerified: claims.EmailVerified,
+	}
+	if user.FirstName == "" {
+		user.FirstName = claims.Name
+	}
+	if user.FirstName == "" {
+		user.FirstName = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := oh.userRepo.Create(c.Context(), user); err != nil {
+		c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create user"))
+		return nil, errResponseSent
+	}
+	return user, nil
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/oidc"
+	"github.com/PI-Team04-GameClub/gameclub-backend/oidc/oidctest"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const oidcTestClientID = "gameclub-test"
+
+func setupOIDCTestApp(t *testing.T, db *gorm.DB) (*fiber.App, *oidctest.Server) {
+	server := oidctest.NewServer(oidcTestClientID)
+	t.Cleanup(server.Close)
+
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:        "stub",
+		Issuer:      server.URL,
+		ClientID:    oidcTestClientID,
+		RedirectURL: "http://localhost/auth/oidc/stub/callback",
+	}, server.Client())
+
+	userRepo := repositories.NewUserRepository(db)
+	oidcHandler := NewOIDCHandlerWithRepo(
+		userRepo,
+		repositories.NewUserIdentityRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewRevokedTokenRepository(db),
+		map[string]*oidc.Provider{"stub": provider},
+	)
+
+	app := fiber.New()
+	app.Get("/auth/oidc/:provider/login", oidcHandler.Login)
+	app.Get("/auth/oidc/:provider/callback", oidcHandler.Callback)
+	return app, server
+}
+
+// startOIDCLogin calls the login endpoint and returns the provider
+// authorization URL together with the flow cookie it set.
+func startOIDCLogin(t *testing.T, app *fiber.App) (string, *http.Cookie) {
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oidc/stub/login", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+
+	var flowCookie *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == oidcFlowCookie {
+			flowCookie = cookie
+		}
+	}
+	assert.NotNil(t, flowCookie)
+	return resp.Header.Get("Location"), flowCookie
+}
+
+func finishOIDCLogin(t *testing.T, app *fiber.App, cookie *http.Cookie, code, state string) *http.Response {
+	query := url.Values{"code": {code}, "state": {state}}
+	req := httptest.NewRequest("GET", "/auth/oidc/stub/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func oidcLogin(t *testing.T, app *fiber.App, server *oidctest.Server, claims jwt.MapClaims) *http.Response {
+	authURL, cookie := startOIDCLogin(t, app)
+	code, state := server.Authorize(authURL, claims)
+	return finishOIDCLogin(t, app, cookie, code, state)
+}
+
+func TestOIDCHandler_Login_RedirectsWithPKCE(t *testing.T) {
+	// Given: A configured provider
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+
+	// When: Starting a login
+	authURL, cookie := startOIDCLogin(t, app)
+
+	// Then: The browser should be sent to the provider with state, nonce and an S256 challenge
+	parsed, _ := url.Parse(authURL)
+	query := parsed.Query()
+	assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.NotEmpty(t, query.Get("state"))
+	assert.NotEmpty(t, query.Get("nonce"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, "/auth/oidc/stub", cookie.Path)
+}
+
+func TestOIDCHandler_Login_UnknownProvider(t *testing.T) {
+	// Given: No provider named "missing"
+	db := setupTestDB(t)
+	app, _ := setupOIDCTestApp(t, db)
+
+	// When: Starting a login with it
+	resp, _ := app.Test(httptest.NewRequest("GET", "/auth/oidc/missing/login", nil))
+
+	// Then: The provider should not be found
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestOIDCHandler_Callback_CreatesUserAndIssuesTokens(t *testing.T) {
+	// Given: A new user consenting at the provider
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+
+	// When: Completing the flow
+	resp := oidcLogin(t, app, server, jwt.MapClaims{
+		"sub":            "external-1",
+		"email":          "new.oidc@example.com",
+		"email_verified": true,
+		"given_name":     "Grace",
+		"family_name":    "Hopper",
+	})
+
+	// Then: A verified member should be created, linked and signed in
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.NotEmpty(t, body.Token)
+	assert.NotEmpty(t, body.RefreshToken)
+	assert.Equal(t, "new.oidc@example.com", body.Email)
+	assert.True(t, body.EmailVerified)
+
+	var user models.User
+	db.Where("email = ?", "new.oidc@example.com").First(&user)
+	assert.Equal(t, "Grace", user.FirstName)
+	assert.Equal(t, "Hopper", user.LastName)
+	assert.Equal(t, models.RoleMember, user.Role)
+	assert.Empty(t, user.Password)
+
+	var identity models.UserIdentity
+	db.Where("provider = ? AND subject = ?", "stub", "external-1").First(&identity)
+	assert.Equal(t, user.ID, identity.UserID)
+}
+
+func TestOIDCHandler_Callback_ReturningIdentityUsesLinkedUser(t *testing.T) {
+	// Given: An identity that already signed in once
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	first := oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-2", "email": "linked@example.com", "email_verified": true})
+	var firstBody dtos.AuthResponse
+	json.NewDecoder(first.Body).Decode(&firstBody)
+
+	// When: Signing in again after the email changed at the provider
+	resp := oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-2", "email": "changed@example.com", "email_verified": true})
+
+	// Then: The same user should be signed in without creating another account
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, firstBody.ID, body.ID)
+
+	var count int64
+	db.Model(&models.User{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestOIDCHandler_Callback_LinksVerifiedExistingUser(t *testing.T) {
+	// Given: A local user with a verified email
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	user := createSessionTestUser(db, "existing@example.com")
+	db.Model(&user).Update("email_verified", true)
+
+	// When: Signing in with a provider that verified the same email
+	resp := oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-3", "email": "existing@example.com", "email_verified": true})
+
+	// Then: The identity should be linked to the existing user
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dtos.AuthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, user.ID, body.ID)
+
+	var identity models.UserIdentity
+	db.Where("subject = ?", "external-3").First(&identity)
+	assert.Equal(t, user.ID, identity.UserID)
+}
+
+func TestOIDCHandler_Callback_RefusesToLinkUnverifiedEmail(t *testing.T) {
+	tests := []struct {
+		name             string
+		localVerified    bool
+		providerVerified bool
+	}{
+		{name: "local email unverified", localVerified: false, providerVerified: true},
+		{name: "provider email unverified", localVerified: true, providerVerified: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: A local user with the same email as the external identity
+			db := setupTestDB(t)
+			app, server := setupOIDCTestApp(t, db)
+			user := createSessionTestUser(db, "victim@example.com")
+			db.Model(&user).Update("email_verified", tt.localVerified)
+
+			// When: Signing in with the provider
+			resp := oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-4", "email": "victim@example.com", "email_verified": tt.providerVerified})
+
+			// Then: The accounts should not be linked
+			assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+			var count int64
+			db.Model(&models.UserIdentity{}).Count(&count)
+			assert.Equal(t, int64(0), count)
+		})
+	}
+}
+
+func TestOIDCHandler_Callback_RejectsStateMismatch(t *testing.T) {
+	// Given: A started login
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	authURL, cookie := startOIDCLogin(t, app)
+	code, _ := server.Authorize(authURL, jwt.MapClaims{"sub": "external-5", "email": "csrf@example.com"})
+
+	// When: The callback arrives with a forged state
+	resp := finishOIDCLogin(t, app, cookie, code, "forged-state")
+
+	// Then: The request should be rejected without creating a user
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var count int64
+	db.Model(&models.User{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestOIDCHandler_Callback_RejectsMissingFlowCookie(t *testing.T) {
+	// Given: A code and state obtained without this browser's flow cookie
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	authURL, _ := startOIDCLogin(t, app)
+	code, state := server.Authorize(authURL, jwt.MapClaims{"sub": "external-6", "email": "nocookie@example.com"})
+
+	// When: Calling back without the cookie
+	resp := finishOIDCLogin(t, app, nil, code, state)
+
+	// Then: The request should be rejected
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestOIDCHandler_Callback_RejectsReusedCode(t *testing.T) {
+	// Given: A completed login
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	authURL, cookie := startOIDCLogin(t, app)
+	code, state := server.Authorize(authURL, jwt.MapClaims{"sub": "external-7", "email": "replay@example.com"})
+	first := finishOIDCLogin(t, app, cookie, code, state)
+	assert.Equal(t, fiber.StatusOK, first.StatusCode)
+
+	// When: Replaying the same callback
+	resp := finishOIDCLogin(t, app, cookie, code, state)
+
+	// Then: The provider should refuse the code
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestOIDCHandler_Callback_RequiresMFAWhenEnabled(t *testing.T) {
+	// Given: A linked user with two-factor login enabled
+	db := setupTestDB(t)
+	app, server := setupOIDCTestApp(t, db)
+	oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-8", "email": "mfa.oidc@example.com", "email_verified": true})
+	db.Model(&models.User{}).Where("email = ?", "mfa.oidc@example.com").Update("totp_enabled", true)
+
+	// When: Signing in with the provider again
+	resp := oidcLogin(t, app, server, jwt.MapClaims{"sub": "external-8", "email": "mfa.oidc@example.com", "email_verified": true})
+
+	// Then: An MFA challenge should be returned instead of tokens
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body dtos.MFAChallengeResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.True(t, body.MFARequired)
+	assert.NotEmpty(t, body.MFAToken)
+}
+
+func TestOIDCHandler_Callback_ProviderError(t *testing.T) {
+	// Given: A user who denied consent at the provider
+	db := setupTestDB(t)
+	app, _ := setupOIDCTestApp(t, db)
+
+	// When: The provider redirects back with an error
+	resp, _ := app.Test(httptest.NewRequest("GET", "/auth/oidc/stub/callback?error=access_denied&state=x", nil))
+
+	// Then: The login should fail
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	playMonthLayout        = "2006-01"
+	defaultMostPlayedLimit = 10
+	maxMostPlayedLimit     = 50
+	maxPlayDurationMinutes = 7 * 24 * 60
+)
+
+// ratingMu serializes rating writes in this process. A play is stored and
+// rated while holding it, so a replay cannot read a play that is about to
+// be rated on top of its result and count it twice.
+var ratingMu sync.Mutex
+
+// PlayHandler logs the games members actually play and answers questions
+// about them: what gets played most, who wins and who beats whom.
+type PlayHandler struct {
+	playRepo   repositories.PlayRepository
+	gameRepo   repositories.GameRepository
+	userRepo   repositories.UserRepository
+	ratingRepo repositories.PlayerRatingRepository
+	engine     *rating.Engine
+	recorder   *audit.Recorder
+	now        func() time.Time
+	// background runs work the response must not wait for.
+	background func(task func())
+}
+
+func NewPlayHandler(db *gorm.DB) *PlayHandler {
+	return NewPlayHandlerWithRepo(
+		repositories.NewPlayRepository(db),
+		repositories.NewGameRepository(db),
+		repositories.NewUserRepository(db),
+		repositories.NewPlayerRatingRepository(db),
+	)
+}
+
+func NewPlayHandlerWithRepo(playRepo repositories.PlayRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository, ratingRepo repositories.PlayerRatingRepository) *PlayHandler {
+	return &PlayHandler{
+		playRepo:   playRepo,
+		gameRepo:   gameRepo,
+		userRepo:   userRepo,
+		ratingRepo: ratingRepo,
+		engine:     rating.DefaultEngine,
+		recorder:   audit.DefaultRecorder,
+		now:        time.Now,
+		background: runInBackground,
+	}
+}
+
+// CreatePlay logs a play. Members log plays they took part in; organizers
+// may log any play. Plays logged by organizers are official, and official
+// competitive plays update the players' ratings.
+func (h *PlayHandler) CreatePlay(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	var req dtos.PlayRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	now := h.now()
+	playedAt, err := validatePlayRequest(&req, now)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	game, err := h.gameRepo.FindByID(c.Context(), strconv.FormatUint(uint64(req.GameID), 10))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	play := mappers.ToPlayModel(req, userID, playedAt)
+	play.Official = actorRole(c).HasAnyOf(models.RoleOrganizer)
+	if !play.HasPlayer(userID) && !play.Official {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	err = h.createAndRate(c.Context(), &play)
+	if errors.Is(err, repositories.ErrPlayerNotFound) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Unknown player"))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to log play"))
+	}
+
+	created, err := h.playRepo.FindByID(c.Context(), play.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to retrieve logged play"))
+	}
+	created.Game = *game
+
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToPlayResponse(created))
+}
+
+func (h *PlayHandler) GetPlay(c *fiber.Ctx) error {
+	play, err := h.findPlay(c)
+	if play == nil {
+		return err
+	}
+
+	return c.JSON(mappers.ToPlayResponse(play))
+}
+
+// DeletePlay removes a play. Whoever logged it can delete it, and organizers
+// can remove anyone's; the latter is audited. Deleting a rated play
+// recalculates ratings without it.
+func (h *PlayHandler) DeletePlay(c *fiber.Ctx) error {
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	play, err := h.findPlay(c)
+	if play == nil {
+		return err
+	}
+
+	ownPlay := play.LoggedByID == userID
+	if !ownPlay && !actorRole(c).HasAnyOf(models.RoleOrganizer) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	if err := h.playRepo.Delete(c.Context(), play); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete play"))
+	}
+
+	h.rerate(play)
+
+	if !ownPlay {
+		h.recorder.RecordChange(c, audit.ResourcePlay, audit.ActionDeleted, play.ID, play, nil)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *PlayHandler) GetGamePlays(c *fiber.Ctx) error {
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	plays, err := h.playRepo.FindByGameID(c.Context(), game.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch plays"))
+	}
+
+	return c.JSON(mappers.ToPlayResponseList(plays))
+}
+
+func (h *PlayHandler) GetUserPlays(c *fiber.Ctx) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+
+	plays, err := h.playRepo.FindByUserID(c.Context(), user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch plays"))
+	}
+
+	return c.JSON(mappers.ToPlayResponseList(plays))
+}
+
+// GetMostPlayed ranks games by plays in a calendar month, given as
+// ?month=2024-05 and defaulting to the current one.
+func (h *PlayHandler) GetMostPlayed(c *fiber.Ctx) error {
+	now := h.now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month := c.Query("month"); month != "" {
+		parsed, err := time.ParseInLocation(playMonthLayout, month, now.Location())
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("month must look like 2024-05"))
+		}
+		from = parsed
+	}
+
+	limit := c.QueryInt("limit", defaultMostPlayedLimit)
+	if limit < 1 || limit > maxMostPlayedLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(fmt.Sprintf("limit must be between 1 and %d", maxMostPlayedLimit)))
+	}
+
+	counts, err := h.playRepo.MostPlayed(c.Context(), from, from.AddDate(0, 1, 0), limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch plays"))
+	}
+
+	return c.JSON(mappers.ToMostPlayedGameResponseList(counts))
+}
+
+// GetUserWinRates lists, per game, how often the user won the plays they
+// took part in. Cooperative wins count as wins.
+func (h *PlayHandler) GetUserWinRates(c *fiber.Ctx) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+
+	rates, err := h.playRepo.WinRates(c.Context(), user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch win rates"))
+	}
+
+	return c.JSON(mappers.ToGameWinRateResponseList(rates))
+}
+
+// GetUserNemesis names the opponent who most often won a competitive play
+// the user lost.
+func (h *PlayHandler) GetUserNemesis(c *fiber.Ctx) error {
+	user, err := h.findUser(c)
+	if user == nil {
+		return err
+	}
+
+	opponent, err := h.playRepo.Nemesis(c.Context(), user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch nemesis"))
+	}
+
+	return c.JSON(mappers.ToNemesisResponse(user.ID, opponent))
+}
+
+// createAndRate stores the play and feeds it to the rating engine. Ratings
+// follow the order plays took place in, as a replay does, so a play dated
+// before others that were already rated triggers a replay instead.
+func (h *PlayHandler) createAndRate(ctx context.Context, play *models.Play) error {
+	if !rating.Rated(play) {
+		return h.playRepo.Create(ctx, play)
+	}
+
+	ratingMu.Lock()
+	backdated, err := h.playRepo.HasHistoryAfter(ctx, play.PlayedAt)
+	if err == nil {
+		err = h.playRepo.Create(ctx, play)
+	}
+	if err == nil && !backdated {
+		h.ratePlay(ctx, play)
+	}
+	ratingMu.Unlock()
+
+	if err == nil && backdated {
+		h.rerate(play)
+	}
+	return err
+}
+
+// ratePlay applies a new play to the current ratings; the caller holds
+// ratingMu. The play is already stored, so a failure is only logged;
+// recalculating ratings catches up.
+func (h *PlayHandler) ratePlay(ctx context.Context, play *models.Play) {
+	userIDs := make([]uint, len(play.Players))
+	for i, player := range play.Players {
+		userIDs[i] = player.UserID
+	}
+	err := h.ratingRepo.Apply(ctx, play.GameID, userIDs, func(current []models.PlayerRating) []models.PlayerRating {
+		return h.engine.Apply(current, play)
+	})
+	if err != nil {
+		log.Printf("Failed to update ratings for play %d: %v", play.ID, err)
+	}
+}
+
+// rerate replays the whole history in the background after a rated play
+// was deleted or logged out of order, as its rating changes cannot be
+// taken back or slotted in on their own. Like ratePlay, a failure is only
+// logged.
+func (h *PlayHandler) rerate(changed *models.Play) {
+	if !rating.Rated(changed) {
+		return
+	}
+
+	h.background(func() {
+		ratingMu.Lock()
+		defer ratingMu.Unlock()
+
+		ctx := context.Background()
+		plays, err := h.playRepo.FindHistory(ctx)
+		if err == nil {
+			err = h.ratingRepo.ReplaceAll(ctx, h.engine.Replay(plays))
+		}
+		if err != nil {
+			log.Printf("Failed to recalculate ratings after play %d changed: %v", changed.ID, err)
+		}
+	})
+}
+
+// findPlay loads the play named by the id route parameter. When it returns
+// nil, the error response has already been written.
+func (h *PlayHandler) findPlay(c *fiber.Ctx) (*models.Play, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid play ID"))
+	}
+
+	play, err := h.playRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	return play, nil
+}
+
+// findUser loads the user named by the id route parameter. When it returns
+// nil, the error response has already been written.
+func (h *PlayHandler) findUser(c *fiber.Ctx) (*models.User, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	user, err := h.userRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	return user, nil
+}
+
+// validatePlayRequest checks the participants and the outcome and returns
+// when the play took place, defaulting to now.
+func validatePlayRequest(req *dtos.PlayRequest, now time.Time) (time.Time, error) {
+	if req.GameID == 0 {
+		return time.Time{}, errors.New("game_id is required")
+	}
+	if len(req.Players) == 0 || len(req.Players) > models.MaxPlayPlayers {
+		return time.Time{}, fmt.Errorf("a play needs between 1 and %d players", models.MaxPlayPlayers)
+	}
+	if req.DurationMinutes < 0 || req.DurationMinutes > maxPlayDurationMinutes {
+		return time.Time{}, fmt.Errorf("duration_minutes must be between 0 and %d", maxPlayDurationMinutes)
+	}
+
+	seen := map[uint]bool{}
+	winners, scored := 0, 0
+	for _, player := range req.Players {
+		if player.UserID == 0 {
+			return time.Time{}, errors.New("every player needs a user_id")
+		}
+		if seen[player.UserID] {
+			return time.Time{}, fmt.Errorf("player %d is listed twice", player.UserID)
+		}
+		seen[player.UserID] = true
+		if player.Winner {
+			winners++
+		}
+		if player.Score != nil {
+			scored++
+		}
+	}
+
+	if req.Cooperative {
+		if req.Won == nil {
+			return time.Time{}, errors.New("won is required for a cooperative play")
+		}
+		if winners > 0 {
+			return time.Time{}, errors.New("players of a cooperative play win or lose together; use won instead")
+		}
+	} else {
+		if req.Won != nil {
+			return time.Time{}, errors.New("won only applies to cooperative plays")
+		}
+		if winners == 0 && scored == 0 {
+			return time.Time{}, errors.New("flag the winners or give scores")
+		}
+	}
+
+	playedAt := now
+	if req.PlayedAt != nil {
+		if req.PlayedAt.After(now) {
+			return time.Time{}, errors.New("played_at cannot be in the future")
+		}
+		playedAt = *req.PlayedAt
+	}
+	return playedAt, nil
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var playTestNow = time.Date(2024, time.May, 20, 18, 0, 0, 0, time.UTC)
+
+func setupPlayTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	playHandler := NewPlayHandler(db)
+	playHandler.recorder = audit.NewRecorder(store)
+	playHandler.now = func() time.Time { return playTestNow }
+	playHandler.background = func(task func()) { task() }
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeResultsWrite)
+	app.Post("/plays", authRequired, canWrite, playHandler.CreatePlay)
+	app.Get("/plays/most-played", playHandler.GetMostPlayed)
+	app.Get("/plays/:id", playHandler.GetPlay)
+	app.Delete("/plays/:id", authRequired, canWrite, playHandler.DeletePlay)
+	app.Get("/games/:id/plays", playHandler.GetGamePlays)
+	app.Get("/users/:id/plays", playHandler.GetUserPlays)
+	app.Get("/users/:id/win-rates", playHandler.GetUserWinRates)
+	app.Get("/users/:id/nemesis", playHandler.GetUserNemesis)
+	return app
+}
+
+func createPlayTestGame(db *gorm.DB, name string) *models.Game {
+	game := models.NewGameBuilder().SetName(name).Build()
+	db.Create(game)
+	return game
+}
+
+func logPlay(t *testing.T, app *fiber.App, token string, req dtos.PlayRequest) (*dtos.PlayResponse, int) {
+	body, _ := json.Marshal(req)
+	resp, err := app.Test(authorizedRequest("POST", "/plays", token, body))
+	assert.NoError(t, err)
+
+	var play dtos.PlayResponse
+	json.NewDecoder(resp.Body).Decode(&play)
+	return &play, resp.StatusCode
+}
+
+func getPlayJSON(t *testing.T, app *fiber.App, path string, target interface{}) int {
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	assert.NoError(t, err)
+	json.NewDecoder(resp.Body).Decode(target)
+	return resp.StatusCode
+}
+
+func playedOn(month time.Month, day int) *time.Time {
+	playedAt := time.Date(2024, month, day, 20, 0, 0, 0, time.UTC)
+	return &playedAt
+}
+
+func points(score int) *int {
+	return &score
+}
+
+func TestPlayHandler_CreatePlay(t *testing.T) {
+	// Given: Two members, an organizer and a game
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	game := createPlayTestGame(db, "Catan")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	organizer, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	// When: The organizer logs Anna and Ben's scored play without flagging a winner
+	play, status := logPlay(t, app, organizerToken, dtos.PlayRequest{
+		GameID:          game.ID,
+		PlayedAt:        playedOn(time.May, 18),
+		DurationMinutes: 75,
+		Players: []dtos.PlayPlayerRequest{
+			{UserID: anna.ID, Score: points(8)},
+			{UserID: ben.ID, Score: points(10)},
+		},
+	})
+
+	// Then: The highest score wins and the play is listed for the game and both players
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, "Catan", play.GameName)
+	assert.Equal(t, organizer.ID, play.LoggedByID)
+	assert.Nil(t, play.Won)
+	assert.Len(t, play.Players, 2)
+	assert.False(t, play.Players[0].Winner)
+	assert.True(t, play.Players[1].Winner)
+
+	var gamePlays, benPlays []dtos.PlayResponse
+	assert.Equal(t, fiber.StatusOK, getPlayJSON(t, app, fmt.Sprintf("/games/%d/plays", game.ID), &gamePlays))
+	assert.Equal(t, fiber.StatusOK, getPlayJSON(t, app, fmt.Sprintf("/users/%d/plays", ben.ID), &benPlays))
+	assert.Len(t, gamePlays, 1)
+	assert.Len(t, benPlays, 1)
+	assert.Equal(t, 75, benPlays[0].DurationMinutes)
+}
+
+func TestPlayHandler_CreatePlay_WithAPIKey(t *testing.T) {
+	// Given: A bot owner with a results key and a news key
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	game := createPlayTestGame(db, "Patchwork")
+	owner, _ := createRoleTestUser(db, "bot@example.com", models.RoleMember)
+	keys := security.NewAPIKeyManager(repositories.NewAPIKeyRepository(db))
+	resultsKey, _, _ := keys.Create(context.Background(), owner.ID, "Discord bot", []string{models.ScopeResultsWrite}, 0)
+	newsKey, _, _ := keys.Create(context.Background(), owner.ID, "News bot", []string{models.ScopeNewsWrite}, 0)
+	body, _ := json.Marshal(dtos.PlayRequest{
+		GameID:  game.ID,
+		Players: []dtos.PlayPlayerRequest{{UserID: owner.ID, Score: points(40)}},
+	})
+
+	// When: Logging the play with each key
+	statuses := make([]int, 0, 2)
+	for _, key := range []string{newsKey, resultsKey} {
+		req := httptest.NewRequest("POST", "/plays", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "ApiKey "+key)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	// Then: Only the key with the results scope may log it
+	assert.Equal(t, []int{fiber.StatusForbidden, fiber.StatusCreated}, statuses)
+}
+
+func TestPlayHandler_CreatePlay_Cooperative(t *testing.T) {
+	// Given: Two members, an organizer and a cooperative game
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	game := createPlayTestGame(db, "Pandemic")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	won := true
+
+	// When: Logging a cooperative win
+	play, status := logPlay(t, app, organizerToken, dtos.PlayRequest{
+		GameID:      game.ID,
+		Cooperative: true,
+		Won:         &won,
+		Players:     []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID}},
+	})
+
+	// Then: The whole table wins and the play defaults to now
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.True(t, *play.Won)
+	assert.True(t, play.Players[0].Winner)
+	assert.True(t, play.Players[1].Winner)
+	assert.True(t, play.PlayedAt.Equal(playTestNow))
+}
+
+func TestPlayHandler_CreatePlay_RejectsInvalidPlays(t *testing.T) {
+	// Given: A game, two members and an organizer
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	game := createPlayTestGame(db, "Azul")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	won := true
+	future := playTestNow.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		req  dtos.PlayRequest
+		want int
+	}{
+		{name: "no players", req: dtos.PlayRequest{GameID: game.ID}, want: fiber.StatusBadRequest},
+		{name: "no winner or scores", req: dtos.PlayRequest{GameID: game.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID}}}, want: fiber.StatusBadRequest},
+		{name: "player listed twice", req: dtos.PlayRequest{GameID: game.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: anna.ID}}}, want: fiber.StatusBadRequest},
+		{name: "cooperative without result", req: dtos.PlayRequest{GameID: game.ID, Cooperative: true, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}}}, want: fiber.StatusBadRequest},
+		{name: "won on competitive play", req: dtos.PlayRequest{GameID: game.ID, Won: &won, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}}}, want: fiber.StatusBadRequest},
+		{name: "in the future", req: dtos.PlayRequest{GameID: game.ID, PlayedAt: &future, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}}}, want: fiber.StatusBadRequest},
+		{name: "unknown player", req: dtos.PlayRequest{GameID: game.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID + 100}}}, want: fiber.StatusBadRequest},
+		{name: "unknown game", req: dtos.PlayRequest{GameID: game.ID + 100, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}}}, want: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Logging the play
+			_, status := logPlay(t, app, organizerToken, tt.req)
+
+			// Then: It should be rejected
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestPlayHandler_CreatePlay_MemberLogsOwnPlays(t *testing.T) {
+	// Given: Two members and an organizer
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	game := createPlayTestGame(db, "Onirim")
+	anna, annaToken := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	won := true
+
+	tests := []struct {
+		name         string
+		token        string
+		players      []dtos.PlayPlayerRequest
+		coop         bool
+		want         int
+		wantOfficial bool
+	}{
+		{name: "solo play", token: annaToken, players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Score: points(12)}}, want: fiber.StatusCreated},
+		{name: "against another member", token: annaToken, players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}, want: fiber.StatusCreated},
+		{name: "cooperative with another member", token: annaToken, players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID}}, coop: true, want: fiber.StatusCreated},
+		{name: "not a participant", token: annaToken, players: []dtos.PlayPlayerRequest{{UserID: ben.ID, Winner: true}}, want: fiber.StatusForbidden},
+		{name: "logged by an organizer", token: organizerToken, players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}, want: fiber.StatusCreated, wantOfficial: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: A play request
+			req := dtos.PlayRequest{GameID: game.ID, Cooperative: tt.coop, Players: tt.players}
+			if tt.coop {
+				req.Won = &won
+			}
+
+			// When: Logging it
+			play, status := logPlay(t, app, tt.token, req)
+
+			// Then: Members may log plays they took part in, and only an
+			// organizer's plays are official
+			assert.Equal(t, tt.want, status)
+			if status == fiber.StatusCreated {
+				assert.Equal(t, tt.wantOfficial, play.Official)
+			}
+		})
+	}
+}
+
+func TestPlayHandler_DeletePlay(t *testing.T) {
+	// Given: Solo plays logged by one member
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupPlayTestApp(db, store)
+	game := createPlayTestGame(db, "Carcassonne")
+	anna, annaToken := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	_, benToken := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	players := []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}}
+	own, _ := logPlay(t, app, annaToken, dtos.PlayRequest{GameID: game.ID, Players: players})
+	other, _ := logPlay(t, app, annaToken, dtos.PlayRequest{GameID: game.ID, Players: players})
+
+	// When: Another member tries to delete one
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/plays/%d", own.ID), benToken, nil))
+
+	// Then: It is refused
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// When: The logger deletes one play and an organizer the other
+	ownResp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/plays/%d", own.ID), annaToken, nil))
+	organizerResp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/plays/%d", other.ID), organizerToken, nil))
+
+	// Then: Both are gone and only the organizer's removal is audited
+	assert.Equal(t, fiber.StatusNoContent, ownResp.StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, organizerResp.StatusCode)
+	var plays []dtos.PlayResponse
+	getPlayJSON(t, app, fmt.Sprintf("/users/%d/plays", anna.ID), &plays)
+	assert.Empty(t, plays)
+	assert.Equal(t, []string{"play.deleted"}, store.Actions())
+}
+
+func TestPlayHandler_Statistics(t *testing.T) {
+	// Given: A month of plays between three members
+	db := setupTestDB(t)
+	app := setupPlayTestApp(db, audit.NewMemoryStore())
+	catan := createPlayTestGame(db, "Catan")
+	azul := createPlayTestGame(db, "Azul")
+	pandemic := createPlayTestGame(db, "Pandemic")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	cleo, _ := createRoleTestUser(db, "cleo@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	won, lost := true, false
+
+	plays := []dtos.PlayRequest{
+		{GameID: catan.ID, PlayedAt: playedOn(time.May, 2), DurationMinutes: 90, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID, Winner: true}, {UserID: cleo.ID}}},
+		{GameID: catan.ID, PlayedAt: playedOn(time.May, 9), DurationMinutes: 80, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID, Winner: true}}},
+		{GameID: catan.ID, PlayedAt: playedOn(time.May, 16), DurationMinutes: 85, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: cleo.ID}}},
+		{GameID: azul.ID, PlayedAt: playedOn(time.May, 10), DurationMinutes: 40, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: cleo.ID, Winner: true}}},
+		{GameID: pandemic.ID, PlayedAt: playedOn(time.May, 12), DurationMinutes: 45, Cooperative: true, Won: &lost, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID}}},
+		{GameID: pandemic.ID, PlayedAt: playedOn(time.May, 13), DurationMinutes: 50, Cooperative: true, Won: &won, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: ben.ID}}},
+		{GameID: azul.ID, PlayedAt: playedOn(time.April, 30), Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}}},
+	}
+	for _, play := range plays {
+		_, status := logPlay(t, app, organizerToken, play)
+		assert.Equal(t, fiber.StatusCreated, status)
+	}
+
+	// When: Asking for the most played games of May
+	var mostPlayed []dtos.MostPlayedGameResponse
+	status := getPlayJSON(t, app, "/plays/most-played?month=2024-05", &mostPlayed)
+
+	// Then: Catan leads and April's play is not counted
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, mostPlayed, 3)
+	assert.Equal(t, dtos.MostPlayedGameResponse{GameID: catan.ID, GameName: "Catan", Plays: 3, Minutes: 255}, mostPlayed[0])
+	assert.Equal(t, "Pandemic", mostPlayed[1].GameName)
+	assert.Equal(t, 1, mostPlayed[2].Plays)
+
+	// When: Asking for Anna's win rates
+	var rates []dtos.GameWinRateResponse
+	getPlayJSON(t, app, fmt.Sprintf("/users/%d/win-rates", anna.ID), &rates)
+
+	// Then: Every game she played is counted, cooperative wins included
+	assert.Equal(t, []dtos.GameWinRateResponse{
+		{GameID: catan.ID, GameName: "Catan", Plays: 3, Wins: 1, WinRate: 0.333},
+		{GameID: azul.ID, GameName: "Azul", Plays: 2, Wins: 1, WinRate: 0.5},
+		{GameID: pandemic.ID, GameName: "Pandemic", Plays: 2, Wins: 1, WinRate: 0.5},
+	}, rates)
+
+	// When: Asking for Anna's nemesis
+	var nemesis dtos.NemesisResponse
+	getPlayJSON(t, app, fmt.Sprintf("/users/%d/nemesis", anna.ID), &nemesis)
+
+	// Then: Ben beat her twice, ignoring the cooperative losses they shared
+	assert.Equal(t, anna.ID, nemesis.UserID)
+	assert.Equal(t, ben.ID, nemesis.Nemesis.UserID)
+	assert.Equal(t, 2, nemesis.Nemesis.Losses)
+	assert.Equal(t, 2, nemesis.Nemesis.Plays)
+
+	// And: Ben has no nemesis, having never lost a competitive play
+	var unbeaten dtos.NemesisResponse
+	getPlayJSON(t, app, fmt.Sprintf("/users/%d/nemesis", ben.ID), &unbeaten)
+	assert.Nil(t, unbeaten.Nemesis)
+}
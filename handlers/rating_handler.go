@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+)
+
+// RatingHandler serves the player ratings the rating engine derives from
+// logged plays.
+type RatingHandler struct {
+	ratingRepo repositories.PlayerRatingRepository
+	playRepo   repositories.PlayRepository
+	gameRepo   repositories.GameRepository
+	userRepo   repositories.UserRepository
+	engine     *rating.Engine
+	recorder   *audit.Recorder
+}
+
+func NewRatingHandler(db *gorm.DB) *RatingHandler {
+	return NewRatingHandlerWithRepo(
+		repositories.NewPlayerRatingRepository(db),
+		repositories.NewPlayRepository(db),
+		repositories.NewGameRepository(db),
+		repositories.NewUserRepository(db),
+	)
+}
+
+func NewRatingHandlerWithRepo(ratingRepo repositories.PlayerRatingRepository, playRepo repositories.PlayRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository) *RatingHandler {
+	return &RatingHandler{
+		ratingRepo: ratingRepo,
+		playRepo:   playRepo,
+		gameRepo:   gameRepo,
+		userRepo:   userRepo,
+		engine:     rating.DefaultEngine,
+		recorder:   audit.DefaultRecorder,
+	}
+}
+
+func (h *RatingHandler) GetGameLeaderboard(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", defaultLeaderboardLimit)
+	if limit < 1 || limit > maxLeaderboardLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(fmt.Sprintf("limit must be between 1 and %d", maxLeaderboardLimit)))
+	}
+
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	algorithm := h.engine.GetCurrentAlgorithm().GetAlgorithmName()
+	ratings, err := h.ratingRepo.FindLeaderboard(c.Context(), game.ID, algorithm, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch leaderboard"))
+	}
+
+	return c.JSON(mappers.ToLeaderboardResponse(game, algorithm, ratings))
+}
+
+// GetUserRatings lists a player's global rating and their rating at every
+// game they have a rated play of.
+func (h *RatingHandler) GetUserRatings(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	user, err := h.userRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	ratings, err := h.ratingRepo.FindByUserID(c.Context(), user.ID, h.engine.GetCurrentAlgorithm().GetAlgorithmName())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch ratings"))
+	}
+	for i := range ratings {
+		ratings[i].User = *user
+	}
+
+	return c.JSON(mappers.ToPlayerRatingResponseList(ratings))
+}
+
+// RecalculateRatings replays every logged play with the current algorithm
+// and replaces all stored ratings. It is run after switching algorithms.
+func (h *RatingHandler) RecalculateRatings(c *fiber.Ctx) error {
+	ratingMu.Lock()
+	defer ratingMu.Unlock()
+
+	plays, err := h.playRepo.FindHistory(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch plays"))
+	}
+
+	ratings := h.engine.Replay(plays)
+	if err := h.ratingRepo.ReplaceAll(c.Context(), ratings); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to store ratings"))
+	}
+
+	rated := 0
+	for i := range plays {
+		if rating.Rated(&plays[i]) {
+			rated++
+		}
+	}
+	algorithm := h.engine.GetCurrentAlgorithm().GetAlgorithmName()
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionRatingsRecalculated,
+		ResourceType: audit.ResourcePlayerRating,
+		Metadata:     map[string]interface{}{"algorithm": algorithm, "plays": rated, "ratings": len(ratings)},
+	})
+
+	return c.JSON(dtos.RatingRecalculationResponse{Algorithm: algorithm, Plays: rated, Ratings: len(ratings)})
+}
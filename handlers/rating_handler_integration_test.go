@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/elo"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/glicko2"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupRatingTestApp(db *gorm.DB, store *audit.MemoryStore, algorithm rating.RatingAlgorithm) *fiber.App {
+	app := setupPlayTestApp(db, store)
+	ratingHandler := NewRatingHandler(db)
+	ratingHandler.engine = rating.NewEngine(algorithm)
+	ratingHandler.recorder = audit.NewRecorder(store)
+
+	app.Get("/games/:id/leaderboard", ratingHandler.GetGameLeaderboard)
+	app.Get("/users/:id/ratings", ratingHandler.GetUserRatings)
+	app.Post("/admin/ratings/recalculate", middleware.JWTMiddleware(db), middleware.RequireRole(models.RoleAdmin), ratingHandler.RecalculateRatings)
+	return app
+}
+
+func TestRatingHandler_PlaysUpdateRatings(t *testing.T) {
+	// Given: Three members playing two games
+	db := setupTestDB(t)
+	app := setupRatingTestApp(db, audit.NewMemoryStore(), elo.New())
+	catan := createPlayTestGame(db, "Catan")
+	azul := createPlayTestGame(db, "Azul")
+	anna, annaToken := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	cleo, _ := createRoleTestUser(db, "cleo@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	won := true
+
+	// When: An organizer logs plays, including a cooperative one that is not
+	// rated, and Anna logs one herself, which is not official
+	for _, play := range []dtos.PlayRequest{
+		{GameID: catan.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}},
+		{GameID: catan.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Score: points(60)}, {UserID: ben.ID, Score: points(45)}, {UserID: cleo.ID, Score: points(50)}}},
+		{GameID: azul.ID, Players: []dtos.PlayPlayerRequest{{UserID: ben.ID, Winner: true}, {UserID: anna.ID}}},
+		{GameID: azul.ID, Cooperative: true, Won: &won, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID}, {UserID: cleo.ID}}},
+	} {
+		_, status := logPlay(t, app, organizerToken, play)
+		assert.Equal(t, fiber.StatusCreated, status)
+	}
+	_, status := logPlay(t, app, annaToken, dtos.PlayRequest{GameID: catan.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: cleo.ID}}})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	// Then: The Catan leaderboard ranks Anna, Cleo and Ben
+	var leaderboard dtos.LeaderboardResponse
+	status = getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", catan.ID), &leaderboard)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Catan", leaderboard.GameName)
+	assert.Equal(t, elo.Name, leaderboard.Algorithm)
+	assert.Len(t, leaderboard.Entries, 3)
+	assert.Equal(t, anna.ID, leaderboard.Entries[0].UserID)
+	assert.Equal(t, 1, leaderboard.Entries[0].Rank)
+	assert.Equal(t, 2, leaderboard.Entries[0].Plays)
+	assert.Equal(t, cleo.ID, leaderboard.Entries[1].UserID)
+	assert.Equal(t, ben.ID, leaderboard.Entries[2].UserID)
+
+	// And: Anna's ratings list her global rating first, then each game
+	var ratings []dtos.PlayerRatingResponse
+	getPlayJSON(t, app, fmt.Sprintf("/users/%d/ratings", anna.ID), &ratings)
+	assert.Len(t, ratings, 3)
+	assert.True(t, ratings[0].Global)
+	assert.Equal(t, 3, ratings[0].Plays)
+	assert.Equal(t, "Catan", ratings[1].GameName)
+	assert.Greater(t, ratings[1].Rating, 1500.0)
+	assert.Equal(t, "Azul", ratings[2].GameName)
+	assert.Equal(t, 1484.0, ratings[2].Rating)
+}
+
+func TestRatingHandler_RecalculateRatings(t *testing.T) {
+	// Given: Plays rated with Elo and an admin who switched to Glicko-2
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	eloApp := setupRatingTestApp(db, audit.NewMemoryStore(), elo.New())
+	app := setupRatingTestApp(db, store, glicko2.New())
+	game := createPlayTestGame(db, "Brass")
+	anna, annaToken := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	for i := 0; i < 2; i++ {
+		logPlay(t, eloApp, adminToken, dtos.PlayRequest{GameID: game.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}})
+	}
+
+	var before dtos.LeaderboardResponse
+	getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", game.ID), &before)
+	assert.Empty(t, before.Entries)
+
+	// When: A member and then the admin ask for a recalculation
+	memberResp, _ := app.Test(authorizedRequest("POST", "/admin/ratings/recalculate", annaToken, nil))
+	resp, err := app.Test(authorizedRequest("POST", "/admin/ratings/recalculate", adminToken, nil))
+
+	// Then: Only the admin may, and every rating is rebuilt with Glicko-2
+	assert.Equal(t, fiber.StatusForbidden, memberResp.StatusCode)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var result dtos.RatingRecalculationResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, dtos.RatingRecalculationResponse{Algorithm: glicko2.Name, Plays: 2, Ratings: 4}, result)
+	assert.Equal(t, []string{audit.ActionRatingsRecalculated}, store.Actions())
+
+	var leaderboard dtos.LeaderboardResponse
+	getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", game.ID), &leaderboard)
+	assert.Len(t, leaderboard.Entries, 2)
+	assert.Equal(t, glicko2.Name, leaderboard.Algorithm)
+	assert.Equal(t, anna.ID, leaderboard.Entries[0].UserID)
+	assert.Equal(t, 2, leaderboard.Entries[0].Plays)
+	assert.Less(t, leaderboard.Entries[0].Deviation, glicko2.InitialDeviation)
+}
+
+func TestRatingHandler_DeletePlay_Rerates(t *testing.T) {
+	// Given: Two rated plays that cancel each other out
+	db := setupTestDB(t)
+	app := setupRatingTestApp(db, audit.NewMemoryStore(), elo.New())
+	game := createPlayTestGame(db, "Jaipur")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	logPlay(t, app, organizerToken, dtos.PlayRequest{GameID: game.ID, PlayedAt: playedOn(time.May, 1), Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}})
+	rematch, _ := logPlay(t, app, organizerToken, dtos.PlayRequest{GameID: game.ID, PlayedAt: playedOn(time.May, 2), Players: []dtos.PlayPlayerRequest{{UserID: ben.ID, Winner: true}, {UserID: anna.ID}}})
+
+	// When: The rematch is deleted
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/plays/%d", rematch.ID), organizerToken, nil))
+
+	// Then: The ratings are as if only Anna's win had been logged
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	var leaderboard dtos.LeaderboardResponse
+	getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", game.ID), &leaderboard)
+	assert.Len(t, leaderboard.Entries, 2)
+	assert.Equal(t, anna.ID, leaderboard.Entries[0].UserID)
+	assert.Equal(t, 1, leaderboard.Entries[0].Plays)
+	assert.Equal(t, 1516.0, leaderboard.Entries[0].Rating)
+	assert.Equal(t, 1484.0, leaderboard.Entries[1].Rating)
+}
+
+func TestRatingHandler_BackdatedPlayThenDelete_RatesInPlayOrder(t *testing.T) {
+	// Given: Anna's win on May 2, then Ben's win on May 1 logged late
+	db := setupTestDB(t)
+	app := setupRatingTestApp(db, audit.NewMemoryStore(), elo.New())
+	game := createPlayTestGame(db, "Patchwork")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	cleo, _ := createRoleTestUser(db, "cleo@example.com", models.RoleMember)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	path := fmt.Sprintf("/games/%d/leaderboard", game.ID)
+	logPlay(t, app, adminToken, dtos.PlayRequest{GameID: game.ID, PlayedAt: playedOn(time.May, 2), Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}})
+	logPlay(t, app, adminToken, dtos.PlayRequest{GameID: game.ID, PlayedAt: playedOn(time.May, 1), Players: []dtos.PlayPlayerRequest{{UserID: ben.ID, Winner: true}, {UserID: anna.ID}}})
+
+	var backdated dtos.LeaderboardResponse
+	getPlayJSON(t, app, path, &backdated)
+	assert.Len(t, backdated.Entries, 2)
+	assert.Equal(t, anna.ID, backdated.Entries[0].UserID)
+	assert.Equal(t, 1501.5, backdated.Entries[0].Rating)
+	assert.Equal(t, 1498.5, backdated.Entries[1].Rating)
+
+	// When: A later play is logged and then deleted
+	later, status := logPlay(t, app, adminToken, dtos.PlayRequest{GameID: game.ID, PlayedAt: playedOn(time.May, 3), Players: []dtos.PlayPlayerRequest{{UserID: cleo.ID, Winner: true}, {UserID: anna.ID}}})
+	assert.Equal(t, fiber.StatusCreated, status)
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/plays/%d", later.ID), adminToken, nil))
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	// Then: The ratings are back to those of the first two plays in the
+	// order they took place, as a recalculation gives them
+	var afterDelete dtos.LeaderboardResponse
+	getPlayJSON(t, app, path, &afterDelete)
+	assert.Equal(t, backdated.Entries, afterDelete.Entries)
+
+	resp, _ = app.Test(authorizedRequest("POST", "/admin/ratings/recalculate", adminToken, nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var recalculated dtos.LeaderboardResponse
+	getPlayJSON(t, app, path, &recalculated)
+	assert.Equal(t, afterDelete.Entries, recalculated.Entries)
+}
+
+func TestRatingHandler_Leaderboard_LeavesOutDeletedUsers(t *testing.T) {
+	// Given: A rated play between two members
+	db := setupTestDB(t)
+	db.AutoMigrate(&models.FriendRequest{})
+	app := setupRatingTestApp(db, audit.NewMemoryStore(), elo.New())
+	game := createPlayTestGame(db, "Hive")
+	anna, _ := createRoleTestUser(db, "anna@example.com", models.RoleMember)
+	ben, _ := createRoleTestUser(db, "ben@example.com", models.RoleMember)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	logPlay(t, app, adminToken, dtos.PlayRequest{GameID: game.ID, Players: []dtos.PlayPlayerRequest{{UserID: anna.ID, Winner: true}, {UserID: ben.ID}}})
+
+	// When: Ben deletes his account
+	assert.NoError(t, repositories.NewUserRepository(db).Delete(context.Background(), ben.ID))
+
+	// Then: His ratings are gone and he is not ranked
+	var remaining int64
+	db.Model(&models.PlayerRating{}).Where("user_id = ?", ben.ID).Count(&remaining)
+	assert.Zero(t, remaining)
+	var leaderboard dtos.LeaderboardResponse
+	getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", game.ID), &leaderboard)
+	assert.Len(t, leaderboard.Entries, 1)
+	assert.Equal(t, anna.ID, leaderboard.Entries[0].UserID)
+
+	// When: Ratings are recalculated from the plays he took part in
+	app.Test(authorizedRequest("POST", "/admin/ratings/recalculate", adminToken, nil))
+
+	// Then: He still stays off the leaderboard
+	getPlayJSON(t, app, fmt.Sprintf("/games/%d/leaderboard", game.ID), &leaderboard)
+	assert.Len(t, leaderboard.Entries, 1)
+}
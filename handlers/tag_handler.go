@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type TagHandler struct {
+	tagRepo  repositories.TagRepository
+	gameRepo repositories.GameRepository
+	recorder *audit.Recorder
+}
+
+func NewTagHandler(db *gorm.DB) *TagHandler {
+	return NewTagHandlerWithRepo(repositories.NewTagRepository(db), repositories.NewGameRepository(db))
+}
+
+func NewTagHandlerWithRepo(tagRepo repositories.TagRepository, gameRepo repositories.GameRepository) *TagHandler {
+	return &TagHandler{tagRepo: tagRepo, gameRepo: gameRepo, recorder: audit.DefaultRecorder}
+}
+
+func (h *TagHandler) GetAllTags(c *fiber.Ctx) error {
+	tags, err := h.tagRepo.FindAll(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to fetch tags"))
+	}
+
+	return c.JSON(mappers.ToTagResponseList(tags))
+}
+
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	var req dtos.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	name, err := validateTagName(req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	if existing, _ := h.tagRepo.FindByName(c.Context(), name); existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Tag already exists"))
+	}
+
+	tag := models.Tag{Name: name}
+	if err := h.tagRepo.Create(c.Context(), &tag); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create tag"))
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceTag, audit.ActionCreated, tag.ID, nil, &tag)
+
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToTagResponse(&tag))
+}
+
+// UpdateTag renames a tag on every game that carries it.
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	tag, err := h.findTag(c)
+	if tag == nil {
+		return err
+	}
+
+	var req dtos.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+	name, err := validateTagName(req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+	}
+
+	if existing, _ := h.tagRepo.FindByName(c.Context(), name); existing != nil && existing.ID != tag.ID {
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Tag already exists"))
+	}
+
+	before := audit.Snapshot(tag)
+	tag.Name = name
+	if err := h.tagRepo.Update(c.Context(), tag); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update tag"))
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceTag, audit.ActionUpdated, tag.ID, before, tag)
+
+	return c.JSON(mappers.ToTagResponse(tag))
+}
+
+// DeleteTag removes a tag from every game and deletes it.
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	tag, err := h.findTag(c)
+	if tag == nil {
+		return err
+	}
+
+	if err := h.tagRepo.Delete(c.Context(), tag.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete tag"))
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceTag, audit.ActionDeleted, tag.ID, tag, nil)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SetGameTags replaces a game's tags with the listed ones. An empty list
+// clears them.
+func (h *TagHandler) SetGameTags(c *fiber.Ctx) error {
+	game, err := h.gameRepo.FindByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	var req dtos.SetGameTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	ids := make([]uint, 0, len(req.TagIDs))
+	seen := make(map[uint]bool, len(req.TagIDs))
+	for _, id := range req.TagIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	tags, err := h.tagRepo.FindByIDs(c.Context(), ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update game tags"))
+	}
+	if len(tags) != len(ids) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Unknown tag"))
+	}
+
+	if err := h.gameRepo.SetTags(c.Context(), game.ID, tags); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update game tags"))
+	}
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionGameTagsChanged,
+		ResourceType: audit.ResourceGame,
+		ResourceID:   audit.ID(game.ID),
+		Metadata: map[string]interface{}{
+			"before": tagNames(game.Tags),
+			"after":  tagNames(tags),
+		},
+	})
+
+	updated, err := h.gameRepo.FindByID(c.Context(), strconv.FormatUint(uint64(game.ID), 10))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to retrieve game"))
+	}
+	return c.JSON(mappers.ToGameResponse(updated))
+}
+
+func (h *TagHandler) findTag(c *fiber.Ctx) (*models.Tag, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid tag ID"))
+	}
+
+	tag, err := h.tagRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	return tag, nil
+}
+
+func validateTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > models.MaxTagNameLength {
+		return "", fmt.Errorf("name must be at most %d characters", models.MaxTagNameLength)
+	}
+	return name, nil
+}
+
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupTagTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	tagHandler := NewTagHandler(db)
+	tagHandler.recorder = audit.NewRecorder(store)
+	gameHandler := NewGameHandler(db)
+	gameHandler.recorder = audit.NewRecorder(store)
+
+	authRequired := middleware.JWTMiddleware(db)
+	keyOrTokenRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeGamesWrite)
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	app.Get("/tags", tagHandler.GetAllTags)
+	app.Post("/tags", keyOrTokenRequired, canWrite, adminOnly, tagHandler.CreateTag)
+	app.Put("/tags/:id", keyOrTokenRequired, canWrite, adminOnly, tagHandler.UpdateTag)
+	app.Delete("/tags/:id", keyOrTokenRequired, canWrite, adminOnly, tagHandler.DeleteTag)
+	app.Get("/games", gameHandler.GetAllGames)
+	app.Post("/games", authRequired, organizerOnly, gameHandler.CreateGame)
+	app.Get("/games/:id", gameHandler.GetGameByID)
+	app.Put("/games/:id", authRequired, organizerOnly, gameHandler.UpdateGame)
+	app.Delete("/games/:id", authRequired, organizerOnly, gameHandler.DeleteGame)
+	app.Put("/games/:id/tags", authRequired, organizerOnly, tagHandler.SetGameTags)
+	return app
+}
+
+func postTag(t *testing.T, app *fiber.App, token, name string) (dtos.TagResponse, int) {
+	body, _ := json.Marshal(dtos.TagRequest{Name: name})
+	resp, err := app.Test(authorizedRequest("POST", "/tags", token, body))
+	assert.NoError(t, err)
+
+	var tag dtos.TagResponse
+	json.NewDecoder(resp.Body).Decode(&tag)
+	return tag, resp.StatusCode
+}
+
+func setGameTags(t *testing.T, app *fiber.App, token string, gameID uint, tagIDs ...uint) (dtos.GameResponse, int) {
+	body, _ := json.Marshal(dtos.SetGameTagsRequest{TagIDs: tagIDs})
+	resp, err := app.Test(authorizedRequest("PUT", fmt.Sprintf("/games/%d/tags", gameID), token, body))
+	assert.NoError(t, err)
+
+	var game dtos.GameResponse
+	json.NewDecoder(resp.Body).Decode(&game)
+	return game, resp.StatusCode
+}
+
+func listGameNames(t *testing.T, app *fiber.App, query string) []string {
+	resp, err := app.Test(httptest.NewRequest("GET", "/games"+query, nil))
+	assert.NoError(t, err)
+
+	var page dtos.GamePageResponse
+	json.NewDecoder(resp.Body).Decode(&page)
+	names := make([]string, len(page.Items))
+	for i, game := range page.Items {
+		names[i] = game.Name
+	}
+	return names
+}
+
+func TestTagHandler_CreateTag_WithAPIKey(t *testing.T) {
+	// Given: An admin with a games key and a news key
+	db := setupTestDB(t)
+	app := setupTagTestApp(db, audit.NewMemoryStore())
+	admin, _ := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	keys := security.NewAPIKeyManager(repositories.NewAPIKeyRepository(db))
+	gamesKey, _, _ := keys.Create(context.Background(), admin.ID, "Catalog sync", []string{models.ScopeGamesWrite}, 0)
+	newsKey, _, _ := keys.Create(context.Background(), admin.ID, "News bot", []string{models.ScopeNewsWrite}, 0)
+
+	// When: Creating a tag with each key
+	statuses := make([]int, 0, 2)
+	for i, key := range []string{newsKey, gamesKey} {
+		body, _ := json.Marshal(dtos.TagRequest{Name: fmt.Sprintf("Tag %d", i)})
+		req := httptest.NewRequest("POST", "/tags", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "ApiKey "+key)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	// Then: Only the key with the games scope may manage tags
+	assert.Equal(t, []int{fiber.StatusForbidden, fiber.StatusCreated}, statuses)
+}
+
+func TestTagHandler_CreateTag(t *testing.T) {
+	// Given: An admin and an organizer
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupTagTestApp(db, store)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+
+	// When: Both try to create tags
+	tag, status := postTag(t, app, adminToken, "  Co-op ")
+	_, organizerStatus := postTag(t, app, organizerToken, "Card")
+	_, duplicateStatus := postTag(t, app, adminToken, "CO-OP")
+	_, blankStatus := postTag(t, app, adminToken, " ")
+
+	// Then: Only admins manage tags and names are unique regardless of case
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, "Co-op", tag.Name)
+	assert.Equal(t, fiber.StatusForbidden, organizerStatus)
+	assert.Equal(t, fiber.StatusConflict, duplicateStatus)
+	assert.Equal(t, fiber.StatusBadRequest, blankStatus)
+	assert.Equal(t, []string{"tag.created"}, store.Actions())
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/tags", nil))
+	var tags []dtos.TagResponse
+	json.NewDecoder(resp.Body).Decode(&tags)
+	assert.Len(t, tags, 1)
+}
+
+func TestTagHandler_SetGameTags_FiltersGameList(t *testing.T) {
+	// Given: Three games and two tags
+	db := setupTestDB(t)
+	app := setupTagTestApp(db, audit.NewMemoryStore())
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	coop, _ := postTag(t, app, adminToken, "Cooperative")
+	card, _ := postTag(t, app, adminToken, "Card")
+	hanabi := models.NewGameBuilder().SetName("Hanabi").Build()
+	pandemic := models.NewGameBuilder().SetName("Pandemic").Build()
+	uno := models.NewGameBuilder().SetName("Uno").Build()
+	db.Create(hanabi)
+	db.Create(pandemic)
+	db.Create(uno)
+
+	// When: Tagging them
+	tagged, status := setGameTags(t, app, organizerToken, hanabi.ID, card.ID, coop.ID, card.ID)
+	setGameTags(t, app, organizerToken, pandemic.ID, coop.ID)
+	setGameTags(t, app, organizerToken, uno.ID, card.ID)
+	_, unknownStatus := setGameTags(t, app, organizerToken, uno.ID, card.ID+100)
+
+	// Then: A game carries several tags and the list filters on all given tags
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []dtos.TagResponse{card, coop}, tagged.Tags)
+	assert.Equal(t, fiber.StatusBadRequest, unknownStatus)
+	assert.Equal(t, []string{"Hanabi", "Pandemic"}, listGameNames(t, app, "?tags=cooperative"))
+	assert.Equal(t, []string{"Hanabi"}, listGameNames(t, app, "?tags=Cooperative,%20card"))
+	assert.Empty(t, listGameNames(t, app, "?tags=unknown"))
+
+	// When: Clearing a game's tags
+	cleared, _ := setGameTags(t, app, organizerToken, uno.ID)
+
+	// Then: It no longer matches
+	assert.Empty(t, cleared.Tags)
+	assert.Equal(t, []string{"Hanabi"}, listGameNames(t, app, "?tags=card"))
+}
+
+func TestTagHandler_UpdateAndDeleteTag(t *testing.T) {
+	// Given: A tagged game
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupTagTestApp(db, store)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	tag, _ := postTag(t, app, adminToken, "Coop")
+	postTag(t, app, adminToken, "Dice")
+	game := models.NewGameBuilder().SetName("Spirit Island").Build()
+	db.Create(game)
+	setGameTags(t, app, adminToken, game.ID, tag.ID)
+	path := fmt.Sprintf("/tags/%d", tag.ID)
+
+	// When: Renaming the tag, once onto an existing name
+	body, _ := json.Marshal(dtos.TagRequest{Name: "dice"})
+	conflictResp, _ := app.Test(authorizedRequest("PUT", path, adminToken, body))
+	body, _ = json.Marshal(dtos.TagRequest{Name: "Cooperative"})
+	renameResp, _ := app.Test(authorizedRequest("PUT", path, adminToken, body))
+
+	// Then: The game shows the new name
+	assert.Equal(t, fiber.StatusConflict, conflictResp.StatusCode)
+	assert.Equal(t, fiber.StatusOK, renameResp.StatusCode)
+	assert.Equal(t, "Cooperative", getRatedGame(t, app, game.ID).Tags[0].Name)
+
+	// When: Deleting the tag
+	deleteResp, _ := app.Test(authorizedRequest("DELETE", path, adminToken, nil))
+
+	// Then: It is gone from the game too
+	assert.Equal(t, fiber.StatusNoContent, deleteResp.StatusCode)
+	assert.Empty(t, getRatedGame(t, app, game.ID).Tags)
+	var links int64
+	db.Table("game_tags").Count(&links)
+	assert.Zero(t, links)
+	assert.Equal(t, []string{"tag.created", "tag.created", "game.tags_changed", "tag.updated", "tag.deleted"}, store.Actions())
+}
+
+func postGame(t *testing.T, app *fiber.App, token string, req dtos.CreateGameRequest) (dtos.GameResponse, int) {
+	body, _ := json.Marshal(req)
+	resp, err := app.Test(authorizedRequest("POST", "/games", token, body))
+	assert.NoError(t, err)
+
+	var game dtos.GameResponse
+	json.NewDecoder(resp.Body).Decode(&game)
+	return game, resp.StatusCode
+}
+
+func TestGameHandler_Expansions(t *testing.T) {
+	// Given: A base game
+	db := setupTestDB(t)
+	app := setupTagTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	base, _ := postGame(t, app, token, dtos.CreateGameRequest{Name: "Catan", NumberOfPlayers: 4})
+
+	// When: Adding two expansions and an expansion of an expansion
+	seafarers, status := postGame(t, app, token, dtos.CreateGameRequest{Name: "Seafarers", NumberOfPlayers: 4, BaseGameID: &base.ID})
+	postGame(t, app, token, dtos.CreateGameRequest{Name: "Cities & Knights", NumberOfPlayers: 4, BaseGameID: &base.ID})
+	_, nestedStatus := postGame(t, app, token, dtos.CreateGameRequest{Name: "Seafarers Scenarios", NumberOfPlayers: 4, BaseGameID: &seafarers.ID})
+	missing := base.ID + 100
+	_, missingStatus := postGame(t, app, token, dtos.CreateGameRequest{Name: "Orphan", NumberOfPlayers: 4, BaseGameID: &missing})
+
+	// Then: The base game lists its expansions and nesting is refused
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, base.ID, *seafarers.BaseGameID)
+	assert.Equal(t, fiber.StatusBadRequest, nestedStatus)
+	assert.Equal(t, fiber.StatusBadRequest, missingStatus)
+	catan := getRatedGame(t, app, base.ID)
+	assert.Nil(t, catan.BaseGameID)
+	assert.Equal(t, []dtos.GameSummaryResponse{
+		{ID: seafarers.ID + 1, Name: "Cities & Knights"},
+		{ID: seafarers.ID, Name: "Seafarers"},
+	}, catan.Expansions)
+
+	// When: Deleting the base game while it has expansions
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/games/%d", base.ID), token, nil))
+
+	// Then: It is refused
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// When: The expansions are detached or deleted
+	detach := uint(0)
+	body, _ := json.Marshal(dtos.CreateGameRequest{Name: "Seafarers", NumberOfPlayers: 4, BaseGameID: &detach})
+	detachResp, _ := app.Test(authorizedRequest("PUT", fmt.Sprintf("/games/%d", seafarers.ID), token, body))
+	app.Test(authorizedRequest("DELETE", fmt.Sprintf("/games/%d", seafarers.ID+1), token, nil))
+	resp, _ = app.Test(authorizedRequest("DELETE", fmt.Sprintf("/games/%d", base.ID), token, nil))
+
+	// Then: The base game can be deleted
+	assert.Equal(t, fiber.StatusOK, detachResp.StatusCode)
+	assert.Nil(t, getRatedGame(t, app, seafarers.ID).BaseGameID)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestGameHandler_UpdateGame_KeepsBaseGameWhenOmitted(t *testing.T) {
+	// Given: A base game and one expansion
+	db := setupTestDB(t)
+	app := setupTagTestApp(db, audit.NewMemoryStore())
+	_, token := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	base, _ := postGame(t, app, token, dtos.CreateGameRequest{Name: "Dominion", NumberOfPlayers: 4})
+	expansion, _ := postGame(t, app, token, dtos.CreateGameRequest{Name: "Intrigue", NumberOfPlayers: 4, BaseGameID: &base.ID})
+
+	// When: Editing the expansion without baseGameId, and making the base an expansion
+	body, _ := json.Marshal(dtos.CreateGameRequest{Name: "Dominion: Intrigue", NumberOfPlayers: 4})
+	resp, _ := app.Test(authorizedRequest("PUT", fmt.Sprintf("/games/%d", expansion.ID), token, body))
+	body, _ = json.Marshal(dtos.CreateGameRequest{Name: "Dominion", NumberOfPlayers: 4, BaseGameID: &expansion.ID})
+	baseResp, _ := app.Test(authorizedRequest("PUT", fmt.Sprintf("/games/%d", base.ID), token, body))
+
+	// Then: The expansion keeps its base game and the base cannot become an expansion
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, base.ID, *getRatedGame(t, app, expansion.ID).BaseGameID)
+	assert.Equal(t, fiber.StatusBadRequest, baseResp.StatusCode)
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"strconv"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
 	"github.com/gofiber/fiber/v2"
@@ -14,17 +16,19 @@ import (
 type TeamHandler struct {
 	teamRepo repositories.TeamRepository
 	userRepo repositories.UserRepository
+	recorder *audit.Recorder
 }
 
 func NewTeamHandler(db *gorm.DB) *TeamHandler {
 	return &TeamHandler{
 		teamRepo: repositories.NewTeamRepository(db),
 		userRepo: repositories.NewUserRepository(db),
+		recorder: audit.DefaultRecorder,
 	}
 }
 
 func NewTeamHandlerWithRepo(teamRepo repositories.TeamRepository, userRepo repositories.UserRepository) *TeamHandler {
-	return &TeamHandler{teamRepo: teamRepo, userRepo: userRepo}
+	return &TeamHandler{teamRepo: teamRepo, userRepo: userRepo, recorder: audit.DefaultRecorder}
 }
 
 func (h *TeamHandler) GetAllTeams(c *fiber.Ctx) error {
@@ -60,6 +64,8 @@ func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create team"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTeam, audit.ActionCreated, team.ID, nil, &team)
+
 	return c.Status(fiber.StatusCreated).JSON(mappers.ToTeamResponse(&team))
 }
 
@@ -76,12 +82,15 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
 	}
 
+	before := audit.Snapshot(team)
 	team.Name = req.Name
 
 	if err := h.teamRepo.Update(c.Context(), team); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update team"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTeam, audit.ActionUpdated, team.ID, before, team)
+
 	return c.JSON(mappers.ToTeamResponse(team))
 }
 
@@ -97,6 +106,8 @@ func (h *TeamHandler) DeleteTeam(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete team"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTeam, audit.ActionDeleted, team.ID, team, nil)
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
@@ -120,6 +131,15 @@ func (h *TeamHandler) JoinTeam(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
 	}
 
+	// Members join teams themselves; only organizers may add someone else.
+	actor, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+	if actor != uint(userID) && !actorRole(c).HasAnyOf(models.RoleOrganizer) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	team, err := h.teamRepo.FindByID(c.Context(), teamID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
@@ -431,7 +431,7 @@ func TestTeamHandler_JoinTeam_Success_Unit(t *testing.T) {
 	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/teams/:id/members/:userId", handler.JoinTeam)
+	app.Post("/teams/:id/members/:userId", withActor(2, models.RoleMember), handler.JoinTeam)
 
 	team := &models.Team{Model: gorm.Model{ID: 1}, Name: "Test Team"}
 	user := &models.User{Model: gorm.Model{ID: 2}, FirstName: "John", LastName: "Doe", Email: "john@example.com"}
@@ -459,7 +459,7 @@ func TestTeamHandler_JoinTeam_TeamNotFound_Unit(t *testing.T) {
 	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/teams/:id/members/:userId", handler.JoinTeam)
+	app.Post("/teams/:id/members/:userId", withActor(1, models.RoleMember), handler.JoinTeam)
 
 	mockTeamRepo.On("FindByID", mock.Anything, "999").Return(nil, gorm.ErrRecordNotFound)
 
@@ -481,7 +481,7 @@ func TestTeamHandler_JoinTeam_UserNotFound_Unit(t *testing.T) {
 	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/teams/:id/members/:userId", handler.JoinTeam)
+	app.Post("/teams/:id/members/:userId", withActor(999, models.RoleMember), handler.JoinTeam)
 
 	team := &models.Team{Model: gorm.Model{ID: 1}, Name: "Test Team"}
 	mockTeamRepo.On("FindByID", mock.Anything, "1").Return(team, nil)
@@ -499,6 +499,52 @@ func TestTeamHandler_JoinTeam_UserNotFound_Unit(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestTeamHandler_JoinTeam_OtherUser_Unit(t *testing.T) {
+	// Given: A member trying to add someone else to a team
+	mockTeamRepo := new(mocks.MockTeamRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
+
+	app := fiber.New()
+	app.Post("/teams/:id/members/:userId", withActor(3, models.RoleMember), handler.JoinTeam)
+
+	req := httptest.NewRequest("POST", "/teams/1/members/2", nil)
+
+	// When: Making the join team request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden and no member added
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockTeamRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamHandler_JoinTeam_OrganizerAddsOtherUser_Unit(t *testing.T) {
+	// Given: An organizer adding a member to a team
+	mockTeamRepo := new(mocks.MockTeamRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
+
+	app := fiber.New()
+	app.Post("/teams/:id/members/:userId", withActor(3, models.RoleOrganizer), handler.JoinTeam)
+
+	team := &models.Team{Model: gorm.Model{ID: 1}, Name: "Test Team"}
+	user := &models.User{Model: gorm.Model{ID: 2}, FirstName: "John"}
+	mockTeamRepo.On("FindByID", mock.Anything, "1").Return(team, nil)
+	mockUserRepo.On("FindByID", mock.Anything, uint(2)).Return(user, nil)
+	mockTeamRepo.On("AddMember", mock.Anything, team, user).Return(nil)
+
+	req := httptest.NewRequest("POST", "/teams/1/members/2", nil)
+
+	// When: Making the join team request
+	resp, err := app.Test(req)
+
+	// Then: The member should be added
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	mockTeamRepo.AssertExpectations(t)
+}
+
 func TestTeamHandler_JoinTeam_InvalidUserID_Unit(t *testing.T) {
 	// Given: An invalid user ID is provided
 	mockTeamRepo := new(mocks.MockTeamRepository)
@@ -506,7 +552,7 @@ func TestTeamHandler_JoinTeam_InvalidUserID_Unit(t *testing.T) {
 	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/teams/:id/members/:userId", handler.JoinTeam)
+	app.Post("/teams/:id/members/:userId", withActor(1, models.RoleMember), handler.JoinTeam)
 
 	req := httptest.NewRequest("POST", "/teams/1/members/invalid", nil)
 
@@ -525,7 +571,7 @@ func TestTeamHandler_JoinTeam_DatabaseError_Unit(t *testing.T) {
 	handler := NewTeamHandlerWithRepo(mockTeamRepo, mockUserRepo)
 
 	app := fiber.New()
-	app.Post("/teams/:id/members/:userId", handler.JoinTeam)
+	app.Post("/teams/:id/members/:userId", withActor(2, models.RoleMember), handler.JoinTeam)
 
 	team := &models.Team{Model: gorm.Model{ID: 1}, Name: "Test Team"}
 	user := &models.User{Model: gorm.Model{ID: 2}, FirstName: "John", LastName: "Doe", Email: "john@example.com"}
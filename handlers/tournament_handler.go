@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/observer"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
@@ -16,12 +19,15 @@ const (
 	errInvalidTournamentID = "Invalid tournament ID"
 	errInvalidRequestBody  = "Invalid request body"
 	errGameNotFound        = "Game not found"
+	errTournamentStarted   = "Teams can only register or withdraw while the tournament is upcoming"
 )
 
 type TournamentHandler struct {
 	tournamentRepo repositories.TournamentRepository
 	gameRepo       repositories.GameRepository
 	userRepo       repositories.UserRepository
+	teamRepo       repositories.TeamRepository
+	recorder       *audit.Recorder
 }
 
 func NewTournamentHandler(db *gorm.DB) *TournamentHandler {
@@ -29,14 +35,18 @@ func NewTournamentHandler(db *gorm.DB) *TournamentHandler {
 		tournamentRepo: repositories.NewTournamentRepository(db),
 		gameRepo:       repositories.NewGameRepository(db),
 		userRepo:       repositories.NewUserRepository(db),
+		teamRepo:       repositories.NewTeamRepository(db),
+		recorder:       audit.DefaultRecorder,
 	}
 }
 
-func NewTournamentHandlerWithRepo(tournamentRepo repositories.TournamentRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository) *TournamentHandler {
+func NewTournamentHandlerWithRepo(tournamentRepo repositories.TournamentRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository, teamRepo repositories.TeamRepository) *TournamentHandler {
 	return &TournamentHandler{
 		tournamentRepo: tournamentRepo,
 		gameRepo:       gameRepo,
 		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		recorder:       audit.DefaultRecorder,
 	}
 }
 
@@ -84,6 +94,8 @@ func (h *TournamentHandler) CreateTournament(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to create tournament"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTournament, audit.ActionCreated, tournament.ID, nil, &tournament)
+
 	createdTournament, _ := h.tournamentRepo.FindByID(ctx, int(tournament.ID))
 
 	users, _ := h.userRepo.FindAll(ctx)
@@ -114,6 +126,7 @@ func (h *TournamentHandler) UpdateTournament(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
 	}
+	before := audit.Snapshot(tournament)
 
 	var req dtos.CreateTournamentRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -131,6 +144,8 @@ func (h *TournamentHandler) UpdateTournament(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update tournament"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTournament, audit.ActionUpdated, updatedTournament.ID, before, updatedTournament)
+
 	result, _ := h.tournamentRepo.FindByID(ctx, int(updatedTournament.ID))
 
 	response := mappers.ToTournamentResponse(result)
@@ -145,7 +160,7 @@ func (h *TournamentHandler) DeleteTournament(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidTournamentID))
 	}
 
-	_, err = h.tournamentRepo.FindByID(ctx, id)
+	tournament, err := h.tournamentRepo.FindByID(ctx, id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
 	}
@@ -154,5 +169,136 @@ func (h *TournamentHandler) DeleteTournament(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to delete tournament"))
 	}
 
+	h.recorder.RecordChange(c, audit.ResourceTournament, audit.ActionDeleted, tournament.ID, tournament, nil)
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
+
+func (h *TournamentHandler) GetTournamentTeams(c *fiber.Ctx) error {
+	tournament, err := h.findTournament(c)
+	if tournament == nil {
+		return err
+	}
+
+	return c.JSON(mappers.ToTeamResponseListFromPointers(tournament.Teams))
+}
+
+// RegisterTeam enters a team into an upcoming tournament. Members of the team
+// can register it; organizers can register any team.
+func (h *TournamentHandler) RegisterTeam(c *fiber.Ctx) error {
+	tournament, err := h.findTournament(c)
+	if tournament == nil {
+		return err
+	}
+
+	var req dtos.RegisterTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidRequestBody))
+	}
+	if req.TeamID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("teamId is required"))
+	}
+
+	team, err := h.findManagedTeam(c, strconv.FormatUint(uint64(req.TeamID), 10))
+	if team == nil {
+		return err
+	}
+
+	err = h.tournamentRepo.AddTeam(c.Context(), tournament, team)
+	switch {
+	case errors.Is(err, repositories.ErrTournamentNotUpcoming):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict(errTournamentStarted))
+	case errors.Is(err, repositories.ErrTeamAlreadyRegistered):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Team is already registered"))
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to register team"))
+	}
+
+	h.recordTeamChange(c, audit.ActionTournamentTeamRegistered, tournament, team)
+
+	result, err := h.tournamentRepo.FindByID(c.Context(), int(tournament.ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to retrieve tournament"))
+	}
+	return c.Status(fiber.StatusCreated).JSON(mappers.ToTournamentResponse(result))
+}
+
+// WithdrawTeam takes a team out of an upcoming tournament, under the same
+// rules as RegisterTeam.
+func (h *TournamentHandler) WithdrawTeam(c *fiber.Ctx) error {
+	tournament, err := h.findTournament(c)
+	if tournament == nil {
+		return err
+	}
+
+	team, err := h.findManagedTeam(c, c.Params("teamId"))
+	if team == nil {
+		return err
+	}
+
+	err = h.tournamentRepo.RemoveTeam(c.Context(), tournament, team)
+	switch {
+	case errors.Is(err, repositories.ErrTournamentNotUpcoming):
+		return c.Status(fiber.StatusConflict).JSON(utils.Conflict(errTournamentStarted))
+	case errors.Is(err, repositories.ErrTeamNotRegistered):
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to withdraw team"))
+	}
+
+	h.recordTeamChange(c, audit.ActionTournamentTeamWithdrawn, tournament, team)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// findTournament loads the tournament named by the id route parameter. When
+// it returns nil, the error response has already been written.
+func (h *TournamentHandler) findTournament(c *fiber.Ctx) (*models.Tournament, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(errInvalidTournamentID))
+	}
+
+	tournament, err := h.tournamentRepo.FindByID(c.Context(), id)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	return tournament, nil
+}
+
+// findManagedTeam loads a team the caller may register or withdraw: one they
+// belong to, or any team for organizers. When it returns nil, the error
+// response has already been written.
+func (h *TournamentHandler) findManagedTeam(c *fiber.Ctx, teamID string) (*models.Team, error) {
+	userID, ok := actorID(c)
+	if !ok {
+		return nil, c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+
+	if _, err := strconv.ParseUint(teamID, 10, 32); err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid team ID"))
+	}
+	team, err := h.teamRepo.FindByIDWithMembers(c.Context(), teamID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	if actorRole(c).HasAnyOf(models.RoleOrganizer) {
+		return team, nil
+	}
+	for _, member := range team.Users {
+		if member.ID == userID {
+			return team, nil
+		}
+	}
+	return nil, c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+}
+
+func (h *TournamentHandler) recordTeamChange(c *fiber.Ctx, action string, tournament *models.Tournament, team *models.Team) {
+	h.recorder.Record(c, audit.Event{
+		Action:       action,
+		ResourceType: audit.ResourceTournament,
+		ResourceID:   audit.ID(tournament.ID),
+		Metadata:     map[string]interface{}{"team_id": team.ID, "team_name": team.Name},
+	})
+}
@@ -22,7 +22,7 @@ func TestTournamentHandler_GetTournaments_Success_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments", handler.GetTournaments)
@@ -49,7 +49,7 @@ func TestTournamentHandler_GetTournaments_Empty_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments", handler.GetTournaments)
@@ -72,7 +72,7 @@ func TestTournamentHandler_GetTournaments_DatabaseError_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments", handler.GetTournaments)
@@ -95,7 +95,7 @@ func TestTournamentHandler_GetTournamentByID_Success_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments/:id", handler.GetTournamentByID)
@@ -119,7 +119,7 @@ func TestTournamentHandler_GetTournamentByID_NotFound_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments/:id", handler.GetTournamentByID)
@@ -142,7 +142,7 @@ func TestTournamentHandler_GetTournamentByID_InvalidID_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Get("/tournaments/:id", handler.GetTournamentByID)
@@ -162,7 +162,7 @@ func TestTournamentHandler_CreateTournament_Success_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Post("/tournaments", handler.CreateTournament)
@@ -203,7 +203,7 @@ func TestTournamentHandler_CreateTournament_InvalidJSON_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Post("/tournaments", handler.CreateTournament)
@@ -224,7 +224,7 @@ func TestTournamentHandler_CreateTournament_GameNotFound_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Post("/tournaments", handler.CreateTournament)
@@ -256,7 +256,7 @@ func TestTournamentHandler_CreateTournament_DatabaseError_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Post("/tournaments", handler.CreateTournament)
@@ -291,7 +291,7 @@ func TestTournamentHandler_UpdateTournament_Success_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -334,7 +334,7 @@ func TestTournamentHandler_UpdateTournament_NotFound_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -366,7 +366,7 @@ func TestTournamentHandler_UpdateTournament_InvalidID_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -393,7 +393,7 @@ func TestTournamentHandler_UpdateTournament_InvalidJSON_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -418,7 +418,7 @@ func TestTournamentHandler_UpdateTournament_GameNotFound_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -451,7 +451,7 @@ func TestTournamentHandler_UpdateTournament_DatabaseError_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Put("/tournaments/:id", handler.UpdateTournament)
@@ -487,7 +487,7 @@ func TestTournamentHandler_DeleteTournament_Success_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Delete("/tournaments/:id", handler.DeleteTournament)
@@ -512,7 +512,7 @@ func TestTournamentHandler_DeleteTournament_NotFound_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Delete("/tournaments/:id", handler.DeleteTournament)
@@ -535,7 +535,7 @@ func TestTournamentHandler_DeleteTournament_InvalidID_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Delete("/tournaments/:id", handler.DeleteTournament)
@@ -555,7 +555,7 @@ func TestTournamentHandler_DeleteTournament_DatabaseError_Unit(t *testing.T) {
 	mockTournamentRepo := new(mocks.MockTournamentRepository)
 	mockGameRepo := new(mocks.MockGameRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo)
+	handler := NewTournamentHandlerWithRepo(mockTournamentRepo, mockGameRepo, mockUserRepo, new(mocks.MockTeamRepository))
 
 	app := fiber.New()
 	app.Delete("/tournaments/:id", handler.DeleteTournament)
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupTournamentTeamTestApp(db *gorm.DB, store *audit.MemoryStore) *fiber.App {
+	app := fiber.New()
+	tournamentHandler := NewTournamentHandler(db)
+	tournamentHandler.recorder = audit.NewRecorder(store)
+
+	authRequired := middleware.JWTMiddleware(db)
+	app.Get("/tournaments/:id", tournamentHandler.GetTournamentByID)
+	app.Get("/tournaments/:id/teams", tournamentHandler.GetTournamentTeams)
+	app.Post("/tournaments/:id/teams", authRequired, tournamentHandler.RegisterTeam)
+	app.Delete("/tournaments/:id/teams/:teamId", authRequired, tournamentHandler.WithdrawTeam)
+	return app
+}
+
+var nextWeek = time.Now().AddDate(0, 0, 7)
+
+func createTeamTournament(db *gorm.DB, startDate time.Time) *models.Tournament {
+	game := models.Game{Name: "Carcassonne"}
+	db.Create(&game)
+	tournament := models.Tournament{Name: "Spring Cup", GameID: game.ID, Status: models.StatusUpcoming, StartDate: startDate}
+	db.Create(&tournament)
+	return &tournament
+}
+
+func createTeamWithMember(db *gorm.DB, name string, member models.User) *models.Team {
+	team := models.Team{Name: name, Users: []*models.User{&member}}
+	db.Create(&team)
+	return &team
+}
+
+func registerTeam(t *testing.T, app *fiber.App, token string, tournamentID, teamID uint) (*dtos.TournamentResponse, int) {
+	body, _ := json.Marshal(dtos.RegisterTeamRequest{TeamID: teamID})
+	resp, err := app.Test(authorizedRequest("POST", fmt.Sprintf("/tournaments/%d/teams", tournamentID), token, body))
+	assert.NoError(t, err)
+
+	var tournament dtos.TournamentResponse
+	json.NewDecoder(resp.Body).Decode(&tournament)
+	return &tournament, resp.StatusCode
+}
+
+func TestTournamentHandler_RegisterTeam(t *testing.T) {
+	// Given: An upcoming tournament and two teams
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupTournamentTeamTestApp(db, store)
+	tournament := createTeamTournament(db, nextWeek)
+	member, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	meeples := createTeamWithMember(db, "Meeples", member)
+	alpha := createTeamWithMember(db, "Alpha", member)
+
+	// When: A member registers both of their teams
+	_, firstStatus := registerTeam(t, app, token, tournament.ID, meeples.ID)
+	registered, secondStatus := registerTeam(t, app, token, tournament.ID, alpha.ID)
+
+	// Then: The tournament lists them by name and the registrations are audited
+	assert.Equal(t, fiber.StatusCreated, firstStatus)
+	assert.Equal(t, fiber.StatusCreated, secondStatus)
+	assert.Len(t, registered.Teams, 2)
+	assert.Equal(t, "Alpha", registered.Teams[0].Name)
+	assert.Equal(t, "Meeples", registered.Teams[1].Name)
+	assert.Equal(t, []string{"tournament.team_registered", "tournament.team_registered"}, store.Actions())
+
+	resp, _ := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/tournaments/%d/teams", tournament.ID), nil))
+	var teams []dtos.TeamResponse
+	json.NewDecoder(resp.Body).Decode(&teams)
+	assert.Len(t, teams, 2)
+}
+
+func TestTournamentHandler_RegisterTeam_Refused(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupTournamentTeamTestApp(db, audit.NewMemoryStore())
+	upcoming := createTeamTournament(db, nextWeek)
+	started := createTeamTournament(db, time.Now().Add(-time.Hour))
+	member, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	outsider, _ := createRoleTestUser(db, "outsider@example.com", models.RoleMember)
+	own := createTeamWithMember(db, "Meeples", member)
+	other := createTeamWithMember(db, "Strangers", outsider)
+	registerTeam(t, app, token, upcoming.ID, own.ID)
+
+	tests := []struct {
+		name         string
+		tournamentID uint
+		teamID       uint
+		want         int
+	}{
+		{name: "already registered", tournamentID: upcoming.ID, teamID: own.ID, want: fiber.StatusConflict},
+		{name: "tournament started", tournamentID: started.ID, teamID: own.ID, want: fiber.StatusConflict},
+		{name: "not a member", tournamentID: upcoming.ID, teamID: other.ID, want: fiber.StatusForbidden},
+		{name: "unknown team", tournamentID: upcoming.ID, teamID: other.ID + 100, want: fiber.StatusNotFound},
+		{name: "missing team", tournamentID: upcoming.ID, teamID: 0, want: fiber.StatusBadRequest},
+		{name: "unknown tournament", tournamentID: started.ID + 100, teamID: own.ID, want: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Registering the team
+			_, status := registerTeam(t, app, token, tt.tournamentID, tt.teamID)
+
+			// Then: It should be refused
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestTournamentHandler_RegisterTeam_ByOrganizer(t *testing.T) {
+	// Given: A team the organizer does not belong to
+	db := setupTestDB(t)
+	app := setupTournamentTeamTestApp(db, audit.NewMemoryStore())
+	tournament := createTeamTournament(db, nextWeek)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	_, organizerToken := createRoleTestUser(db, "organizer@example.com", models.RoleOrganizer)
+	team := createTeamWithMember(db, "Meeples", member)
+
+	// When: The organizer registers it
+	registered, status := registerTeam(t, app, organizerToken, tournament.ID, team.ID)
+
+	// Then: The team is entered
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Len(t, registered.Teams, 1)
+}
+
+func TestTournamentHandler_WithdrawTeam(t *testing.T) {
+	// Given: A registered team
+	db := setupTestDB(t)
+	store := audit.NewMemoryStore()
+	app := setupTournamentTeamTestApp(db, store)
+	tournament := createTeamTournament(db, nextWeek)
+	member, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	_, outsiderToken := createRoleTestUser(db, "outsider@example.com", models.RoleMember)
+	team := createTeamWithMember(db, "Meeples", member)
+	registerTeam(t, app, token, tournament.ID, team.ID)
+	path := fmt.Sprintf("/tournaments/%d/teams/%d", tournament.ID, team.ID)
+
+	// When: Someone outside the team tries to withdraw it
+	resp, _ := app.Test(authorizedRequest("DELETE", path, outsiderToken, nil))
+
+	// Then: It is refused
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// When: A member withdraws it, twice
+	withdrawn, _ := app.Test(authorizedRequest("DELETE", path, token, nil))
+	again, _ := app.Test(authorizedRequest("DELETE", path, token, nil))
+
+	// Then: The team is gone and the second attempt finds nothing to withdraw
+	assert.Equal(t, fiber.StatusNoContent, withdrawn.StatusCode)
+	assert.Equal(t, fiber.StatusNotFound, again.StatusCode)
+	assert.Equal(t, []string{"tournament.team_registered", "tournament.team_withdrawn"}, store.Actions())
+
+	getResp, _ := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/tournaments/%d", tournament.ID), nil))
+	var result dtos.TournamentResponse
+	json.NewDecoder(getResp.Body).Decode(&result)
+	assert.Empty(t, result.Teams)
+}
+
+func TestTournamentHandler_WithdrawTeam_AfterStart(t *testing.T) {
+	// Given: A team registered before the tournament's start date passed
+	db := setupTestDB(t)
+	app := setupTournamentTeamTestApp(db, audit.NewMemoryStore())
+	tournament := createTeamTournament(db, nextWeek)
+	member, token := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	team := createTeamWithMember(db, "Meeples", member)
+	registerTeam(t, app, token, tournament.ID, team.ID)
+	db.Model(tournament).Update("start_date", time.Now().Add(-time.Minute))
+
+	// When: Withdrawing it
+	resp, _ := app.Test(authorizedRequest("DELETE", fmt.Sprintf("/tournaments/%d/teams/%d", tournament.ID, team.ID), token, nil))
+
+	// Then: The team stays in
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	teamsResp, _ := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/tournaments/%d/teams", tournament.ID), nil))
+	var teams []dtos.TeamResponse
+	json.NewDecoder(teamsResp.Body).Decode(&teams)
+	assert.Len(t, teams, 1)
+}
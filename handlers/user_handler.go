@@ -1,13 +1,17 @@
 package handlers
 
 import (
-	"crypto/sha256"
-	"fmt"
+	"log"
 	"strconv"
+	"time"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
 	"github.com/PI-Team04-GameClub/gameclub-backend/mappers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -15,19 +19,28 @@ import (
 
 type UserHandler struct {
 	userRepo repositories.UserRepository
+	hasher   security.PasswordHasher
+	sessions *security.SessionManager
+	mailer   mail.Sender
+	recorder *audit.Recorder
 }
 
 func NewUserHandler(db *gorm.DB) *UserHandler {
-	return &UserHandler{userRepo: repositories.NewUserRepository(db)}
+	return NewUserHandlerWithRepo(
+		repositories.NewUserRepository(db),
+		repositories.NewRefreshTokenRepository(db),
+		repositories.NewDefaultRevokedTokenRepository(db),
+	)
 }
 
-func NewUserHandlerWithRepo(userRepo repositories.UserRepository) *UserHandler {
-	return &UserHandler{userRepo: userRepo}
-}
-
-func hashUserPassword(password string) string {
-	hash := sha256.Sum256([]byte(password))
-	return fmt.Sprintf("%x", hash)
+func NewUserHandlerWithRepo(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, revokedTokenRepo repositories.RevokedTokenRepository) *UserHandler {
+	return &UserHandler{
+		userRepo: userRepo,
+		hasher:   security.DefaultPasswordHasher,
+		sessions: security.NewSessionManager(userRepo, refreshTokenRepo, revokedTokenRepo),
+		mailer:   mail.DefaultSender,
+		recorder: audit.DefaultRecorder,
+	}
 }
 
 func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
@@ -59,6 +72,14 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
 	}
 
+	userID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+	if userID != uint(id) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
 	user, err := h.userRepo.FindByID(c.Context(), uint(id))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
@@ -69,25 +90,155 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
 	}
 
-	if req.Email != "" && req.Email != user.Email {
+	// Staff acting as the user may fix their profile but not take over the
+	// account by changing its credentials.
+	emailChanged := req.Email != "" && req.Email != user.Email
+	if (req.Password != "" || emailChanged) && impersonated(c) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+	if emailChanged {
 		existingUser, _ := h.userRepo.FindByEmail(c.Context(), req.Email)
 		if existingUser != nil {
 			return c.Status(fiber.StatusConflict).JSON(utils.Conflict("Email already registered"))
 		}
 	}
 
-	if req.Password != "" && len(req.Password) < 6 {
-		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Password must be at least 6 characters"))
+	if req.Password != "" {
+		if err := validatePassword(req.Password); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest(err.Error()))
+		}
+		if req.CurrentPassword == "" || !h.hasher.Verify(user.Password, req.CurrentPassword) {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.Unauthorized("Current password is incorrect"))
+		}
 	}
 
+	before := audit.Snapshot(user)
 	updatedUser := mappers.UpdateUserFromRequest(user, req)
 	if req.Password != "" {
-		updatedUser.Password = hashUserPassword(req.Password)
+		hashedPassword, err := h.hasher.Hash(req.Password)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update user"))
+		}
+		updatedUser.Password = hashedPassword
 	}
 
 	if err := h.userRepo.Update(c.Context(), updatedUser); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update user"))
 	}
 
+	// A new address has to be verified again. Update skips zero values, so
+	// the flags are cleared explicitly.
+	if emailChanged {
+		if err := h.userRepo.UpdateFields(c.Context(), updatedUser.ID, map[string]interface{}{
+			"email_verified":    false,
+			"email_verified_at": nil,
+		}); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update user"))
+		}
+		updatedUser.EmailVerified = false
+		updatedUser.EmailVerifiedAt = nil
+		if err := sendVerificationEmail(c, h.mailer, updatedUser); err != nil {
+			log.Printf("Failed to send verification email to user %d: %v", updatedUser.ID, err)
+		}
+	}
+
+	h.recorder.RecordChange(c, audit.ResourceUser, audit.ActionUpdated, updatedUser.ID, before, updatedUser)
+	if req.Password != "" {
+		// Whoever knew the old password may still hold a session; end them all.
+		if err := h.sessions.RevokeAll(c.Context(), updatedUser); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update user"))
+		}
+		h.recorder.Record(c, audit.Event{
+			Action:       audit.ActionUserPasswordChanged,
+			ResourceType: audit.ResourceUser,
+			ResourceID:   audit.ID(updatedUser.ID),
+		})
+	}
+
 	return c.JSON(mappers.ToUserResponse(updatedUser))
 }
+
+func (h *UserHandler) UpdateUserRole(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	var req dtos.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid request body"))
+	}
+
+	role := models.UserRole(req.Role)
+	if !models.IsValidRole(role) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid role"))
+	}
+
+	if userID, ok := actorID(c); ok && userID == uint(id) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("You cannot change your own role"))
+	}
+
+	user, err := h.userRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+
+	before := audit.Snapshot(user)
+	user.Role = role
+	if err := h.userRepo.Update(c.Context(), user); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to update user role"))
+	}
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionUserRoleChanged,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(user.ID),
+		Before:       before,
+		After:        user,
+	})
+
+	return c.JSON(mappers.ToUserResponse(user))
+}
+
+// ImpersonateUser lets an admin act as a member to reproduce an issue. The
+// token names the admin in its "act" claim and cannot be refreshed.
+func (h *UserHandler) ImpersonateUser(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("Invalid user ID"))
+	}
+
+	adminID, ok := actorID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+	}
+	if adminID == uint(id) {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.BadRequest("You cannot impersonate yourself"))
+	}
+
+	user, err := h.userRepo.FindByID(c.Context(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(utils.NotFound())
+	}
+	if user.Role == models.RoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+	}
+
+	token, expiresAt, err := security.GenerateImpersonationToken(user, adminID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.InternalServerError("Failed to start impersonation"))
+	}
+
+	h.recorder.Record(c, audit.Event{
+		Action:       audit.ActionImpersonationStarted,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   audit.ID(user.ID),
+		Metadata:     map[string]interface{}{"expires_at": expiresAt.UTC().Format(time.RFC3339)},
+	})
+
+	return c.JSON(dtos.ImpersonationResponse{
+		Token:     token,
+		ExpiresIn: int64(time.Until(expiresAt).Seconds()),
+		User:      mappers.ToUserResponse(user),
+	})
+}
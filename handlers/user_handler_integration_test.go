@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -20,7 +24,7 @@ func setupUserTestApp(db *gorm.DB) *fiber.App {
 
 	app.Get("/users", userHandler.GetAllUsers)
 	app.Get("/users/:id", userHandler.GetUserByID)
-	app.Put("/users/:id", userHandler.UpdateUser)
+	app.Put("/users/:id", middleware.JWTMiddleware(db), userHandler.UpdateUser)
 
 	return app
 }
@@ -134,6 +138,7 @@ func TestUserHandler_UpdateUser_Success(t *testing.T) {
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update user request
@@ -149,23 +154,55 @@ func TestUserHandler_UpdateUser_Success(t *testing.T) {
 	assert.Equal(t, "Updated", response.LastName)
 }
 
-func TestUserHandler_UpdateUser_NotFound(t *testing.T) {
-	// Given: An empty database
+func TestUserHandler_UpdateUser_OtherUserForbidden(t *testing.T) {
+	// Given: Two existing users
+	db := setupTestDB(t)
+	app := setupUserTestApp(db)
+
+	user1 := models.User{FirstName: "John", Email: "john@example.com", Password: "hashed"}
+	user2 := models.User{FirstName: "Jane", Email: "jane@example.com", Password: "hashed"}
+	db.Create(&user1)
+	db.Create(&user2)
+
+	reqBody := dtos.UpdateUserRequest{FirstName: "Hacked"}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user2.ID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, user1)
+
+	// When: One user tries to update the other
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden and the user unchanged
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var unchanged models.User
+	db.First(&unchanged, user2.ID)
+	assert.Equal(t, "Jane", unchanged.FirstName)
+}
+
+func TestUserHandler_UpdateUser_Unauthenticated(t *testing.T) {
+	// Given: An existing user and a request without a token
 	db := setupTestDB(t)
 	app := setupUserTestApp(db)
 
+	user := models.User{FirstName: "John", Email: "john@example.com", Password: "hashed"}
+	db.Create(&user)
+
 	reqBody := dtos.UpdateUserRequest{FirstName: "Updated"}
 	body, _ := json.Marshal(reqBody)
 
-	req := httptest.NewRequest("PUT", "/users/999", bytes.NewReader(body))
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 
-	// When: Making the update request for non-existent user
+	// When: Making the update request
 	resp, err := app.Test(req)
 
-	// Then: The request should return not found
+	// Then: The request should be rejected as unauthorized
 	assert.NoError(t, err)
-	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
 func TestUserHandler_UpdateUser_InvalidJSON(t *testing.T) {
@@ -177,6 +214,7 @@ func TestUserHandler_UpdateUser_InvalidJSON(t *testing.T) {
 	db.Create(&user)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader([]byte("invalid")))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update request with invalid JSON
@@ -203,6 +241,7 @@ func TestUserHandler_UpdateUser_DuplicateEmail(t *testing.T) {
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user1.ID), bytes.NewReader(body))
+	authenticate(req, user1)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update request with duplicate email
@@ -213,6 +252,42 @@ func TestUserHandler_UpdateUser_DuplicateEmail(t *testing.T) {
 	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
 }
 
+func TestUserHandler_UpdateUser_EmailChangeRequiresVerification(t *testing.T) {
+	// Given: A user with a verified email address
+	db := setupTestDB(t)
+	sink := mail.NewMemorySink()
+	userHandler := NewUserHandler(db)
+	userHandler.mailer = sink
+	app := fiber.New()
+	app.Put("/users/:id", middleware.JWTMiddleware(db), userHandler.UpdateUser)
+
+	verifiedAt := time.Now()
+	user := models.User{FirstName: "John", Email: "john@example.com", Password: "hashed", EmailVerified: true, EmailVerifiedAt: &verifiedAt}
+	db.Create(&user)
+
+	body, _ := json.Marshal(dtos.UpdateUserRequest{Email: "john.new@example.com"})
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
+	authenticate(req, user)
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Changing the email address
+	resp, err := app.Test(req)
+
+	// Then: The new address is unverified and a verification email is sent to it
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var updatedUser models.User
+	db.First(&updatedUser, user.ID)
+	assert.Equal(t, "john.new@example.com", updatedUser.Email)
+	assert.False(t, updatedUser.EmailVerified)
+	assert.Nil(t, updatedUser.EmailVerifiedAt)
+
+	msg, ok := sink.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "john.new@example.com", msg.To)
+}
+
 func TestUserHandler_UpdateUser_PasswordTooShort(t *testing.T) {
 	// Given: An existing user
 	db := setupTestDB(t)
@@ -227,6 +302,7 @@ func TestUserHandler_UpdateUser_PasswordTooShort(t *testing.T) {
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update request with short password
@@ -238,29 +314,76 @@ func TestUserHandler_UpdateUser_PasswordTooShort(t *testing.T) {
 }
 
 func TestUserHandler_UpdateUser_PasswordIsHashed(t *testing.T) {
-	// Given: An existing user
+	// Given: An existing user with a session
 	db := setupTestDB(t)
 	app := setupUserTestApp(db)
 
-	user := models.User{FirstName: "John", Email: "john@example.com", Password: "oldhash"}
+	oldHash, _ := security.DefaultPasswordHasher.Hash("oldpassword")
+	user := models.User{FirstName: "John", Email: "john@example.com", Password: oldHash}
 	db.Create(&user)
+	db.Create(&models.RefreshToken{UserID: user.ID, TokenHash: "refresh-hash", FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)})
 
 	reqBody := dtos.UpdateUserRequest{
-		Password: "newpassword123",
+		Password:        "newpassword123",
+		CurrentPassword: "oldpassword",
 	}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
+	authenticate(req, user)
 	req.Header.Set("Content-Type", "application/json")
 
 	// When: Making the update user request
-	app.Test(req)
+	resp, err := app.Test(req)
 
-	// Then: The password in the database should be hashed
+	// Then: The password in the database should be hashed and the sessions ended
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 	var updatedUser models.User
 	db.First(&updatedUser, user.ID)
 	assert.NotEqual(t, "newpassword123", updatedUser.Password)
-	assert.NotEqual(t, "oldhash", updatedUser.Password)
+	assert.True(t, security.DefaultPasswordHasher.Verify(updatedUser.Password, "newpassword123"))
+	assert.NotNil(t, updatedUser.SessionsRevokedAt)
+
+	var refreshToken models.RefreshToken
+	db.First(&refreshToken, "user_id = ?", user.ID)
+	assert.True(t, refreshToken.IsRevoked())
+}
+
+func TestUserHandler_UpdateUser_PasswordRequiresCurrentPassword(t *testing.T) {
+	db := setupTestDB(t)
+	app := setupUserTestApp(db)
+
+	oldHash, _ := security.DefaultPasswordHasher.Hash("oldpassword")
+	user := models.User{FirstName: "John", Email: "john@example.com", Password: oldHash}
+	db.Create(&user)
+
+	tests := []struct {
+		name    string
+		current string
+	}{
+		{name: "missing", current: ""},
+		{name: "wrong", current: "guessed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(dtos.UpdateUserRequest{Password: "newpassword123", CurrentPassword: tt.current})
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewReader(body))
+			authenticate(req, user)
+			req.Header.Set("Content-Type", "application/json")
+
+			// When: Changing the password without the right current password
+			resp, err := app.Test(req)
+
+			// Then: It should be refused and the password kept
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+			var stored models.User
+			db.First(&stored, user.ID)
+			assert.Equal(t, oldHash, stored.Password)
+		})
+	}
 }
 
 func TestNewUserHandler(t *testing.T) {
@@ -273,3 +396,77 @@ func TestNewUserHandler(t *testing.T) {
 	// Then: The handler should be created
 	assert.NotNil(t, handler)
 }
+
+func setupAdminRoleTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+	userHandler := NewUserHandler(db)
+
+	app.Put("/admin/users/:id/role", middleware.JWTMiddleware(db), middleware.RequireRole(models.RoleAdmin), userHandler.UpdateUserRole)
+
+	return app
+}
+
+func createRoleTestUser(db *gorm.DB, email string, role models.UserRole) (models.User, string) {
+	user := models.User{FirstName: "Role", LastName: "User", Email: email, Password: "hashed", Role: role}
+	db.Create(&user)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+	return user, token
+}
+
+func TestUserHandler_UpdateUserRole_AsAdmin(t *testing.T) {
+	// Given: An admin and a member
+	db := setupTestDB(t)
+	app := setupAdminRoleTestApp(db)
+	_, adminToken := createRoleTestUser(db, "admin@example.com", models.RoleAdmin)
+	member, _ := createRoleTestUser(db, "member@example.com", models.RoleMember)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Organizer"})
+	req := authorizedRequest("PUT", fmt.Sprintf("/admin/users/%d/role", member.ID), adminToken, body)
+
+	// When: The admin promotes the member
+	resp, err := app.Test(req)
+
+	// Then: The member should become an organizer
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var updated models.User
+	db.First(&updated, member.ID)
+	assert.Equal(t, models.RoleOrganizer, updated.Role)
+}
+
+func TestUserHandler_UpdateUserRole_AsMemberForbidden(t *testing.T) {
+	// Given: Two members
+	db := setupTestDB(t)
+	app := setupAdminRoleTestApp(db)
+	_, memberToken := createRoleTestUser(db, "member@example.com", models.RoleMember)
+	other, _ := createRoleTestUser(db, "other@example.com", models.RoleMember)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Admin"})
+	req := authorizedRequest("PUT", fmt.Sprintf("/admin/users/%d/role", other.ID), memberToken, body)
+
+	// When: A member tries to change a role
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden and the role unchanged
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var unchanged models.User
+	db.First(&unchanged, other.ID)
+	assert.Equal(t, models.RoleMember, unchanged.Role)
+}
+
+func TestUser_DefaultsToMemberRole(t *testing.T) {
+	// Given: A user created without a role
+	db := setupTestDB(t)
+	user := models.User{FirstName: "John", Email: "john@example.com", Password: "hashed"}
+
+	// When: Saving the user
+	db.Create(&user)
+
+	// Then: The stored role should be member
+	var stored models.User
+	db.First(&stored, user.ID)
+	assert.Equal(t, models.RoleMember, stored.Role)
+}
@@ -19,7 +19,7 @@ import (
 func TestUserHandler_GetAllUsers_Success_Unit(t *testing.T) {
 	// Given: Users exist in the repository
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users", handler.GetAllUsers)
@@ -44,7 +44,7 @@ func TestUserHandler_GetAllUsers_Success_Unit(t *testing.T) {
 func TestUserHandler_GetAllUsers_Empty_Unit(t *testing.T) {
 	// Given: No users exist in the repository
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users", handler.GetAllUsers)
@@ -65,7 +65,7 @@ func TestUserHandler_GetAllUsers_Empty_Unit(t *testing.T) {
 func TestUserHandler_GetAllUsers_DatabaseError_Unit(t *testing.T) {
 	// Given: A database error occurs
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users", handler.GetAllUsers)
@@ -86,7 +86,7 @@ func TestUserHandler_GetAllUsers_DatabaseError_Unit(t *testing.T) {
 func TestUserHandler_GetUserByID_Success_Unit(t *testing.T) {
 	// Given: A user exists with the specified ID
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users/:id", handler.GetUserByID)
@@ -108,7 +108,7 @@ func TestUserHandler_GetUserByID_Success_Unit(t *testing.T) {
 func TestUserHandler_GetUserByID_NotFound_Unit(t *testing.T) {
 	// Given: No user exists with the specified ID
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users/:id", handler.GetUserByID)
@@ -129,7 +129,7 @@ func TestUserHandler_GetUserByID_NotFound_Unit(t *testing.T) {
 func TestUserHandler_GetUserByID_InvalidID_Unit(t *testing.T) {
 	// Given: An invalid user ID is provided
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
 	app.Get("/users/:id", handler.GetUserByID)
@@ -147,10 +147,10 @@ func TestUserHandler_GetUserByID_InvalidID_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_Success_Unit(t *testing.T) {
 	// Given: A user exists and valid update request
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	existingUser := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", LastName: "Doe", Email: "john@example.com"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(existingUser, nil)
@@ -177,10 +177,10 @@ func TestUserHandler_UpdateUser_Success_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_NotFound_Unit(t *testing.T) {
 	// Given: No user exists with the specified ID
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(999, models.RoleMember), handler.UpdateUser)
 
 	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -204,10 +204,10 @@ func TestUserHandler_UpdateUser_NotFound_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_InvalidID_Unit(t *testing.T) {
 	// Given: An invalid user ID is provided
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	reqBody := dtos.UpdateUserRequest{
 		FirstName: "Johnny",
@@ -228,10 +228,10 @@ func TestUserHandler_UpdateUser_InvalidID_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_InvalidJSON_Unit(t *testing.T) {
 	// Given: A user exists but request body is invalid JSON
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	existingUser := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", Email: "john@example.com"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(existingUser, nil)
@@ -251,10 +251,10 @@ func TestUserHandler_UpdateUser_InvalidJSON_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_EmailAlreadyExists_Unit(t *testing.T) {
 	// Given: A user exists but new email is already taken
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	existingUser := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", Email: "john@example.com"}
 	otherUser := &models.User{Model: gorm.Model{ID: 2}, Email: "taken@example.com"}
@@ -281,10 +281,10 @@ func TestUserHandler_UpdateUser_EmailAlreadyExists_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_PasswordTooShort_Unit(t *testing.T) {
 	// Given: A user exists but new password is too short
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	existingUser := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", Email: "john@example.com"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(existingUser, nil)
@@ -309,10 +309,10 @@ func TestUserHandler_UpdateUser_PasswordTooShort_Unit(t *testing.T) {
 func TestUserHandler_UpdateUser_DatabaseError_Unit(t *testing.T) {
 	// Given: A user exists but database error occurs during update
 	mockUserRepo := new(mocks.MockUserRepository)
-	handler := NewUserHandlerWithRepo(mockUserRepo)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
 
 	app := fiber.New()
-	app.Put("/users/:id", handler.UpdateUser)
+	app.Put("/users/:id", withActor(1, models.RoleMember), handler.UpdateUser)
 
 	existingUser := &models.User{Model: gorm.Model{ID: 1}, FirstName: "John", Email: "john@example.com"}
 	mockUserRepo.On("FindByID", mock.Anything, uint(1)).Return(existingUser, nil)
@@ -334,3 +334,120 @@ func TestUserHandler_UpdateUser_DatabaseError_Unit(t *testing.T) {
 	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestUserHandler_UpdateUser_OtherUser_Unit(t *testing.T) {
+	// Given: A user trying to update someone else
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
+
+	app := fiber.New()
+	app.Put("/users/:id", withActor(2, models.RoleMember), handler.UpdateUser)
+
+	body, _ := json.Marshal(dtos.UpdateUserRequest{FirstName: "Hacked"})
+	req := httptest.NewRequest("PUT", "/users/1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update user request
+	resp, err := app.Test(req)
+
+	// Then: The request should be forbidden without touching the repository
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func setupUserRoleTestApp(handler *UserHandler, actorID uint) *fiber.App {
+	app := fiber.New()
+	app.Put("/admin/users/:id/role", func(c *fiber.Ctx) error {
+		c.Locals("userID", actorID)
+		return c.Next()
+	}, handler.UpdateUserRole)
+	return app
+}
+
+func TestUserHandler_UpdateUserRole_Success_Unit(t *testing.T) {
+	// Given: A member and an admin changing their role
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
+	app := setupUserRoleTestApp(handler, 1)
+
+	existingUser := &models.User{Model: gorm.Model{ID: 2}, Email: "member@example.com", Role: models.RoleMember}
+	mockUserRepo.On("FindByID", mock.Anything, uint(2)).Return(existingUser, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return u.Role == models.RoleOrganizer
+	})).Return(nil)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Organizer"})
+	req := httptest.NewRequest("PUT", "/admin/users/2/role", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update role request
+	resp, err := app.Test(req)
+
+	// Then: The role should be updated and returned
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response dtos.UserResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, "Organizer", response.Role)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserHandler_UpdateUserRole_InvalidRole_Unit(t *testing.T) {
+	// Given: An unknown role in the request
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
+	app := setupUserRoleTestApp(handler, 1)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Superuser"})
+	req := httptest.NewRequest("PUT", "/admin/users/2/role", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update role request
+	resp, err := app.Test(req)
+
+	// Then: The request should fail with bad request
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_UpdateUserRole_OwnRole_Unit(t *testing.T) {
+	// Given: An admin trying to change their own role
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
+	app := setupUserRoleTestApp(handler, 1)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Member"})
+	req := httptest.NewRequest("PUT", "/admin/users/1/role", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update role request
+	resp, err := app.Test(req)
+
+	// Then: The request should fail with bad request
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_UpdateUserRole_NotFound_Unit(t *testing.T) {
+	// Given: A user that does not exist
+	mockUserRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandlerWithRepo(mockUserRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockRevokedTokenRepository))
+	app := setupUserRoleTestApp(handler, 1)
+
+	mockUserRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+
+	body, _ := json.Marshal(dtos.UpdateUserRoleRequest{Role: "Organizer"})
+	req := httptest.NewRequest("PUT", "/admin/users/999/role", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// When: Making the update role request
+	resp, err := app.Test(req)
+
+	// Then: The request should return not found
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -8,4 +8,7 @@ type ITournamentHandler interface {
 	CreateTournament(c *fiber.Ctx) error
 	UpdateTournament(c *fiber.Ctx) error
 	DeleteTournament(c *fiber.Ctx) error
+	GetTournamentTeams(c *fiber.Ctx) error
+	RegisterTeam(c *fiber.Ctx) error
+	WithdrawTeam(c *fiber.Ctx) error
 }
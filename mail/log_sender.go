@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	log.Printf("Sending email to: %s", msg.To)
+	log.Printf("Subject: %s", msg.Subject)
+	log.Printf("Body: %s", msg.Body)
+	return nil
+}
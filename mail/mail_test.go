@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSenderFromConfig_WithoutSMTPHost(t *testing.T) {
+	// Given: A configuration without an SMTP host
+	cfg := &config.Config{}
+
+	// When: Building the sender
+	sender := NewSenderFromConfig(cfg)
+
+	// Then: Email should only be logged
+	assert.IsType(t, &LogSender{}, sender)
+}
+
+func TestNewSenderFromConfig_WithSMTPHost(t *testing.T) {
+	// Given: A configuration with an SMTP host
+	cfg := &config.Config{SMTPHost: "smtp.example.com", SMTPPort: 587, MailFrom: "no-reply@example.com"}
+
+	// When: Building the sender
+	sender := NewSenderFromConfig(cfg)
+
+	// Then: Email should be sent over SMTP
+	assert.IsType(t, &SMTPSender{}, sender)
+}
+
+func TestMemorySink_RecordsMessages(t *testing.T) {
+	// Given: An empty memory sink
+	sink := NewMemorySink()
+
+	// When: Sending two messages
+	sink.Send(context.Background(), Message{To: "a@example.com"})
+	sink.Send(context.Background(), Message{To: "b@example.com"})
+
+	// Then: Both should be recorded in order
+	assert.Len(t, sink.Messages(), 2)
+	last, ok := sink.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "b@example.com", last.To)
+}
+
+func TestMemorySink_FailWith(t *testing.T) {
+	// Given: A memory sink configured to fail
+	sink := NewMemorySink()
+	sink.FailWith(errors.New("boom"))
+
+	// When: Sending a message
+	err := sink.Send(context.Background(), Message{To: "a@example.com"})
+
+	// Then: The error should be returned and nothing recorded
+	assert.Error(t, err)
+	assert.Empty(t, sink.Messages())
+}
+
+func TestVerificationEmail_ContainsLink(t *testing.T) {
+	// Given: A verification token with characters that need escaping
+	token := "abc+def/ghi"
+
+	// When: Building the verification email
+	msg := VerificationEmail("user@example.com", "Jane", token)
+
+	// Then: The body should link to the verification page with the escaped token
+	assert.Equal(t, "user@example.com", msg.To)
+	assert.Contains(t, msg.Body, "Hi Jane")
+	assert.Contains(t, msg.Body, AppBaseURL+"/verify-email?token=abc%2Bdef%2Fghi")
+}
+
+func TestFormatMessage_UsesCRLF(t *testing.T) {
+	// Given: A message with a multi-line body
+	msg := Message{To: "user@example.com", Subject: "Hello", Body: "line one\nline two"}
+
+	// When: Formatting it for SMTP
+	raw := string(formatMessage("no-reply@example.com", msg))
+
+	// Then: Headers and body should use CRLF line endings
+	assert.True(t, strings.HasPrefix(raw, "From: no-reply@example.com\r\n"))
+	assert.Contains(t, raw, "Subject: Hello\r\n")
+	assert.Contains(t, raw, "line one\r\nline two")
+}
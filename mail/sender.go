@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var DefaultSender Sender = NewLogSender()
+
+var AppBaseURL = "http://localhost:3000"
+
+func NewSenderFromConfig(cfg *config.Config) Sender {
+	if cfg.SMTPHost == "" {
+		return NewLogSender()
+	}
+	return NewSMTPSender(SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.MailFrom,
+	})
+}
+
+func Configure(cfg *config.Config) {
+	DefaultSender = NewSenderFromConfig(cfg)
+	if cfg.AppBaseURL != "" {
+		AppBaseURL = cfg.AppBaseURL
+	}
+}
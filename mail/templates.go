@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func VerificationEmail(to, firstName, token string) Message {
+	link := buildLink("/verify-email", token)
+	return Message{
+		To:      to,
+		Subject: "Verify your GameClub email address",
+		Body: fmt.Sprintf(`Hi %s,
+
+Please confirm your email address by opening the link below:
+
+%s
+
+If you did not create a GameClub account, you can ignore this email.
+
+Best regards,
+GameClub Team
+`, firstName, link),
+	}
+}
+
+func PasswordResetEmail(to, firstName, token string) Message {
+	link := buildLink("/reset-password", token)
+	return Message{
+		To:      to,
+		Subject: "Reset your GameClub password",
+		Body: fmt.Sprintf(`Hi %s,
+
+We received a request to reset your password. Open the link below to choose a new one:
+
+%s
+
+The link can only be used once and expires soon. If you did not ask for a password reset, you can ignore this email.
+
+Best regards,
+GameClub Team
+`, firstName, link),
+	}
+}
+
+func buildLink(path, token string) string {
+	return strings.TrimRight(AppBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
@@ -3,11 +3,16 @@ package main
 import (
 	"log"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/audit"
 	"github.com/PI-Team04-GameClub/gameclub-backend/config"
 	"github.com/PI-Team04-GameClub/gameclub-backend/db"
+	"github.com/PI-Team04-GameClub/gameclub-backend/mail"
 	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/oidc"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating"
 	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
 	"github.com/PI-Team04-GameClub/gameclub-backend/routes"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -16,8 +21,21 @@ import (
 func main() {
 	cfg := config.GetFromEnv()
 
+	if err := security.Configure(cfg); err != nil {
+		log.Fatalf("Failed to configure security: %v", err)
+	}
+
+	if err := rating.Configure(cfg); err != nil {
+		log.Fatalf("Failed to configure ratings: %v", err)
+	}
+
+	mail.Configure(cfg)
+	oidc.Configure(cfg)
+
 	db.Connect(cfg)
 	db.Migrate()
+	db.PromoteAdmin(cfg.AdminEmail)
+	audit.Configure(db.DB)
 
 	redis.Connect(cfg)
 	defer redis.Close()
@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+func ToAPIKeyResponse(key *models.APIKey) dtos.APIKeyResponse {
+	return dtos.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
+
+func ToAPIKeyResponseList(keys []models.APIKey) []dtos.APIKeyResponse {
+	responses := make([]dtos.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = ToAPIKeyResponse(&keys[i])
+	}
+	return responses
+}
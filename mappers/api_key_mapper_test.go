@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToAPIKeyResponse_OmitsSecrets(t *testing.T) {
+	// Given: A stored API key
+	expiresAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	key := &models.APIKey{
+		Model:     gorm.Model{ID: 7},
+		Name:      "Scoreboard",
+		Prefix:    "gck_abcdefgh",
+		KeyHash:   "secret-hash",
+		Scopes:    "tournaments:write games:write",
+		ExpiresAt: &expiresAt,
+	}
+
+	// When: Converting to response
+	response := ToAPIKeyResponse(key)
+
+	// Then: Display fields should be mapped and scopes split
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, "Scoreboard", response.Name)
+	assert.Equal(t, "gck_abcdefgh", response.Prefix)
+	assert.Equal(t, []string{"tournaments:write", "games:write"}, response.Scopes)
+	assert.Equal(t, &expiresAt, response.ExpiresAt)
+	assert.Nil(t, response.LastUsedAt)
+}
+
+func TestToAPIKeyResponseList_Empty(t *testing.T) {
+	// Given: No keys
+	// When: Converting the list
+	responses := ToAPIKeyResponseList(nil)
+
+	// Then: An empty list should be returned
+	assert.Empty(t, responses)
+	assert.NotNil(t, responses)
+}
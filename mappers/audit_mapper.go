@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+func ToAuditEventResponse(event *models.AuditEvent) dtos.AuditEventResponse {
+	return dtos.AuditEventResponse{
+		ID:           event.ID,
+		CreatedAt:    event.CreatedAt,
+		ActorID:      event.ActorID,
+		ActorEmail:   event.ActorEmail,
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		IP:           event.IP,
+		UserAgent:    event.UserAgent,
+		Changes:      rawJSON(event.Changes),
+		Metadata:     rawJSON(event.Metadata),
+	}
+}
+
+func ToAuditEventResponseList(events []models.AuditEvent) []dtos.AuditEventResponse {
+	responses := make([]dtos.AuditEventResponse, len(events))
+	for i := range events {
+		responses[i] = ToAuditEventResponse(&events[i])
+	}
+	return responses
+}
+
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToAuditEventResponse_EmbedsJSON(t *testing.T) {
+	// Given: A stored audit event with changes and no metadata
+	actorID := uint(3)
+	event := &models.AuditEvent{
+		ID:           9,
+		ActorID:      &actorID,
+		ActorEmail:   "admin@example.com",
+		Action:       "user.role_changed",
+		ResourceType: "user",
+		ResourceID:   "4",
+		IP:           "10.0.0.1",
+		Changes:      `{"Role":{"before":"Member","after":"Admin"}}`,
+	}
+
+	// When: Converting to response
+	response := ToAuditEventResponse(event)
+
+	// Then: Changes should be embedded as JSON and empty metadata omitted
+	assert.Equal(t, uint(9), response.ID)
+	assert.Equal(t, &actorID, response.ActorID)
+	assert.Equal(t, "user.role_changed", response.Action)
+	assert.JSONEq(t, event.Changes, string(response.Changes))
+	assert.Nil(t, response.Metadata)
+}
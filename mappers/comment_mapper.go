@@ -26,10 +26,10 @@ func ToCommentResponseList(comments []models.Comment) []dtos.CommentResponse {
 	return responses
 }
 
-func ToCommentModel(req dtos.CreateCommentRequest) models.Comment {
+func ToCommentModel(req dtos.CreateCommentRequest, userID uint) models.Comment {
 	return models.Comment{
 		Content: req.Content,
-		UserID:  req.UserID,
+		UserID:  userID,
 		NewsID:  req.NewsID,
 	}
 }
@@ -151,12 +151,11 @@ func TestToCommentModel_BasicRequest(t *testing.T) {
 	// Given: A create comment request
 	req := dtos.CreateCommentRequest{
 		Content: "New comment",
-		UserID:  10,
 		NewsID:  20,
 	}
 
 	// When: Converting to model
-	comment := ToCommentModel(req)
+	comment := ToCommentModel(req, 10)
 
 	// Then: Fields should be set correctly
 	assert.Equal(t, "New comment", comment.Content)
@@ -168,12 +167,11 @@ func TestToCommentModel_SpecialCharactersInContent(t *testing.T) {
 	// Given: A create comment request with special characters
 	req := dtos.CreateCommentRequest{
 		Content: "Special chars: <html> & symbols @#$%",
-		UserID:  1,
 		NewsID:  1,
 	}
 
 	// When: Converting to model
-	comment := ToCommentModel(req)
+	comment := ToCommentModel(req, 1)
 
 	// Then: Special characters should be preserved
 	assert.Equal(t, "Special chars: <html> & symbols @#$%", comment.Content)
@@ -26,9 +26,9 @@ func ToFriendRequestResponseList(friendRequests []models.FriendRequest) []dtos.F
 	return responses
 }
 
-func ToFriendRequestModel(req dtos.CreateFriendRequestRequest) models.FriendRequest {
+func ToFriendRequestModel(req dtos.CreateFriendRequestRequest, senderID uint) models.FriendRequest {
 	return models.FriendRequest{
-		SenderID:   req.SenderID,
+		SenderID:   senderID,
 		ReceiverID: req.ReceiverID,
 		Status:     models.StatusPending,
 	}
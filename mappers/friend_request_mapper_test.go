@@ -190,12 +190,11 @@ func TestToFriendRequestResponseList_PreservesOrder(t *testing.T) {
 func TestToFriendRequestModel_BasicRequest(t *testing.T) {
 	// Given: A create friend request request
 	req := dtos.CreateFriendRequestRequest{
-		SenderID:   10,
 		ReceiverID: 20,
 	}
 
 	// When: Converting to model
-	friendRequest := ToFriendRequestModel(req)
+	friendRequest := ToFriendRequestModel(req, 10)
 
 	// Then: Fields should be set correctly
 	assert.Equal(t, uint(10), friendRequest.SenderID)
@@ -206,12 +205,11 @@ func TestToFriendRequestModel_BasicRequest(t *testing.T) {
 func TestToFriendRequestModel_DefaultStatus(t *testing.T) {
 	// Given: A create friend request request
 	req := dtos.CreateFriendRequestRequest{
-		SenderID:   1,
 		ReceiverID: 2,
 	}
 
 	// When: Converting to model
-	friendRequest := ToFriendRequestModel(req)
+	friendRequest := ToFriendRequestModel(req, 1)
 
 	// Then: Status should default to Pending
 	assert.Equal(t, models.StatusPending, friendRequest.Status)
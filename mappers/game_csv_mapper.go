@@ -0,0 +1,114 @@
+package mappers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+// GameCSVHeader names the export columns like the fields of GameResponse, so
+// an exported sheet can be edited and imported again.
+var GameCSVHeader = []string{
+	"id", "name", "description", "numberOfPlayers", "minPlayers", "maxPlayers",
+	"playtimeMinutes", "minAge", "complexity", "category", "publisher",
+	"yearPublished", "rating", "ratingCount",
+}
+
+// Spreadsheet programs often start a UTF-8 CSV file with a byte order mark.
+const utf8BOM = "\ufeff"
+
+// Export-only columns that an import accepts and ignores.
+var gameCSVReadOnlyColumns = map[string]bool{"id": true, "rating": true, "ratingCount": true}
+
+func ToGameCSVRecord(game *models.Game) []string {
+	return []string{
+		strconv.FormatUint(uint64(game.ID), 10),
+		game.Name,
+		game.Description,
+		strconv.Itoa(game.NumberOfPlayers),
+		strconv.Itoa(game.MinPlayers),
+		strconv.Itoa(game.MaxPlayers),
+		strconv.Itoa(game.PlaytimeMinutes),
+		strconv.Itoa(game.MinAge),
+		string(game.Complexity),
+		string(game.Category),
+		game.Publisher,
+		strconv.Itoa(game.YearPublished),
+		strconv.FormatFloat(game.Rating, 'f', -1, 64),
+		strconv.Itoa(game.RatingCount),
+	}
+}
+
+// ParseGameCSVHeader returns the column names of an import sheet in their
+// canonical spelling. Column names are matched case-insensitively and
+// unknown columns are rejected rather than silently dropped.
+func ParseGameCSVHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, raw := range header {
+		name := strings.TrimSpace(strings.TrimPrefix(raw, utf8BOM))
+		column := ""
+		for _, known := range GameCSVHeader {
+			if strings.EqualFold(name, known) {
+				column = known
+				break
+			}
+		}
+		if column == "" {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("column %q appears more than once", column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	if !seen["name"] {
+		return nil, fmt.Errorf("the name column is required")
+	}
+	return columns, nil
+}
+
+// ToGameRequestFromCSV reads one record laid out as columns, which must come
+// from ParseGameCSVHeader. Empty numeric cells are left at zero.
+func ToGameRequestFromCSV(columns, record []string) (dtos.CreateGameRequest, error) {
+	var req dtos.CreateGameRequest
+	ints := map[string]*int{
+		"numberOfPlayers": &req.NumberOfPlayers,
+		"minPlayers":      &req.MinPlayers,
+		"maxPlayers":      &req.MaxPlayers,
+		"playtimeMinutes": &req.PlaytimeMinutes,
+		"minAge":          &req.MinAge,
+		"yearPublished":   &req.YearPublished,
+	}
+	strs := map[string]*string{
+		"name":        &req.Name,
+		"description": &req.Description,
+		"complexity":  &req.Complexity,
+		"category":    &req.Category,
+		"publisher":   &req.Publisher,
+	}
+
+	for i, column := range columns {
+		if i >= len(record) || gameCSVReadOnlyColumns[column] {
+			continue
+		}
+		value := strings.TrimSpace(record[i])
+		if target, ok := strs[column]; ok {
+			*target = value
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return req, fmt.Errorf("%s must be a whole number", column)
+		}
+		*ints[column] = parsed
+	}
+	return req, nil
+}
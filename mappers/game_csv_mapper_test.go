@@ -0,0 +1,84 @@
+package mappers
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToGameCSVRecord_RoundTrip(t *testing.T) {
+	// Given: An exported game
+	game := &models.Game{
+		Model:           gorm.Model{ID: 3},
+		Name:            "Azul",
+		Description:     "Tiles, \"walls\" and\nscoring",
+		NumberOfPlayers: 4,
+		MinPlayers:      2,
+		MaxPlayers:      4,
+		PlaytimeMinutes: 45,
+		MinAge:          8,
+		Complexity:      models.ComplexityEasy,
+		Category:        models.CategoryFamily,
+		Publisher:       "Plan B",
+		YearPublished:   2017,
+		Rating:          7.75,
+		RatingCount:     4,
+	}
+	record := ToGameCSVRecord(game)
+
+	// When: Reading the record back as an import row
+	req, err := ToGameRequestFromCSV(GameCSVHeader, record)
+
+	// Then: Every editable field survives and the rating is ignored
+	assert.NoError(t, err)
+	assert.Len(t, record, len(GameCSVHeader))
+	assert.Equal(t, "7.75", record[12])
+	assert.Equal(t, "Azul", req.Name)
+	assert.Equal(t, game.Description, req.Description)
+	assert.Equal(t, 4, req.NumberOfPlayers)
+	assert.Equal(t, 2, req.MinPlayers)
+	assert.Equal(t, 45, req.PlaytimeMinutes)
+	assert.Equal(t, "Easy", req.Complexity)
+	assert.Equal(t, "Family", req.Category)
+	assert.Equal(t, 2017, req.YearPublished)
+}
+
+func TestToGameRequestFromCSV_RejectsNonNumbers(t *testing.T) {
+	// Given: A row with text in a numeric column
+	columns := []string{"name", "minAge"}
+
+	// When: Reading it
+	_, err := ToGameRequestFromCSV(columns, []string{"Azul", "eight"})
+
+	// Then: The column is named in the error
+	assert.EqualError(t, err, "minAge must be a whole number")
+}
+
+func TestParseGameCSVHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []string
+		want    []string
+		wantErr string
+	}{
+		{name: "any case and a byte order mark", header: []string{"\ufeffName", " MINAGE "}, want: []string{"name", "minAge"}},
+		{name: "unknown column", header: []string{"name", "price"}, wantErr: `unknown column "price"`},
+		{name: "repeated column", header: []string{"name", "Name"}, wantErr: `column "name" appears more than once`},
+		{name: "missing name", header: []string{"minAge"}, wantErr: "the name column is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := ParseGameCSVHeader(tt.header)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, columns)
+		})
+	}
+}
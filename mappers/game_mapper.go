@@ -20,9 +20,21 @@ func ToGameResponse(game *models.Game) dtos.GameResponse {
 		Publisher:       game.Publisher,
 		YearPublished:   game.YearPublished,
 		Rating:          game.Rating,
+		RatingCount:     game.RatingCount,
+		BaseGameID:      game.BaseGameID,
+		Tags:            ToTagResponseList(game.Tags),
+		Expansions:      toGameSummaryList(game.Expansions),
 	}
 }
 
+func toGameSummaryList(games []models.Game) []dtos.GameSummaryResponse {
+	summaries := make([]dtos.GameSummaryResponse, len(games))
+	for i, game := range games {
+		summaries[i] = dtos.GameSummaryResponse{ID: game.ID, Name: game.Name}
+	}
+	return summaries
+}
+
 func ToGameModel(req dtos.CreateGameRequest) models.Game {
 	builder := models.NewGameBuilder().
 		SetName(req.Name).
@@ -60,8 +72,8 @@ func ToGameModel(req dtos.CreateGameRequest) models.Game {
 		builder.SetYearPublished(req.YearPublished)
 	}
 
-	if req.Rating > 0 {
-		builder.SetRating(req.Rating)
+	if req.BaseGameID != nil && *req.BaseGameID != 0 {
+		builder.SetBaseGameID(req.BaseGameID)
 	}
 
 	return *builder.Build()
@@ -80,7 +92,10 @@ func UpdateGameFromRequest(existingGame *models.Game, req dtos.CreateGameRequest
 		SetID(existingGame.ID).
 		SetModel(existingGame.Model).
 		SetName(req.Name).
-		SetDescription(req.Description)
+		SetDescription(req.Description).
+		SetRating(existingGame.Rating).
+		SetRatingCount(existingGame.RatingCount).
+		SetBaseGameID(existingGame.BaseGameID)
 
 	if req.MinPlayers > 0 && req.MaxPlayers > 0 {
 		builder.SetPlayerRange(req.MinPlayers, req.MaxPlayers)
@@ -114,8 +129,12 @@ func UpdateGameFromRequest(existingGame *models.Game, req dtos.CreateGameRequest
 		builder.SetYearPublished(req.YearPublished)
 	}
 
-	if req.Rating > 0 {
-		builder.SetRating(req.Rating)
+	if req.BaseGameID != nil {
+		if *req.BaseGameID == 0 {
+			builder.SetBaseGameID(nil)
+		} else {
+			builder.SetBaseGameID(req.BaseGameID)
+		}
 	}
 
 	return builder.Build()
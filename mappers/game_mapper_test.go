@@ -25,6 +25,7 @@ func TestToGameResponse_BasicFields(t *testing.T) {
 		Publisher:       "Classic Games",
 		YearPublished:   1850,
 		Rating:          9.5,
+		RatingCount:     12,
 	}
 
 	// When: Converting to response
@@ -44,6 +45,7 @@ func TestToGameResponse_BasicFields(t *testing.T) {
 	assert.Equal(t, "Classic Games", response.Publisher)
 	assert.Equal(t, 1850, response.YearPublished)
 	assert.Equal(t, 9.5, response.Rating)
+	assert.Equal(t, 12, response.RatingCount)
 }
 
 func TestToGameResponse_EmptyFields(t *testing.T) {
@@ -108,7 +110,6 @@ func TestToGameModel_WithOptionalFields(t *testing.T) {
 		Category:        "Strategy",
 		Publisher:       "Board Game Publisher",
 		YearPublished:   2023,
-		Rating:          8.5,
 	}
 
 	// When: Converting to model
@@ -121,7 +122,7 @@ func TestToGameModel_WithOptionalFields(t *testing.T) {
 	assert.Equal(t, models.GameCategory("Strategy"), game.Category)
 	assert.Equal(t, "Board Game Publisher", game.Publisher)
 	assert.Equal(t, 2023, game.YearPublished)
-	assert.Equal(t, 8.5, game.Rating)
+	assert.Equal(t, 0.0, game.Rating)
 }
 
 func TestToGameModel_DefaultValues(t *testing.T) {
@@ -191,7 +192,6 @@ func TestUpdateGameFromRequest_UpdatesAllFields(t *testing.T) {
 		Category:        "Party",
 		Publisher:       "New Publisher",
 		YearPublished:   2024,
-		Rating:          9.0,
 	}
 
 	// When: Updating the game
@@ -224,6 +224,23 @@ func TestUpdateGameFromRequest_PreservesID(t *testing.T) {
 	assert.Equal(t, uint(999), updatedGame.ID)
 }
 
+func TestUpdateGameFromRequest_PreservesRating(t *testing.T) {
+	// Given: A reviewed game
+	existingGame := &models.Game{
+		Model:       gorm.Model{ID: 5},
+		Name:        "Reviewed",
+		Rating:      7.25,
+		RatingCount: 4,
+	}
+
+	// When: Updating its details
+	updatedGame := UpdateGameFromRequest(existingGame, dtos.CreateGameRequest{Name: "Renamed"})
+
+	// Then: The rating computed from reviews should be kept
+	assert.Equal(t, 7.25, updatedGame.Rating)
+	assert.Equal(t, 4, updatedGame.RatingCount)
+}
+
 func TestToGameResponse_ComplexityEnumConversion(t *testing.T) {
 	// Given: A game with Expert complexity
 	game := &models.Game{
@@ -253,3 +270,43 @@ func TestToGameResponse_CategoryEnumConversion(t *testing.T) {
 	// Then: Category should be converted to string "Cooperative"
 	assert.Equal(t, "Cooperative", response.Category)
 }
+
+func TestToGameResponse_TagsAndExpansions(t *testing.T) {
+	// Given: A base game with a tag and an expansion
+	game := models.NewGameBuilder().SetID(1).SetName("Catan").Build()
+	game.Tags = []models.Tag{{ID: 3, Name: "Trading"}}
+	game.Expansions = []models.Game{*models.NewGameBuilder().SetID(2).SetName("Seafarers").SetBaseGameID(&game.ID).Build()}
+
+	// When: Converting to response
+	response := ToGameResponse(game)
+
+	// Then: Both relations are listed
+	assert.Nil(t, response.BaseGameID)
+	assert.Equal(t, []dtos.TagResponse{{ID: 3, Name: "Trading"}}, response.Tags)
+	assert.Equal(t, []dtos.GameSummaryResponse{{ID: 2, Name: "Seafarers"}}, response.Expansions)
+}
+
+func TestUpdateGameFromRequest_BaseGame(t *testing.T) {
+	base, other, detach := uint(1), uint(5), uint(0)
+	existing := models.NewGameBuilder().SetID(2).SetName("Seafarers").SetBaseGameID(&base).Build()
+
+	tests := []struct {
+		name       string
+		baseGameID *uint
+		want       *uint
+	}{
+		{name: "omitted keeps the base game", baseGameID: nil, want: &base},
+		{name: "zero detaches", baseGameID: &detach, want: nil},
+		{name: "id moves the expansion", baseGameID: &other, want: &other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Updating with the request
+			updated := UpdateGameFromRequest(existing, dtos.CreateGameRequest{Name: "Seafarers", BaseGameID: tt.baseGameID})
+
+			// Then: The base game follows the request
+			assert.Equal(t, tt.want, updated.BaseGameID)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+func ToGameReviewResponse(review *models.GameReview) dtos.GameReviewResponse {
+	return dtos.GameReviewResponse{
+		ID:        review.ID,
+		GameID:    review.GameID,
+		GameName:  review.Game.Name,
+		UserID:    review.UserID,
+		UserName:  strings.TrimSpace(review.User.FirstName + " " + review.User.LastName),
+		Score:     review.Score,
+		Text:      review.Text,
+		CreatedAt: review.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: review.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func ToGameReviewResponseList(reviews []models.GameReview) []dtos.GameReviewResponse {
+	responses := make([]dtos.GameReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = ToGameReviewResponse(&review)
+	}
+	return responses
+}
+
+func ToGameReviewModel(req dtos.GameReviewRequest, gameID, userID uint) models.GameReview {
+	return models.GameReview{
+		GameID: gameID,
+		UserID: userID,
+		Score:  req.Score,
+		Text:   strings.TrimSpace(req.Text),
+	}
+}
@@ -0,0 +1,50 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToGameReviewResponse_MapsAllFields(t *testing.T) {
+	// Given: A review with its author loaded
+	now := time.Now()
+	review := &models.GameReview{
+		Model:  gorm.Model{ID: 4, CreatedAt: now, UpdatedAt: now},
+		GameID: 2,
+		UserID: 9,
+		Score:  8,
+		Text:   "Great with five players",
+		User:   models.User{FirstName: "Ada", LastName: "Lovelace"},
+	}
+
+	// When: Converting to response
+	response := ToGameReviewResponse(review)
+
+	// Then: All fields should be mapped
+	assert.Equal(t, uint(4), response.ID)
+	assert.Equal(t, uint(2), response.GameID)
+	assert.Equal(t, uint(9), response.UserID)
+	assert.Equal(t, "Ada Lovelace", response.UserName)
+	assert.Equal(t, 8, response.Score)
+	assert.Equal(t, "Great with five players", response.Text)
+	assert.Equal(t, now.Format("2006-01-02 15:04:05"), response.CreatedAt)
+}
+
+func TestToGameReviewModel_TrimsText(t *testing.T) {
+	// Given: A review request with padded text
+	req := dtos.GameReviewRequest{Score: 6, Text: "  Fine  "}
+
+	// When: Converting to model
+	review := ToGameReviewModel(req, 2, 9)
+
+	// Then: The ids are set and the text is trimmed
+	assert.Equal(t, uint(2), review.GameID)
+	assert.Equal(t, uint(9), review.UserID)
+	assert.Equal(t, 6, review.Score)
+	assert.Equal(t, "Fine", review.Text)
+}
@@ -0,0 +1,95 @@
+package mappers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+const acquiredDateLayout = "2006-01-02"
+
+// ToGameCopyResponse expects the copy's Loans to hold only its open loan, as
+// loaded by the copy repository.
+func ToGameCopyResponse(gameCopy *models.GameCopy, now time.Time) dtos.GameCopyResponse {
+	response := dtos.GameCopyResponse{
+		ID:        gameCopy.ID,
+		GameID:    gameCopy.GameID,
+		GameName:  gameCopy.Game.Name,
+		Barcode:   gameCopy.Barcode,
+		Condition: string(gameCopy.Condition),
+		Location:  gameCopy.Location,
+		Available: true,
+	}
+	if gameCopy.AcquiredAt != nil {
+		response.AcquiredAt = gameCopy.AcquiredAt.Format(acquiredDateLayout)
+	}
+	for i := range gameCopy.Loans {
+		if gameCopy.Loans[i].IsOpen() {
+			loan := gameCopy.Loans[i]
+			loan.Copy = *gameCopy
+			current := ToLoanResponse(&loan, now)
+			response.CurrentLoan = &current
+			response.Available = false
+		}
+	}
+	return response
+}
+
+func ToGameCopyResponseList(copies []models.GameCopy, now time.Time) []dtos.GameCopyResponse {
+	responses := make([]dtos.GameCopyResponse, len(copies))
+	for i := range copies {
+		responses[i] = ToGameCopyResponse(&copies[i], now)
+	}
+	return responses
+}
+
+// ApplyGameCopyRequest copies the request onto the model. The condition must
+// already be valid and acquiredAt parsed with ParseAcquiredAt.
+func ApplyGameCopyRequest(gameCopy *models.GameCopy, req dtos.GameCopyRequest, acquiredAt *time.Time) {
+	gameCopy.Barcode = strings.TrimSpace(req.Barcode)
+	gameCopy.Location = strings.TrimSpace(req.Location)
+	gameCopy.AcquiredAt = acquiredAt
+	if req.Condition != "" {
+		gameCopy.Condition = models.CopyCondition(req.Condition)
+	} else if gameCopy.Condition == "" {
+		gameCopy.Condition = models.ConditionGood
+	}
+}
+
+// ParseAcquiredAt reads an optional YYYY-MM-DD date.
+func ParseAcquiredAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(acquiredDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func ToLoanResponse(loan *models.Loan, now time.Time) dtos.LoanResponse {
+	return dtos.LoanResponse{
+		ID:           loan.ID,
+		CopyID:       loan.CopyID,
+		Barcode:      loan.Copy.Barcode,
+		GameID:       loan.Copy.GameID,
+		GameName:     loan.Copy.Game.Name,
+		UserID:       loan.UserID,
+		UserName:     strings.TrimSpace(loan.User.FirstName + " " + loan.User.LastName),
+		CheckedOutAt: loan.CheckedOutAt,
+		DueAt:        loan.DueAt,
+		ReturnedAt:   loan.ReturnedAt,
+		Overdue:      loan.IsOverdue(now),
+	}
+}
+
+func ToLoanResponseList(loans []models.Loan, now time.Time) []dtos.LoanResponse {
+	responses := make([]dtos.LoanResponse, len(loans))
+	for i := range loans {
+		responses[i] = ToLoanResponse(&loans[i], now)
+	}
+	return responses
+}
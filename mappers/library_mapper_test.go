@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToGameCopyResponse_WithOpenLoan(t *testing.T) {
+	// Given: A copy that is out on an overdue loan
+	now := time.Now()
+	gameCopy := &models.GameCopy{
+		Model:     gorm.Model{ID: 3},
+		GameID:    1,
+		Barcode:   "AZ-1",
+		Condition: models.ConditionFair,
+		Game:      models.Game{Name: "Azul"},
+		Loans:     []models.Loan{{Model: gorm.Model{ID: 9}, CopyID: 3, UserID: 4, DueAt: now.Add(-time.Hour)}},
+	}
+
+	// When: Converting to response
+	response := ToGameCopyResponse(gameCopy, now)
+
+	// Then: The copy is unavailable and the loan carries the copy details
+	assert.False(t, response.Available)
+	assert.Equal(t, "Fair", response.Condition)
+	assert.Equal(t, uint(9), response.CurrentLoan.ID)
+	assert.Equal(t, "AZ-1", response.CurrentLoan.Barcode)
+	assert.Equal(t, "Azul", response.CurrentLoan.GameName)
+	assert.True(t, response.CurrentLoan.Overdue)
+}
+
+func TestToGameCopyResponse_Available(t *testing.T) {
+	// Given: A copy on the shelf
+	acquired := time.Date(2023, 11, 2, 0, 0, 0, 0, time.UTC)
+	gameCopy := &models.GameCopy{Barcode: "AZ-2", AcquiredAt: &acquired}
+
+	// When: Converting to response
+	response := ToGameCopyResponse(gameCopy, time.Now())
+
+	// Then: It is available and the date is formatted
+	assert.True(t, response.Available)
+	assert.Nil(t, response.CurrentLoan)
+	assert.Equal(t, "2023-11-02", response.AcquiredAt)
+}
+
+func TestApplyGameCopyRequest_DefaultsCondition(t *testing.T) {
+	// Given: A new copy without a condition
+	gameCopy := &models.GameCopy{}
+
+	// When: Applying the request
+	ApplyGameCopyRequest(gameCopy, dtos.GameCopyRequest{Barcode: "AZ-3", Location: " Shelf A "}, nil)
+
+	// Then: The condition defaults to good and the location is trimmed
+	assert.Equal(t, models.ConditionGood, gameCopy.Condition)
+	assert.Equal(t, "Shelf A", gameCopy.Location)
+}
+
+func TestParseAcquiredAt(t *testing.T) {
+	date, err := ParseAcquiredAt("2024-05-01")
+	assert.NoError(t, err)
+	assert.Equal(t, 2024, date.Year())
+
+	date, err = ParseAcquiredAt("")
+	assert.NoError(t, err)
+	assert.Nil(t, date)
+
+	_, err = ParseAcquiredAt("01/05/2024")
+	assert.Error(t, err)
+}
@@ -70,7 +70,6 @@ func TestToNewsModel_BasicRequest(t *testing.T) {
 	req := dtos.CreateNewsRequest{
 		Title:       "New Article",
 		Description: "Article description",
-		AuthorId:    10,
 	}
 	authorID := uint(10)
 
@@ -88,7 +87,6 @@ func TestToNewsModel_AutoGeneratesDate(t *testing.T) {
 	req := dtos.CreateNewsRequest{
 		Title:       "Dated Article",
 		Description: "Test",
-		AuthorId:    1,
 	}
 
 	// When: Converting to model
@@ -105,7 +103,6 @@ func TestToNewsModel_SpecialCharactersInTitle(t *testing.T) {
 	req := dtos.CreateNewsRequest{
 		Title:       "Special: News & Updates (2024)",
 		Description: "Content with <html> tags & symbols",
-		AuthorId:    5,
 	}
 
 	// When: Converting to model
@@ -199,21 +196,6 @@ func TestToNewsResponse_IDIsInt(t *testing.T) {
 	assert.Equal(t, 999, response.ID)
 }
 
-func TestToNewsModel_DifferentAuthorIDs(t *testing.T) {
-	// Given: A request with one author ID and a different parameter
-	req := dtos.CreateNewsRequest{
-		Title:    "Test",
-		AuthorId: 100,
-	}
-	authorIDParam := uint(200)
-
-	// When: Converting to model
-	news := ToNewsModel(req, authorIDParam)
-
-	// Then: The authorID parameter should be used
-	assert.Equal(t, uint(200), news.AuthorID)
-}
-
 func TestToNewsResponseList_UsesAuthorFirstName(t *testing.T) {
 	// Given: A slice with news where author has first and last name
 	newsList := []models.News{
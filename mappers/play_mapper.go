@@ -0,0 +1,121 @@
+package mappers
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+// ToPlayModel builds a play from a validated request. Competitive plays with
+// no flagged winner go to the highest score; cooperative plays give every
+// player the table's result.
+func ToPlayModel(req dtos.PlayRequest, loggedByID uint, playedAt time.Time) models.Play {
+	play := models.Play{
+		GameID:          req.GameID,
+		PlayedAt:        playedAt,
+		DurationMinutes: req.DurationMinutes,
+		Cooperative:     req.Cooperative,
+		Notes:           strings.TrimSpace(req.Notes),
+		LoggedByID:      loggedByID,
+		Players:         make([]models.PlayPlayer, len(req.Players)),
+	}
+
+	flagged := false
+	best := math.MinInt
+	for i, player := range req.Players {
+		play.Players[i] = models.PlayPlayer{UserID: player.UserID, Score: player.Score, Winner: player.Winner}
+		flagged = flagged || player.Winner
+		if player.Score != nil && *player.Score > best {
+			best = *player.Score
+		}
+	}
+
+	for i := range play.Players {
+		switch {
+		case req.Cooperative:
+			play.Players[i].Winner = req.Won != nil && *req.Won
+		case !flagged:
+			play.Players[i].Winner = play.Players[i].Score != nil && *play.Players[i].Score == best
+		}
+	}
+	return play
+}
+
+func ToPlayResponse(play *models.Play) dtos.PlayResponse {
+	response := dtos.PlayResponse{
+		ID:              play.ID,
+		GameID:          play.GameID,
+		GameName:        play.Game.Name,
+		PlayedAt:        play.PlayedAt,
+		DurationMinutes: play.DurationMinutes,
+		Cooperative:     play.Cooperative,
+		Official:        play.Official,
+		Notes:           play.Notes,
+		LoggedByID:      play.LoggedByID,
+		Players:         make([]dtos.PlayPlayerResponse, len(play.Players)),
+	}
+	if play.Cooperative {
+		won := play.Won()
+		response.Won = &won
+	}
+	for i, player := range play.Players {
+		response.Players[i] = dtos.PlayPlayerResponse{
+			UserID:   player.UserID,
+			UserName: strings.TrimSpace(player.User.FirstName + " " + player.User.LastName),
+			Score:    player.Score,
+			Winner:   player.Winner,
+		}
+	}
+	return response
+}
+
+func ToPlayResponseList(plays []models.Play) []dtos.PlayResponse {
+	responses := make([]dtos.PlayResponse, len(plays))
+	for i := range plays {
+		responses[i] = ToPlayResponse(&plays[i])
+	}
+	return responses
+}
+
+func ToMostPlayedGameResponseList(counts []models.GamePlayCount) []dtos.MostPlayedGameResponse {
+	responses := make([]dtos.MostPlayedGameResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = dtos.MostPlayedGameResponse{
+			GameID:   count.GameID,
+			GameName: count.GameName,
+			Plays:    count.Plays,
+			Minutes:  count.Minutes,
+		}
+	}
+	return responses
+}
+
+func ToGameWinRateResponseList(rates []models.GameWinRate) []dtos.GameWinRateResponse {
+	responses := make([]dtos.GameWinRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = dtos.GameWinRateResponse{
+			GameID:   rate.GameID,
+			GameName: rate.GameName,
+			Plays:    rate.Plays,
+			Wins:     rate.Wins,
+			WinRate:  math.Round(rate.Rate()*1000) / 1000,
+		}
+	}
+	return responses
+}
+
+func ToNemesisResponse(userID uint, opponent *models.Opponent) dtos.NemesisResponse {
+	response := dtos.NemesisResponse{UserID: userID}
+	if opponent != nil {
+		response.Nemesis = &dtos.OpponentResponse{
+			UserID:   opponent.UserID,
+			UserName: strings.TrimSpace(opponent.FirstName + " " + opponent.LastName),
+			Plays:    opponent.Plays,
+			Losses:   opponent.Losses,
+		}
+	}
+	return response
+}
@@ -0,0 +1,70 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestToPlayModel_HighestScoreWins(t *testing.T) {
+	// Given: A scored play with a tie for first and no flagged winner
+	req := dtos.PlayRequest{
+		GameID: 1,
+		Notes:  "  Close game ",
+		Players: []dtos.PlayPlayerRequest{
+			{UserID: 1, Score: intPtr(42)},
+			{UserID: 2, Score: intPtr(42)},
+			{UserID: 3, Score: intPtr(30)},
+			{UserID: 4},
+		},
+	}
+
+	// When: Converting to a model
+	play := ToPlayModel(req, 1, time.Now())
+
+	// Then: Both top scorers win
+	assert.Equal(t, "Close game", play.Notes)
+	assert.True(t, play.Players[0].Winner)
+	assert.True(t, play.Players[1].Winner)
+	assert.False(t, play.Players[2].Winner)
+	assert.False(t, play.Players[3].Winner)
+}
+
+func TestToPlayModel_FlaggedWinnersOverrideScores(t *testing.T) {
+	// Given: A play whose winner did not score highest
+	req := dtos.PlayRequest{Players: []dtos.PlayPlayerRequest{
+		{UserID: 1, Score: intPtr(10)},
+		{UserID: 2, Score: intPtr(5), Winner: true},
+	}}
+
+	// When: Converting to a model
+	play := ToPlayModel(req, 1, time.Now())
+
+	// Then: The flag decides
+	assert.False(t, play.Players[0].Winner)
+	assert.True(t, play.Players[1].Winner)
+}
+
+func TestToPlayResponse_Cooperative(t *testing.T) {
+	// Given: A lost cooperative play
+	lost := false
+	play := ToPlayModel(dtos.PlayRequest{
+		Cooperative: true,
+		Won:         &lost,
+		Players:     []dtos.PlayPlayerRequest{{UserID: 1}, {UserID: 2}},
+	}, 1, time.Now())
+
+	// When: Converting to response
+	response := ToPlayResponse(&play)
+
+	// Then: The table's result is reported and nobody won
+	assert.False(t, *response.Won)
+	assert.False(t, response.Players[0].Winner)
+	assert.False(t, response.Players[1].Winner)
+}
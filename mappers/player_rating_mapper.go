@@ -0,0 +1,57 @@
+package mappers
+
+import (
+	"math"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+func ToPlayerRatingResponse(rating *models.PlayerRating) dtos.PlayerRatingResponse {
+	return dtos.PlayerRatingResponse{
+		UserID:     rating.UserID,
+		UserName:   strings.TrimSpace(rating.User.FirstName + " " + rating.User.LastName),
+		GameID:     rating.GameID,
+		GameName:   rating.GameName,
+		Global:     rating.GameID == models.GlobalRatingGameID,
+		Algorithm:  rating.Algorithm,
+		Rating:     roundRating(rating.Rating, 1),
+		Deviation:  roundRating(rating.Deviation, 1),
+		Volatility: roundRating(rating.Volatility, 4),
+		Plays:      rating.Plays,
+	}
+}
+
+func ToPlayerRatingResponseList(ratings []models.PlayerRating) []dtos.PlayerRatingResponse {
+	responses := make([]dtos.PlayerRatingResponse, len(ratings))
+	for i := range ratings {
+		responses[i] = ToPlayerRatingResponse(&ratings[i])
+	}
+	return responses
+}
+
+// ToLeaderboardResponse ranks the ratings in the order given; players with
+// equal ratings share a rank.
+func ToLeaderboardResponse(game *models.Game, algorithm string, ratings []models.PlayerRating) dtos.LeaderboardResponse {
+	response := dtos.LeaderboardResponse{
+		GameID:    game.ID,
+		GameName:  game.Name,
+		Algorithm: algorithm,
+		Entries:   make([]dtos.LeaderboardEntryResponse, len(ratings)),
+	}
+	for i := range ratings {
+		entry := dtos.LeaderboardEntryResponse{Rank: i + 1, PlayerRatingResponse: ToPlayerRatingResponse(&ratings[i])}
+		entry.GameName = ""
+		if i > 0 && ratings[i].Rating == ratings[i-1].Rating {
+			entry.Rank = response.Entries[i-1].Rank
+		}
+		response.Entries[i] = entry
+	}
+	return response
+}
+
+func roundRating(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToLeaderboardResponse_SharesRanks(t *testing.T) {
+	// Given: Three ratings, two of them equal
+	game := &models.Game{Model: gorm.Model{ID: 4}, Name: "Azul"}
+	ratings := []models.PlayerRating{
+		{UserID: 1, GameID: 4, SkillRating: models.SkillRating{Rating: 1532.456}, User: models.User{FirstName: "Anna", LastName: "Lee"}},
+		{UserID: 2, GameID: 4, SkillRating: models.SkillRating{Rating: 1532.456}},
+		{UserID: 3, GameID: 4, SkillRating: models.SkillRating{Rating: 1470}},
+	}
+
+	// When: Converting to a leaderboard
+	response := ToLeaderboardResponse(game, "elo", ratings)
+
+	// Then: The tied players share first place and ratings are rounded
+	assert.Equal(t, "Azul", response.GameName)
+	assert.Equal(t, []int{1, 1, 3}, []int{response.Entries[0].Rank, response.Entries[1].Rank, response.Entries[2].Rank})
+	assert.Equal(t, 1532.5, response.Entries[0].Rating)
+	assert.Equal(t, "Anna Lee", response.Entries[0].UserName)
+	assert.False(t, response.Entries[0].Global)
+}
+
+func TestToPlayerRatingResponse_Global(t *testing.T) {
+	response := ToPlayerRatingResponse(&models.PlayerRating{GameID: models.GlobalRatingGameID, Algorithm: "glicko2"})
+
+	assert.True(t, response.Global)
+	assert.Equal(t, "glicko2", response.Algorithm)
+}
@@ -0,0 +1,18 @@
+package mappers
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+func ToTagResponse(tag *models.Tag) dtos.TagResponse {
+	return dtos.TagResponse{ID: tag.ID, Name: tag.Name}
+}
+
+func ToTagResponseList(tags []models.Tag) []dtos.TagResponse {
+	responses := make([]dtos.TagResponse, len(tags))
+	for i := range tags {
+		responses[i] = ToTagResponse(&tags[i])
+	}
+	return responses
+}
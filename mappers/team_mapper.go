@@ -25,3 +25,11 @@ func ToTeamResponseList(teams []models.Team) []dtos.TeamResponse {
 	}
 	return responses
 }
+
+func ToTeamResponseListFromPointers(teams []*models.Team) []dtos.TeamResponse {
+	responses := make([]dtos.TeamResponse, len(teams))
+	for i, team := range teams {
+		responses[i] = ToTeamResponse(team)
+	}
+	return responses
+}
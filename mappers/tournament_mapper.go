@@ -22,6 +22,7 @@ func ToTournamentResponse(tournament *models.Tournament) dtos.TournamentResponse
 		BonusMultiplier:     bonusMultiplier,
 		StartDate:           tournament.StartDate,
 		Status:              string(tournament.Status),
+		Teams:               ToTeamResponseListFromPointers(tournament.Teams),
 	}
 }
 
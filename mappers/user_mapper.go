@@ -1,6 +1,8 @@
 package mappers
 
 import (
+	"time"
+
 	"github.com/PI-Team04-GameClub/gameclub-backend/dtos"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 )
@@ -11,6 +13,7 @@ func ToUserResponse(user *models.User) dtos.UserResponse {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      string(user.Role),
 	}
 }
 
@@ -45,3 +48,28 @@ func UpdateUserFromRequest(existingUser *models.User, req dtos.UpdateUserRequest
 	}
 	return existingUser
 }
+
+// ToUserExportResponse assembles the personal data archive of a user loaded
+// with their teams and news.
+func ToUserExportResponse(user *models.User, comments []models.Comment, friendRequests []models.FriendRequest, reviews []models.GameReview, loans []models.Loan, exportedAt time.Time) dtos.UserExportResponse {
+	news := make([]dtos.NewsResponse, len(user.News))
+	for i := range user.News {
+		news[i] = ToNewsResponse(&user.News[i], user.FirstName)
+	}
+
+	return dtos.UserExportResponse{
+		ExportedAt: exportedAt.UTC().Format(time.RFC3339),
+		Profile: dtos.UserExportProfile{
+			UserResponse:  ToUserResponse(user),
+			EmailVerified: user.EmailVerified,
+			MFAEnabled:    user.TOTPEnabled,
+			CreatedAt:     user.CreatedAt.Format("2006-01-02 15:04:05"),
+		},
+		Teams:          ToTeamResponseListFromPointers(user.Teams),
+		News:           news,
+		Comments:       ToCommentResponseList(comments),
+		FriendRequests: ToFriendRequestResponseList(friendRequests),
+		Reviews:        ToGameReviewResponseList(reviews),
+		Loans:          ToLoanResponseList(loans, exportedAt),
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const apiKeyAuthScheme = "ApiKey "
+
+// JWTOrAPIKeyMiddleware accepts either a bearer access token or an
+// "Authorization: ApiKey ..." header. Routes that use it should also use
+// RequireScope, because API keys are limited to the scopes they were
+// created with. Plain JWTMiddleware keeps rejecting API keys, so routes
+// have to opt in.
+func JWTOrAPIKeyMiddleware(db *gorm.DB) fiber.Handler {
+	return JWTOrAPIKeyMiddlewareWithRepo(db, repositories.NewDefaultRevokedTokenRepository(db), repositories.NewAPIKeyRepository(db))
+}
+
+func JWTOrAPIKeyMiddlewareWithRepo(db *gorm.DB, revokedTokenRepo repositories.RevokedTokenRepository, apiKeyRepo repositories.APIKeyRepository) fiber.Handler {
+	jwtAuth := JWTMiddlewareWithRepo(db, revokedTokenRepo)
+	apiKeys := security.NewAPIKeyManager(apiKeyRepo)
+
+	return func(c *fiber.Ctx) error {
+		header := c.Get("Authorization")
+		if !strings.HasPrefix(header, apiKeyAuthScheme) {
+			return jwtAuth(c)
+		}
+
+		key, err := apiKeys.Authenticate(c.Context(), strings.TrimSpace(header[len(apiKeyAuthScheme):]))
+		if err != nil {
+			if errors.Is(err, security.ErrInvalidAPIKey) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Invalid API key",
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to verify API key",
+			})
+		}
+
+		user, err := gorm.G[models.User](db).Where("id = ?", key.UserID).First(context.Background())
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		}
+
+		c.Locals("user", &user)
+		c.Locals("userID", user.ID)
+		c.Locals("role", user.Role)
+		c.Locals("apiKey", key)
+		c.Locals("scopes", key.ScopeList())
+
+		return c.Next()
+	}
+}
+
+// RequireScope lets interactive sessions through and requires API keys to
+// carry the given scope.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, ok := c.Locals("apiKey").(*models.APIKey)
+		if !ok || key == nil {
+			return c.Next()
+		}
+
+		if !key.HasScope(scope) {
+			return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupAPIKeyTestApp(t *testing.T) (*fiber.App, *gorm.DB) {
+	db := setupMiddlewareTestDB(t)
+	if err := db.AutoMigrate(&models.APIKey{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	app := fiber.New()
+	app.Post("/scoped", JWTOrAPIKeyMiddleware(db), RequireScope(models.ScopeTournamentsWrite), func(c *fiber.Ctx) error {
+		user := c.Locals("user").(*models.User)
+		scopes, _ := c.Locals("scopes").([]string)
+		return c.JSON(fiber.Map{"id": user.ID, "scopes": scopes})
+	})
+	app.Post("/session-only", JWTMiddleware(db), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app, db
+}
+
+func createAPIKeyTestUser(t *testing.T, db *gorm.DB, scopes ...string) (models.User, string) {
+	user := models.User{FirstName: "Bot", Email: "bot-owner@example.com", Password: "hashedpassword"}
+	db.Create(&user)
+
+	plain, _, err := security.NewAPIKeyManager(repositories.NewAPIKeyRepository(db)).Create(context.Background(), user.ID, "bot", scopes, 0)
+	if err != nil {
+		t.Fatalf("Failed to create API key: %v", err)
+	}
+	return user, plain
+}
+
+func TestJWTOrAPIKeyMiddleware_ValidKey_SetsUserAndScopes(t *testing.T) {
+	// Given: A key with the required scope
+	app, db := setupAPIKeyTestApp(t)
+	user, plain := createAPIKeyTestUser(t, db, models.ScopeTournamentsWrite)
+
+	req := httptest.NewRequest("POST", "/scoped", nil)
+	req.Header.Set("Authorization", "ApiKey "+plain)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The owning user and the scopes should be available to the handler
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		ID     uint     `json:"id"`
+		Scopes []string `json:"scopes"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, user.ID, body.ID)
+	assert.Equal(t, []string{models.ScopeTournamentsWrite}, body.Scopes)
+}
+
+func TestJWTOrAPIKeyMiddleware_InvalidKey(t *testing.T) {
+	// Given: An unknown key
+	app, _ := setupAPIKeyTestApp(t)
+
+	req := httptest.NewRequest("POST", "/scoped", nil)
+	req.Header.Set("Authorization", "ApiKey gck_unknown")
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJWTOrAPIKeyMiddleware_RevokedKey(t *testing.T) {
+	// Given: A key that was revoked
+	app, db := setupAPIKeyTestApp(t)
+	user, plain := createAPIKeyTestUser(t, db, models.ScopeTournamentsWrite)
+	db.Model(&models.APIKey{}).Where("user_id = ?", user.ID).Update("revoked_at", gorm.Expr("CURRENT_TIMESTAMP"))
+
+	req := httptest.NewRequest("POST", "/scoped", nil)
+	req.Header.Set("Authorization", "ApiKey "+plain)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRequireScope_ForbidsKeyWithoutScope(t *testing.T) {
+	// Given: A key that lacks the route's scope
+	app, db := setupAPIKeyTestApp(t)
+	_, plain := createAPIKeyTestUser(t, db, models.ScopeNewsWrite)
+
+	req := httptest.NewRequest("POST", "/scoped", nil)
+	req.Header.Set("Authorization", "ApiKey "+plain)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: Access should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestRequireScope_AllowsInteractiveSession(t *testing.T) {
+	// Given: A regular access token, which carries no scopes
+	app, db := setupAPIKeyTestApp(t)
+	user, _ := createAPIKeyTestUser(t, db, models.ScopeNewsWrite)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+
+	req := httptest.NewRequest("POST", "/scoped", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The session should not be limited by scopes
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestJWTMiddleware_RejectsAPIKey(t *testing.T) {
+	// Given: A valid key on a route that has not opted in to API keys
+	app, db := setupAPIKeyTestApp(t)
+	_, plain := createAPIKeyTestUser(t, db, models.ScopeTournamentsWrite)
+
+	req := httptest.NewRequest("POST", "/session-only", nil)
+	req.Header.Set("Authorization", "ApiKey "+plain)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
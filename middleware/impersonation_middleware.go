@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Impersonator returns the admin behind an impersonated request. The
+// effective user stays in Locals("user"); Locals("realUser") always holds
+// the person who actually signed in.
+func Impersonator(c *fiber.Ctx) (*models.User, bool) {
+	if _, ok := c.Locals("impersonatorID").(uint); !ok {
+		return nil, false
+	}
+	realUser, ok := c.Locals("realUser").(*models.User)
+	return realUser, ok && realUser != nil
+}
+
+// DenyImpersonation guards actions only the account owner may take, such
+// as changing credentials or deleting the account.
+func DenyImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := Impersonator(c); ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Not allowed while impersonating",
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupImpersonationTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/whoami", JWTMiddleware(db), func(c *fiber.Ctx) error {
+		user := c.Locals("user").(*models.User)
+		realUser := c.Locals("realUser").(*models.User)
+		return c.JSON(fiber.Map{"user": user.ID, "real_user": realUser.ID})
+	})
+	app.Delete("/account", JWTMiddleware(db), DenyImpersonation(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	return app
+}
+
+func createImpersonationUsers(db *gorm.DB) (admin, member models.User) {
+	admin = models.User{FirstName: "Admin", Email: "admin@example.com", Password: "hashed", Role: models.RoleAdmin}
+	member = models.User{FirstName: "Member", Email: "member@example.com", Password: "hashed", Role: models.RoleMember}
+	db.Create(&admin)
+	db.Create(&member)
+	return admin, member
+}
+
+func TestJWTMiddleware_Impersonation_ExposesBothUsers(t *testing.T) {
+	// Given: An admin impersonating a member
+	db := setupMiddlewareTestDB(t)
+	app := setupImpersonationTestApp(db)
+	admin, member := createImpersonationUsers(db)
+	token, _, _ := security.GenerateImpersonationToken(&member, admin.ID)
+
+	req := httptest.NewRequest("GET", "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making a request with the impersonation token
+	resp, err := app.Test(req)
+
+	// Then: The member should be the effective user and the admin the real one
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var body map[string]uint
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, member.ID, body["user"])
+	assert.Equal(t, admin.ID, body["real_user"])
+}
+
+func TestJWTMiddleware_RegularToken_RealUserIsSelf(t *testing.T) {
+	// Given: A member with a regular session
+	db := setupMiddlewareTestDB(t)
+	app := setupImpersonationTestApp(db)
+	_, member := createImpersonationUsers(db)
+	token, _ := security.GenerateToken(member.ID, member.Email, member.FirstName, member.LastName, string(member.Role))
+
+	req := httptest.NewRequest("GET", "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making a request
+	resp, _ := app.Test(req)
+
+	// Then: Both users should be the member
+	var body map[string]uint
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, member.ID, body["user"])
+	assert.Equal(t, member.ID, body["real_user"])
+}
+
+func TestJWTMiddleware_Impersonation_RejectedAfterDemotion(t *testing.T) {
+	// Given: An impersonation token whose admin has since been demoted
+	db := setupMiddlewareTestDB(t)
+	app := setupImpersonationTestApp(db)
+	admin, member := createImpersonationUsers(db)
+	token, _, _ := security.GenerateImpersonationToken(&member, admin.ID)
+	db.Model(&admin).Update("role", models.RoleMember)
+
+	req := httptest.NewRequest("GET", "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Using the token
+	resp, err := app.Test(req)
+
+	// Then: It should no longer be accepted
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestDenyImpersonation(t *testing.T) {
+	// Given: An admin impersonating a member, and the member themselves
+	db := setupMiddlewareTestDB(t)
+	app := setupImpersonationTestApp(db)
+	admin, member := createImpersonationUsers(db)
+	impersonation, _, _ := security.GenerateImpersonationToken(&member, admin.ID)
+	own, _ := security.GenerateToken(member.ID, member.Email, member.FirstName, member.LastName, string(member.Role))
+
+	// When: Both try a sensitive action
+	req := httptest.NewRequest("DELETE", "/account", nil)
+	req.Header.Set("Authorization", "Bearer "+impersonation)
+	impersonated, _ := app.Test(req)
+	req = httptest.NewRequest("DELETE", "/account", nil)
+	req.Header.Set("Authorization", "Bearer "+own)
+	owner, _ := app.Test(req)
+
+	// Then: Only the account owner should be allowed
+	assert.Equal(t, fiber.StatusForbidden, impersonated.StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, owner.StatusCode)
+}
@@ -3,14 +3,23 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"log"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
 )
 
+const errTokenRevoked = "Token has been revoked"
+
 func JWTMiddleware(db *gorm.DB) fiber.Handler {
+	return JWTMiddlewareWithRepo(db, repositories.NewDefaultRevokedTokenRepository(db))
+}
+
+func JWTMiddlewareWithRepo(db *gorm.DB, revokedTokenRepo repositories.RevokedTokenRepository) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		tokenString := c.Get("Authorization")
 		if tokenString == "" {
@@ -37,6 +46,20 @@ func JWTMiddleware(db *gorm.DB) fiber.Handler {
 			})
 		}
 
+		if jti, ok := claims["jti"].(string); ok {
+			revoked, err := revokedTokenRepo.IsRevoked(c.Context(), jti)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Failed to verify token",
+				})
+			}
+			if revoked {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": errTokenRevoked,
+				})
+			}
+		}
+
 		user, err := gorm.G[models.User](db).Where("id = ?", uint(userID)).First(context.Background())
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -44,13 +67,63 @@ func JWTMiddleware(db *gorm.DB) fiber.Handler {
 			})
 		}
 
+		if issuedBeforeSessionRevocation(claims, &user) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": errTokenRevoked,
+			})
+		}
+
+		realUser := &user
+		if impersonatorID, ok := security.ImpersonatorID(claims); ok {
+			if realUser, ok = loadImpersonator(db, impersonatorID, claims); !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Impersonation is no longer allowed",
+				})
+			}
+			c.Locals("impersonatorID", realUser.ID)
+			log.Printf("Impersonation: user %d acting as user %d: %s %s", realUser.ID, user.ID, c.Method(), c.Path())
+		}
+
 		c.Locals("user", &user)
+		c.Locals("realUser", realUser)
 		c.Locals("userID", uint(userID))
+		c.Locals("role", user.Role)
+		c.Locals("claims", claims)
 
 		return c.Next()
 	}
 }
 
+// loadImpersonator checks that the admin named in the "act" claim still
+// exists, is still an admin and has not revoked their own sessions since.
+func loadImpersonator(db *gorm.DB, impersonatorID uint, claims jwt.MapClaims) (*models.User, bool) {
+	if impersonatorID == 0 {
+		return nil, false
+	}
+
+	impersonator, err := gorm.G[models.User](db).Where("id = ?", impersonatorID).First(context.Background())
+	if err != nil || impersonator.Role != models.RoleAdmin || issuedBeforeSessionRevocation(claims, &impersonator) {
+		return nil, false
+	}
+	return &impersonator, true
+}
+
+// issuedBeforeSessionRevocation also refuses tokens from the second of the
+// revocation: iat has no finer precision, so they may predate it. Sessions
+// started after a revocation are dated past that second.
+func issuedBeforeSessionRevocation(claims jwt.MapClaims, user *models.User) bool {
+	if user.SessionsRevokedAt == nil {
+		return false
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return true
+	}
+
+	return issuedAt.Unix() <= user.SessionsRevokedAt.Unix()
+}
+
 func UnauthorizedHandler(c *fiber.Ctx, err error) error {
 	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 		"error": fmt.Sprintf("Unauthorized: %v", err),
@@ -2,13 +2,17 @@ package middleware
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/security"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -19,7 +23,7 @@ func setupMiddlewareTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to open test database: %v", err)
 	}
 
-	err = db.AutoMigrate(&models.User{})
+	err = db.AutoMigrate(&models.User{}, &models.RevokedToken{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -85,7 +89,7 @@ func TestJWTMiddleware_ValidToken_UserNotFound(t *testing.T) {
 	db := setupMiddlewareTestDB(t)
 	app := setupMiddlewareTestApp(db)
 
-	token, _ := security.GenerateToken(999, "nonexistent@test.com", "Non", "Existent")
+	token, _ := security.GenerateToken(999, "nonexistent@test.com", "Non", "Existent", "Member")
 
 	req := httptest.NewRequest("GET", "/protected", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -115,7 +119,7 @@ func TestJWTMiddleware_ValidToken_Success(t *testing.T) {
 	}
 	db.Create(&user)
 
-	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
 
 	req := httptest.NewRequest("GET", "/protected", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -141,7 +145,7 @@ func TestJWTMiddleware_ValidToken_SetsUserInContext(t *testing.T) {
 	}
 	db.Create(&user)
 
-	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
 
 	req := httptest.NewRequest("GET", "/protected", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -171,7 +175,7 @@ func TestJWTMiddleware_TokenWithoutBearerPrefix(t *testing.T) {
 	}
 	db.Create(&user)
 
-	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
 
 	req := httptest.NewRequest("GET", "/protected", nil)
 	req.Header.Set("Authorization", token)
@@ -273,7 +277,7 @@ func TestJWTMiddleware_SetsUserIDInContext(t *testing.T) {
 	}
 	db.Create(&user)
 
-	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
 
 	req := httptest.NewRequest("GET", "/protected", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -289,3 +293,115 @@ func TestJWTMiddleware_SetsUserIDInContext(t *testing.T) {
 	json.NewDecoder(resp.Body).Decode(&response)
 	assert.Equal(t, float64(user.ID), response["userID"])
 }
+
+func TestJWTMiddleware_RevokedTokenID(t *testing.T) {
+	// Given: A valid token whose jti has been revoked
+	db := setupMiddlewareTestDB(t)
+	app := setupMiddlewareTestApp(db)
+
+	user := models.User{
+		FirstName: "Revoked",
+		LastName:  "User",
+		Email:     "revoked@example.com",
+		Password:  "hashedpassword",
+	}
+	db.Create(&user)
+
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+	claims, _ := security.ExtractClaims(token)
+	db.Create(&models.RevokedToken{JTI: claims["jti"].(string), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	var response map[string]string
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, "Token has been revoked", response["error"])
+}
+
+func TestJWTMiddleware_TokenIssuedBeforeSessionsRevoked(t *testing.T) {
+	// Given: A token issued before the user logged out everywhere
+	db := setupMiddlewareTestDB(t)
+	app := setupMiddlewareTestApp(db)
+
+	revokedAt := time.Now().Add(time.Minute)
+	user := models.User{
+		FirstName:         "Logged",
+		LastName:          "Out",
+		Email:             "loggedout@example.com",
+		Password:          "hashedpassword",
+		SessionsRevokedAt: &revokedAt,
+	}
+	db.Create(&user)
+
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJWTMiddleware_TokenIssuedInSameSecondAsRevocation(t *testing.T) {
+	// Given: A token issued just before the user logged out everywhere,
+	// within the same second
+	db := setupMiddlewareTestDB(t)
+	app := setupMiddlewareTestApp(db)
+
+	user := models.User{
+		FirstName: "Logged",
+		LastName:  "Out",
+		Email:     "samesecond@example.com",
+		Password:  "hashedpassword",
+	}
+	db.Create(&user)
+	token, _ := security.GenerateToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role))
+	revokedAt := time.Unix(time.Now().Unix(), 999_000_000)
+	db.Model(&user).Update("sessions_revoked_at", revokedAt)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The request should be rejected
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJWTMiddleware_DenylistError(t *testing.T) {
+	// Given: A denylist that cannot be reached
+	db := setupMiddlewareTestDB(t)
+	revokedRepo := new(mocks.MockRevokedTokenRepository)
+	revokedRepo.On("IsRevoked", mock.Anything, mock.Anything).Return(false, errors.New("database down"))
+
+	app := fiber.New()
+	app.Get("/protected", JWTMiddlewareWithRepo(db, revokedRepo), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	token, _ := security.GenerateToken(1, "down@example.com", "Down", "User", "Member")
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// When: Making the request
+	resp, err := app.Test(req)
+
+	// Then: The middleware should fail closed
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func RequireRole(roles ...models.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*models.User)
+		if !ok || user == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.RequiresAuthentication())
+		}
+
+		if !user.Role.HasAnyOf(roles...) {
+			return c.Status(fiber.StatusForbidden).JSON(utils.AccessForbidden())
+		}
+
+		return c.Next()
+	}
+}
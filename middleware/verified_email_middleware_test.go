@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupVerifiedEmailTestApp(user *models.User) *fiber.App {
+	app := fiber.New()
+
+	app.Post("/restricted", func(c *fiber.Ctx) error {
+		if user != nil {
+			c.Locals("user", user)
+		}
+		return c.Next()
+	}, RequireVerifiedEmail(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	return app
+}
+
+func TestRequireVerifiedEmail_AllowsVerifiedUser(t *testing.T) {
+	// Given: A user with a verified email address
+	app := setupVerifiedEmailTestApp(&models.User{EmailVerified: true})
+
+	// When: Making the request
+	resp, err := app.Test(httptest.NewRequest("POST", "/restricted", nil))
+
+	// Then: The request should pass through
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequireVerifiedEmail_ForbidsUnverifiedUser(t *testing.T) {
+	// Given: A user who has not verified their email address
+	app := setupVerifiedEmailTestApp(&models.User{})
+
+	// When: Making the request
+	resp, err := app.Test(httptest.NewRequest("POST", "/restricted", nil))
+
+	// Then: The request should be forbidden
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestRequireVerifiedEmail_MissingUser(t *testing.T) {
+	// Given: A request without an authenticated user
+	app := setupVerifiedEmailTestApp(nil)
+
+	// When: Making the request
+	resp, err := app.Test(httptest.NewRequest("POST", "/restricted", nil))
+
+	// Then: The request should be rejected as unauthorized
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
@@ -23,6 +23,30 @@ func (m *MockGameRepository) FindAll(ctx context.Context) ([]models.Game, error)
 	return args.Get(0).([]models.Game), args.Error(1)
 }
 
+func (m *MockGameRepository) FindPage(ctx context.Context, query models.GameQuery) ([]models.Game, int64, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.Game), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGameRepository) FindPlayable(ctx context.Context, players, youngestAge int) ([]models.Game, error) {
+	args := m.Called(ctx, players, youngestAge)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) Search(ctx context.Context, query string, limit int) ([]models.Game, string, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).([]models.Game), args.String(1), args.Error(2)
+}
+
 func (m *MockGameRepository) FindByID(ctx context.Context, id string) (*models.Game, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -31,6 +55,14 @@ func (m *MockGameRepository) FindByID(ctx context.Context, id string) (*models.G
 	return args.Get(0).(*models.Game), args.Error(1)
 }
 
+func (m *MockGameRepository) FindByNames(ctx context.Context, names []string) ([]models.Game, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Game), args.Error(1)
+}
+
 func (m *MockGameRepository) Create(ctx context.Context, game *models.Game) error {
 	return m.mockMethodError("Create", ctx, game)
 }
@@ -43,3 +75,19 @@ func (m *MockGameRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockGameRepository) Upsert(ctx context.Context, games []*models.Game) error {
+	return m.mockMethodError("Upsert", ctx, games)
+}
+
+func (m *MockGameRepository) UpsertEach(ctx context.Context, games []*models.Game) []error {
+	args := m.Called(ctx, games)
+	if args.Get(0) == nil {
+		return make([]error, len(games))
+	}
+	return args.Get(0).([]error)
+}
+
+func (m *MockGameRepository) SetTags(ctx context.Context, gameID uint, tags []models.Tag) error {
+	return m.mockMethodError("SetTags", ctx, gameID, tags)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGameReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockGameReviewRepository) mockMethodError(methodName string, args ...interface{}) error {
+	return m.MethodCalled(methodName, args...).Error(0)
+}
+
+func (m *MockGameReviewRepository) Create(ctx context.Context, review *models.GameReview) error {
+	return m.mockMethodError("Create", ctx, review)
+}
+
+func (m *MockGameReviewRepository) Update(ctx context.Context, review *models.GameReview) error {
+	return m.mockMethodError("Update", ctx, review)
+}
+
+func (m *MockGameReviewRepository) Delete(ctx context.Context, review *models.GameReview) error {
+	return m.mockMethodError("Delete", ctx, review)
+}
+
+func (m *MockGameReviewRepository) FindByID(ctx context.Context, id uint) (*models.GameReview, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameReview), args.Error(1)
+}
+
+func (m *MockGameReviewRepository) FindByGameID(ctx context.Context, gameID uint) ([]models.GameReview, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GameReview), args.Error(1)
+}
+
+func (m *MockGameReviewRepository) FindByUserID(ctx context.Context, userID uint) ([]models.GameReview, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GameReview), args.Error(1)
+}
+
+func (m *MockGameReviewRepository) FindByGameAndUser(ctx context.Context, gameID, userID uint) (*models.GameReview, error) {
+	args := m.Called(ctx, gameID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameReview), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+func (m *MockPasswordResetTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPasswordResetTokenRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	return m.Called(ctx, userID).Error(0)
+}
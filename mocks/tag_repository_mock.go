@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) mockMethodError(methodName string, args ...interface{}) error {
+	return m.MethodCalled(methodName, args...).Error(0)
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByName(ctx context.Context, name string) (*models.Tag, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	return m.mockMethodError("Create", ctx, tag)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	return m.mockMethodError("Update", ctx, tag)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id uint) error {
+	return m.mockMethodError("Delete", ctx, id)
+}
@@ -43,3 +43,11 @@ func (m *MockTournamentRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockTournamentRepository) AddTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error {
+	return m.mockMethodError("AddTeam", ctx, tournament, team)
+}
+
+func (m *MockTournamentRepository) RemoveTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error {
+	return m.mockMethodError("RemoveTeam", ctx, tournament, team)
+}
@@ -46,3 +46,19 @@ func (m *MockUserRepository) FindAll(ctx context.Context) ([]models.User, error)
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	return m.mockMethodError("Update", ctx, user)
 }
+
+func (m *MockUserRepository) UpdateFields(ctx context.Context, id uint, fields map[string]interface{}) error {
+	return m.mockMethodError("UpdateFields", ctx, id, fields)
+}
+
+func (m *MockUserRepository) FindForExport(ctx context.Context, id uint) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
+	return m.mockMethodError("Delete", ctx, id)
+}
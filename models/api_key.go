@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API key scopes. A key authenticates as its owner, so the owner's role is
+// still checked; the scope only narrows what the key may do on top of that.
+const (
+	ScopeTournamentsWrite = "tournaments:write"
+	ScopeGamesWrite       = "games:write"
+	ScopeTeamsWrite       = "teams:write"
+	ScopeNewsWrite        = "news:write"
+	ScopeResultsWrite     = "results:write"
+)
+
+var APIKeyScopes = []string{
+	ScopeTournamentsWrite,
+	ScopeGamesWrite,
+	ScopeTeamsWrite,
+	ScopeNewsWrite,
+	ScopeResultsWrite,
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type APIKey struct {
+	gorm.Model
+	UserID     uint   `gorm:"not null;index"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	Prefix     string `gorm:"type:varchar(16);not null"`
+	KeyHash    string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scopes     string `gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrAuditEventImmutable = errors.New("audit events cannot be changed or deleted")
+
+// AuditEvent is an append-only record of an authentication event or a
+// privileged change. Changes and Metadata hold JSON documents.
+type AuditEvent struct {
+	ID           uint      `gorm:"primarykey"`
+	CreatedAt    time.Time `gorm:"not null;index"`
+	ActorID      *uint     `gorm:"index"`
+	ActorEmail   string    `gorm:"type:varchar(255)"`
+	Action       string    `gorm:"type:varchar(64);not null;index"`
+	ResourceType string    `gorm:"type:varchar(32);index:idx_audit_events_resource"`
+	ResourceID   string    `gorm:"type:varchar(64);index:idx_audit_events_resource"`
+	IP           string    `gorm:"type:varchar(64)"`
+	UserAgent    string    `gorm:"type:varchar(512)"`
+	Changes      string    `gorm:"type:text"`
+	Metadata     string    `gorm:"type:text"`
+}
+
+func (e *AuditEvent) BeforeUpdate(tx *gorm.DB) error {
+	return ErrAuditEventImmutable
+}
+
+func (e *AuditEvent) BeforeDelete(tx *gorm.DB) error {
+	return ErrAuditEventImmutable
+}
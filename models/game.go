@@ -19,6 +19,22 @@ const (
 	CategoryCooperative GameCategory = "Cooperative"
 )
 
+func IsValidComplexity(complexity GameComplexity) bool {
+	switch complexity {
+	case ComplexityEasy, ComplexityMedium, ComplexityHard, ComplexityExpert:
+		return true
+	}
+	return false
+}
+
+func IsValidCategory(category GameCategory) bool {
+	switch category {
+	case CategoryStrategy, CategoryParty, CategoryFamily, CategoryCard, CategoryDice, CategoryCooperative:
+		return true
+	}
+	return false
+}
+
 type Game struct {
 	gorm.Model
 	Name            string         `gorm:"not null;unique"`
@@ -32,9 +48,19 @@ type Game struct {
 	Category        GameCategory   `gorm:"type:varchar(30);default:'Strategy'"`
 	Publisher       string         `gorm:"type:varchar(100)"`
 	YearPublished   int            `gorm:"default:2024"`
-	Rating          float64        `gorm:"type:decimal(3,2);default:0.0"`
+	Rating          float64        `gorm:"type:decimal(4,2);default:0.0"`
+	RatingCount     int            `gorm:"not null;default:0"`
+	// BaseGameID is set on expansions. Expansions cannot have expansions of
+	// their own.
+	BaseGameID *uint `gorm:"index"`
 
 	Tournaments []Tournament `gorm:"foreignKey:GameID"`
+	Expansions  []Game       `gorm:"foreignKey:BaseGameID"`
+	Tags        []Tag        `gorm:"many2many:game_tags;"`
+}
+
+func (g *Game) IsExpansion() bool {
+	return g.BaseGameID != nil
 }
 
 type GameBuilder struct {
@@ -123,6 +149,16 @@ func (b *GameBuilder) SetRating(rating float64) *GameBuilder {
 	return b
 }
 
+func (b *GameBuilder) SetRatingCount(count int) *GameBuilder {
+	b.game.RatingCount = count
+	return b
+}
+
+func (b *GameBuilder) SetBaseGameID(id *uint) *GameBuilder {
+	b.game.BaseGameID = id
+	return b
+}
+
 func (b *GameBuilder) SetID(id uint) *GameBuilder {
 	b.game.ID = id
 	return b
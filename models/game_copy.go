@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CopyCondition string
+
+const (
+	ConditionNew     CopyCondition = "New"
+	ConditionGood    CopyCondition = "Good"
+	ConditionFair    CopyCondition = "Fair"
+	ConditionWorn    CopyCondition = "Worn"
+	ConditionDamaged CopyCondition = "Damaged"
+)
+
+func IsValidCopyCondition(condition CopyCondition) bool {
+	switch condition {
+	case ConditionNew, ConditionGood, ConditionFair, ConditionWorn, ConditionDamaged:
+		return true
+	}
+	return false
+}
+
+// GameCopy is one physical box of a game owned by the club. Barcodes are
+// unique among copies still in the library, so a retired copy's label can
+// be reused.
+type GameCopy struct {
+	gorm.Model
+	GameID     uint          `gorm:"not null;index"`
+	Barcode    string        `gorm:"type:varchar(64);not null;uniqueIndex:idx_game_copies_barcode,where:deleted_at IS NULL"`
+	Condition  CopyCondition `gorm:"type:varchar(20);not null;default:'Good'"`
+	Location   string        `gorm:"type:varchar(100)"`
+	AcquiredAt *time.Time
+
+	Game  Game   `gorm:"foreignKey:GameID"`
+	Loans []Loan `gorm:"foreignKey:CopyID"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GameSortColumns maps the sort keys accepted by GET /games, which are the
+// JSON field names of a game, to their columns.
+var GameSortColumns = map[string]string{
+	"id":              "id",
+	"name":            "name",
+	"numberOfPlayers": "number_of_players",
+	"minPlayers":      "min_players",
+	"maxPlayers":      "max_players",
+	"playtimeMinutes": "playtime_minutes",
+	"minAge":          "min_age",
+	"complexity":      "complexity",
+	"category":        "category",
+	"publisher":       "publisher",
+	"yearPublished":   "year_published",
+	"rating":          "rating",
+	"ratingCount":     "rating_count",
+	"createdAt":       "created_at",
+}
+
+type GameSort struct {
+	Field string
+	Desc  bool
+}
+
+// GameQuery filters, sorts and pages the game list. Zero values mean "no
+// filter"; Page and Limit must be set.
+type GameQuery struct {
+	Category    string
+	Complexity  string
+	Players     int
+	MaxPlaytime int
+	MinAge      int
+	Publisher   string
+	MinYear     int
+	MaxYear     int
+	MinRating   float64
+	MaxRating   float64
+	// Tags holds lowercase tag names; a game must carry all of them.
+	Tags  []string
+	Sort  []GameSort
+	Page  int
+	Limit int
+}
+
+// CacheKey renders the query canonically so equal queries share a cache
+// entry regardless of the order of the request parameters.
+func (q GameQuery) CacheKey() string {
+	values := url.Values{}
+	setString := func(name, value string) {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			values.Set(name, strconv.Itoa(value))
+		}
+	}
+	setFloat := func(name string, value float64) {
+		if value != 0 {
+			values.Set(name, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+
+	setString("category", q.Category)
+	setString("complexity", q.Complexity)
+	setInt("players", q.Players)
+	setInt("maxPlaytime", q.MaxPlaytime)
+	setInt("minAge", q.MinAge)
+	setString("publisher", strings.ToLower(q.Publisher))
+	setInt("minYear", q.MinYear)
+	setInt("maxYear", q.MaxYear)
+	setFloat("minRating", q.MinRating)
+	setFloat("maxRating", q.MaxRating)
+	tags := append([]string(nil), q.Tags...)
+	sort.Strings(tags)
+	setString("tags", strings.Join(tags, ","))
+	setInt("page", q.Page)
+	setInt("limit", q.Limit)
+
+	sorts := make([]string, len(q.Sort))
+	for i, sort := range q.Sort {
+		sorts[i] = sort.Field
+		if sort.Desc {
+			sorts[i] = "-" + sort.Field
+		}
+	}
+	setString("sort", strings.Join(sorts, ","))
+
+	// Encode sorts by parameter name, which makes the result canonical.
+	return values.Encode()
+}
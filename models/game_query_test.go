@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameQuery_CacheKey_IsCanonical(t *testing.T) {
+	// Given: The same query written with different publisher casing
+	first := GameQuery{Publisher: "Kosmos", Players: 4, Sort: []GameSort{{Field: "rating", Desc: true}}, Page: 1, Limit: 20}
+	second := GameQuery{Players: 4, Publisher: "KOSMOS", Sort: []GameSort{{Field: "rating", Desc: true}}, Page: 1, Limit: 20}
+
+	// When: Rendering their cache keys
+	// Then: They should share one key that includes every part of the query
+	assert.Equal(t, first.CacheKey(), second.CacheKey())
+	assert.Equal(t, "limit=20&page=1&players=4&publisher=kosmos&sort=-rating", first.CacheKey())
+}
+
+func TestGameQuery_CacheKey_DistinguishesPages(t *testing.T) {
+	// Given: Two pages of the same list
+	first := GameQuery{Page: 1, Limit: 20}
+	second := GameQuery{Page: 2, Limit: 20}
+
+	// Then: They should be cached separately
+	assert.NotEqual(t, first.CacheKey(), second.CacheKey())
+}
+
+func TestGameQuery_CacheKey_IgnoresTagOrder(t *testing.T) {
+	// Given: The same tag filter listed in two orders
+	first := GameQuery{Tags: []string{"cooperative", "card"}, Page: 1, Limit: 20}
+	second := GameQuery{Tags: []string{"card", "cooperative"}, Page: 1, Limit: 20}
+
+	// Then: They should share one key
+	assert.Equal(t, first.CacheKey(), second.CacheKey())
+	assert.Equal(t, []string{"cooperative", "card"}, first.Tags)
+}
@@ -0,0 +1,25 @@
+package models
+
+import "gorm.io/gorm"
+
+const (
+	MinReviewScore = 1
+	MaxReviewScore = 10
+)
+
+// GameReview is one member's score for a game. A member can review each game
+// once; the game's Rating is the average of its reviews.
+type GameReview struct {
+	gorm.Model
+	GameID uint   `gorm:"not null;uniqueIndex:idx_game_reviews_game_user"`
+	UserID uint   `gorm:"not null;uniqueIndex:idx_game_reviews_game_user"`
+	Score  int    `gorm:"not null"`
+	Text   string `gorm:"type:text"`
+
+	Game Game `gorm:"foreignKey:GameID"`
+	User User `gorm:"foreignKey:UserID"`
+}
+
+func IsValidReviewScore(score int) bool {
+	return score >= MinReviewScore && score <= MaxReviewScore
+}
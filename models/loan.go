@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Loan is a copy checked out to a member. It stays open until ReturnedAt is
+// set; the partial unique index allows one open loan per copy.
+type Loan struct {
+	gorm.Model
+	CopyID       uint      `gorm:"not null;index;uniqueIndex:idx_loans_open_copy,where:returned_at IS NULL"`
+	UserID       uint      `gorm:"not null;index"`
+	CheckedOutAt time.Time `gorm:"not null"`
+	DueAt        time.Time `gorm:"not null;index"`
+	ReturnedAt   *time.Time
+
+	Copy GameCopy `gorm:"foreignKey:CopyID"`
+	User User     `gorm:"foreignKey:UserID"`
+}
+
+func (l *Loan) IsOpen() bool {
+	return l.ReturnedAt == nil
+}
+
+func (l *Loan) IsOverdue(now time.Time) bool {
+	return l.IsOpen() && now.After(l.DueAt)
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoan_IsOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	returned := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		loan Loan
+		want bool
+	}{
+		{name: "open and past due", loan: Loan{DueAt: now.Add(-time.Minute)}, want: true},
+		{name: "open and not yet due", loan: Loan{DueAt: now.Add(time.Minute)}, want: false},
+		{name: "returned late", loan: Loan{DueAt: now.Add(-48 * time.Hour), ReturnedAt: &returned}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When / Then: Only open loans past their due date are overdue
+			assert.Equal(t, tt.want, tt.loan.IsOverdue(now))
+		})
+	}
+}
+
+func TestIsValidCopyCondition(t *testing.T) {
+	assert.True(t, IsValidCopyCondition(ConditionWorn))
+	assert.False(t, IsValidCopyCondition("Mint"))
+	assert.False(t, IsValidCopyCondition(""))
+}
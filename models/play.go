@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxPlayPlayers bounds how many participants one play may list.
+const MaxPlayPlayers = 20
+
+// Play is one session of a game. In a cooperative play the whole table wins
+// or loses together, so every participant carries the same Winner flag.
+// Official plays were logged by an organizer; only they move ratings.
+type Play struct {
+	gorm.Model
+	GameID          uint      `gorm:"not null;index"`
+	PlayedAt        time.Time `gorm:"not null;index"`
+	DurationMinutes int
+	Cooperative     bool   `gorm:"not null;default:false"`
+	Official        bool   `gorm:"not null;default:false"`
+	Notes           string `gorm:"type:text"`
+	LoggedByID      uint   `gorm:"not null;index"`
+
+	Game     Game         `gorm:"foreignKey:GameID"`
+	LoggedBy User         `gorm:"foreignKey:LoggedByID"`
+	Players  []PlayPlayer `gorm:"foreignKey:PlayID"`
+}
+
+// PlayPlayer is a participant of a play. Score is nil when the game was not
+// scored.
+type PlayPlayer struct {
+	ID     uint `gorm:"primaryKey"`
+	PlayID uint `gorm:"not null;uniqueIndex:idx_play_players_play_user"`
+	UserID uint `gorm:"not null;index;uniqueIndex:idx_play_players_play_user"`
+	Score  *int
+	Winner bool `gorm:"not null;default:false"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// Won reports whether the table won a cooperative play.
+func (p *Play) Won() bool {
+	return p.Cooperative && len(p.Players) > 0 && p.Players[0].Winner
+}
+
+// HasPlayer reports whether the user took part in the play.
+func (p *Play) HasPlayer(userID uint) bool {
+	for _, player := range p.Players {
+		if player.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// GamePlayCount is how often a game was played in a period.
+type GamePlayCount struct {
+	GameID   uint
+	GameName string
+	Plays    int
+	Minutes  int
+}
+
+// GameWinRate is how often a user won a game they played.
+type GameWinRate struct {
+	GameID   uint
+	GameName string
+	Plays    int
+	Wins     int
+}
+
+func (r GameWinRate) Rate() float64 {
+	if r.Plays == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Plays)
+}
+
+// Opponent counts a user's competitive plays against another member and how
+// many of them the other member won while the user lost.
+type Opponent struct {
+	UserID    uint
+	FirstName string
+	LastName  string
+	Plays     int
+	Losses    int
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// GlobalRatingGameID is the game ID under which a player's rating across all
+// games is kept.
+const GlobalRatingGameID = 0
+
+// SkillRating is a skill estimate. Deviation and Volatility are only used by
+// algorithms that track uncertainty, such as Glicko-2.
+type SkillRating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// Standing is a player's rating going into a play and where they finished:
+// rank 1 is first, and equal ranks are a draw.
+type Standing struct {
+	SkillRating
+	Rank int
+}
+
+// Outcome scores the standing against an opponent: 1 for finishing ahead,
+// 0.5 for a draw and 0 for finishing behind.
+func (s Standing) Outcome(opponent Standing) float64 {
+	switch {
+	case s.Rank < opponent.Rank:
+		return 1
+	case s.Rank == opponent.Rank:
+		return 0.5
+	}
+	return 0
+}
+
+// PlayerRating is a player's current rating at one game, or across all games
+// when GameID is GlobalRatingGameID. Algorithm names the rating algorithm
+// that produced it.
+type PlayerRating struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_player_ratings_user_game"`
+	GameID    uint   `gorm:"not null;index;uniqueIndex:idx_player_ratings_user_game"`
+	Algorithm string `gorm:"type:varchar(20);not null"`
+	SkillRating
+	Plays int `gorm:"not null;default:0"`
+
+	GameName string `gorm:"->;-:migration"`
+	User     User   `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RecoveryCode struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	CodeHash string `gorm:"type:varchar(64);not null;index"`
+	UsedAt   *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
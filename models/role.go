@@ -0,0 +1,33 @@
+package models
+
+type UserRole string
+
+const (
+	RoleAdmin     UserRole = "Admin"
+	RoleOrganizer UserRole = "Organizer"
+	RoleMember    UserRole = "Member"
+)
+
+func IsValidRole(role UserRole) bool {
+	switch role {
+	case RoleAdmin, RoleOrganizer, RoleMember:
+		return true
+	}
+	return false
+}
+
+func (r UserRole) HasAnyOf(roles ...UserRole) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, role := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (r UserRole) CanModerate() bool {
+	return r == RoleAdmin
+}
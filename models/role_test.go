@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidRole(t *testing.T) {
+	// Given: Known and unknown roles
+
+	// When: Validating them
+
+	// Then: Only the known roles should be valid
+	assert.True(t, IsValidRole(RoleAdmin))
+	assert.True(t, IsValidRole(RoleOrganizer))
+	assert.True(t, IsValidRole(RoleMember))
+	assert.False(t, IsValidRole(UserRole("Guest")))
+	assert.False(t, IsValidRole(UserRole("")))
+}
+
+func TestUserRole_HasAnyOf(t *testing.T) {
+	// Given: Users with different roles
+
+	// When: Checking them against an organizer policy
+
+	// Then: Organizers and admins should match, members should not
+	assert.True(t, RoleOrganizer.HasAnyOf(RoleOrganizer))
+	assert.True(t, RoleAdmin.HasAnyOf(RoleOrganizer))
+	assert.False(t, RoleMember.HasAnyOf(RoleOrganizer))
+	assert.True(t, RoleMember.HasAnyOf(RoleOrganizer, RoleMember))
+}
+
+func TestUserRole_CanModerate(t *testing.T) {
+	// Given: Users with different roles
+
+	// When: Checking moderation rights
+
+	// Then: Only admins should be able to moderate
+	assert.True(t, RoleAdmin.CanModerate())
+	assert.False(t, RoleOrganizer.CanModerate())
+	assert.False(t, RoleMember.CanModerate())
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// MaxTagNameLength bounds tag names so they fit on a game card.
+const MaxTagNameLength = 50
+
+// Tag is a label admins define and organizers attach to games. Unlike the
+// single Category, a game can carry any number of tags. Tags are deleted
+// outright, together with their links to games.
+type Tag struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string `gorm:"type:varchar(50);not null;uniqueIndex"`
+
+	Games []*Game `gorm:"many2many:game_tags;"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -8,8 +10,18 @@ type User struct {
 	gorm.Model
 	FirstName string `gorm:"not null"`
 	LastName  string
-	Email     string `gorm:"unique"`
-	Password  string `gorm:"not null"`
+	Email     string   `gorm:"unique"`
+	Password  string   `gorm:"not null"`
+	Role      UserRole `gorm:"type:varchar(20);not null;default:'Member'"`
+
+	EmailVerified   bool `gorm:"not null;default:false"`
+	EmailVerifiedAt *time.Time
+
+	TOTPSecret   string `gorm:"type:varchar(64)"`
+	TOTPEnabled  bool   `gorm:"not null;default:false"`
+	TOTPLastStep int64
+
+	SessionsRevokedAt *time.Time
 
 	Teams    []*Team   `gorm:"many2many:user_teams;"`
 	News     []News    `gorm:"foreignKey:AuthorID"`
@@ -0,0 +1,15 @@
+package models
+
+import "gorm.io/gorm"
+
+// UserIdentity links a user to an account at an external OpenID Connect
+// provider, identified by the provider's stable subject claim.
+type UserIdentity struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	Provider string `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email    string
+
+	User User `gorm:"foreignKey:UserID"`
+}
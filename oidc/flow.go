@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const purposeOIDCFlow = "oidc_flow"
+
+var FlowTTL = 10 * time.Minute
+
+var ErrInvalidFlowState = errors.New("invalid or expired login state")
+
+// FlowState is what the callback needs to finish an authorization code
+// flow. It travels in a signed cookie so no server-side storage is needed.
+type FlowState struct {
+	Provider     string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// NewFlowState creates fresh state, nonce and PKCE values and returns the
+// S256 challenge to send with the authorization request.
+func NewFlowState(provider string) (*FlowState, string, error) {
+	state, err := randomString(24)
+	if err != nil {
+		return nil, "", err
+	}
+	nonce, err := randomString(24)
+	if err != nil {
+		return nil, "", err
+	}
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		return nil, "", err
+	}
+	return &FlowState{Provider: provider, State: state, Nonce: nonce, CodeVerifier: verifier}, challenge, nil
+}
+
+func (f *FlowState) Encode() (string, error) {
+	now := time.Now()
+	return security.DefaultKeyRing.Sign(jwt.MapClaims{
+		"purpose":       purposeOIDCFlow,
+		"provider":      f.Provider,
+		"state":         f.State,
+		"nonce":         f.Nonce,
+		"code_verifier": f.CodeVerifier,
+		"iat":           now.Unix(),
+		"exp":           now.Add(FlowTTL).Unix(),
+	})
+}
+
+// DecodeFlowState verifies the cookie value and checks that it belongs to
+// the provider and state the callback was invoked with.
+func DecodeFlowState(token, provider, state string) (*FlowState, error) {
+	claims, err := security.DefaultKeyRing.Parse(token)
+	if err != nil {
+		return nil, ErrInvalidFlowState
+	}
+	if purpose, _ := claims["purpose"].(string); purpose != purposeOIDCFlow {
+		return nil, ErrInvalidFlowState
+	}
+
+	flow := &FlowState{}
+	flow.Provider, _ = claims["provider"].(string)
+	flow.State, _ = claims["state"].(string)
+	flow.Nonce, _ = claims["nonce"].(string)
+	flow.CodeVerifier, _ = claims["code_verifier"].(string)
+
+	if flow.Provider != provider || flow.State == "" || flow.Nonce == "" || flow.CodeVerifier == "" {
+		return nil, ErrInvalidFlowState
+	}
+	if subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return nil, ErrInvalidFlowState
+	}
+	return flow, nil
+}
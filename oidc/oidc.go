@@ -0,0 +1,33 @@
+package oidc
+
+import (
+	"log"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+)
+
+// DefaultProviders holds the configured identity providers keyed by name.
+var DefaultProviders = map[string]*Provider{}
+
+func NewProvidersFromConfig(cfg *config.Config) map[string]*Provider {
+	providers := make(map[string]*Provider, len(cfg.OIDCProviders))
+	for _, p := range cfg.OIDCProviders {
+		if p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			log.Printf("Skipping OIDC provider %q: issuer, client ID and redirect URL are required", p.Name)
+			continue
+		}
+		providers[p.Name] = NewProvider(ProviderConfig{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}, nil)
+	}
+	return providers
+}
+
+func Configure(cfg *config.Config) {
+	DefaultProviders = NewProvidersFromConfig(cfg)
+}
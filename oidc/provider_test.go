@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testClientID = "gameclub-test"
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	server := oidctest.NewServer(testClientID)
+	t.Cleanup(server.Close)
+
+	provider := NewProvider(ProviderConfig{
+		Name:        "stub",
+		Issuer:      server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/api/auth/oidc/stub/callback",
+	}, server.Client())
+	return provider, server
+}
+
+func TestAuthCodeURL_ContainsPKCEAndState(t *testing.T) {
+	// Given: A provider discovered from the stub server
+	provider, server := newTestProvider(t)
+
+	// When: Building the authorization URL
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+
+	// Then: The URL should target the authorization endpoint with all parameters
+	assert.NoError(t, err)
+	parsed, _ := url.Parse(authURL)
+	query := parsed.Query()
+	assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "state-1", query.Get("state"))
+	assert.Equal(t, "nonce-1", query.Get("nonce"))
+	assert.Equal(t, "challenge-1", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestExchange_ReturnsVerifiedClaims(t *testing.T) {
+	// Given: A user who consented at the provider
+	provider, server := newTestProvider(t)
+	verifier, challenge, err := NewPKCE()
+	assert.NoError(t, err)
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce-1", challenge)
+	assert.NoError(t, err)
+	code, _ := server.Authorize(authURL, jwt.MapClaims{
+		"sub":            "subject-1",
+		"email":          "ada@example.com",
+		"email_verified": true,
+		"given_name":     "Ada",
+	})
+
+	// When: Exchanging the code with the matching verifier
+	claims, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+
+	// Then: The ID token claims should be returned
+	assert.NoError(t, err)
+	assert.Equal(t, "subject-1", claims.Subject)
+	assert.Equal(t, "ada@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "Ada", claims.GivenName)
+}
+
+func TestExchange_RejectsWrongVerifier(t *testing.T) {
+	// Given: A code issued for a different PKCE challenge
+	provider, server := newTestProvider(t)
+	_, challenge, _ := NewPKCE()
+	authURL, _ := provider.AuthCodeURL(context.Background(), "state", "nonce-1", challenge)
+	code, _ := server.Authorize(authURL, jwt.MapClaims{"sub": "subject-1"})
+
+	// When: Exchanging the code with another verifier
+	_, err := provider.Exchange(context.Background(), code, "not-the-verifier", "nonce-1")
+
+	// Then: The exchange should fail
+	assert.ErrorIs(t, err, ErrTokenExchange)
+}
+
+func TestVerifyIDToken_RejectsInvalidTokens(t *testing.T) {
+	provider, server := newTestProvider(t)
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		nonce  string
+	}{
+		{name: "nonce mismatch", claims: jwt.MapClaims{"sub": "s", "nonce": "other"}, nonce: "nonce-1"},
+		{name: "wrong audience", claims: jwt.MapClaims{"sub": "s", "nonce": "nonce-1", "aud": "someone-else"}, nonce: "nonce-1"},
+		{name: "wrong issuer", claims: jwt.MapClaims{"sub": "s", "nonce": "nonce-1", "iss": "https://evil.example"}, nonce: "nonce-1"},
+		{name: "expired", claims: jwt.MapClaims{"sub": "s", "nonce": "nonce-1", "exp": time.Now().Add(-time.Minute).Unix()}, nonce: "nonce-1"},
+		{name: "missing subject", claims: jwt.MapClaims{"nonce": "nonce-1"}, nonce: "nonce-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: An ID token that violates one check
+			raw := server.SignIDToken(tt.claims)
+
+			// When: Verifying it
+			_, err := provider.VerifyIDToken(context.Background(), raw, tt.nonce)
+
+			// Then: It should be rejected
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestVerifyIDToken_RefetchesKeysAfterRotation(t *testing.T) {
+	// Given: A provider that has cached the stub's first key
+	provider, server := newTestProvider(t)
+	_, err := provider.VerifyIDToken(context.Background(), server.SignIDToken(jwt.MapClaims{"sub": "s", "nonce": "n"}), "n")
+	assert.NoError(t, err)
+
+	// When: The stub rotates its key and signs a new token
+	server.RotateKey()
+	claims, err := provider.VerifyIDToken(context.Background(), server.SignIDToken(jwt.MapClaims{"sub": "s", "nonce": "n"}), "n")
+
+	// Then: The new key should be fetched and the token accepted
+	assert.NoError(t, err)
+	assert.Equal(t, "s", claims.Subject)
+}
+
+func TestFlowState_RoundTrip(t *testing.T) {
+	// Given: An encoded flow state
+	flow, challenge, err := NewFlowState("stub")
+	assert.NoError(t, err)
+	encoded, err := flow.Encode()
+	assert.NoError(t, err)
+
+	// When: Decoding it for the same provider and state
+	decoded, err := DecodeFlowState(encoded, "stub", flow.State)
+
+	// Then: All values should survive and the challenge should match the verifier
+	assert.NoError(t, err)
+	assert.Equal(t, flow, decoded)
+	assert.Equal(t, CodeChallenge(decoded.CodeVerifier), challenge)
+}
+
+func TestDecodeFlowState_RejectsMismatch(t *testing.T) {
+	// Given: An encoded flow state
+	flow, _, _ := NewFlowState("stub")
+	encoded, _ := flow.Encode()
+
+	// When / Then: A different state, provider or a tampered cookie is rejected
+	_, err := DecodeFlowState(encoded, "stub", "forged-state")
+	assert.ErrorIs(t, err, ErrInvalidFlowState)
+	_, err = DecodeFlowState(encoded, "other", flow.State)
+	assert.ErrorIs(t, err, ErrInvalidFlowState)
+	_, err = DecodeFlowState(encoded+"x", "stub", flow.State)
+	assert.ErrorIs(t, err, ErrInvalidFlowState)
+}
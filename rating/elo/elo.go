@@ -0,0 +1,53 @@
+package elo
+
+import (
+	"math"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+const (
+	Name          = "elo"
+	InitialRating = 1500.0
+	DefaultK      = 32.0
+)
+
+// Algorithm is Elo extended to multiplayer games: each play counts as a
+// match against every other player, and the K-factor is shared among them.
+type Algorithm struct {
+	k float64
+}
+
+func New() *Algorithm {
+	return &Algorithm{k: DefaultK}
+}
+
+func (a *Algorithm) Initial() models.SkillRating {
+	return models.SkillRating{Rating: InitialRating}
+}
+
+func (a *Algorithm) Update(standings []models.Standing) []models.SkillRating {
+	updated := make([]models.SkillRating, len(standings))
+	opponents := float64(len(standings) - 1)
+	for i, player := range standings {
+		var delta float64
+		for j, opponent := range standings {
+			if i == j {
+				continue
+			}
+			delta += player.Outcome(opponent) - Expected(player.Rating, opponent.Rating)
+		}
+		updated[i] = models.SkillRating{Rating: player.Rating + a.k*delta/opponents}
+	}
+	return updated
+}
+
+func (a *Algorithm) GetAlgorithmName() string {
+	return Name
+}
+
+// Expected is the score a player rated rating is expected to take from an
+// opponent rated opponent.
+func Expected(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
@@ -0,0 +1,63 @@
+package elo
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlgorithm_Update_HeadToHead(t *testing.T) {
+	// Given: Two new players, one of whom wins
+	algorithm := New()
+	standings := []models.Standing{
+		{SkillRating: algorithm.Initial(), Rank: 1},
+		{SkillRating: algorithm.Initial(), Rank: 2},
+	}
+
+	// When: Rating the play
+	updated := algorithm.Update(standings)
+
+	// Then: Half the K-factor moves from the loser to the winner
+	assert.Equal(t, 1516.0, updated[0].Rating)
+	assert.Equal(t, 1484.0, updated[1].Rating)
+}
+
+func TestAlgorithm_Update_Upset(t *testing.T) {
+	// Given: An underdog beating a much stronger player
+	algorithm := New()
+	standings := []models.Standing{
+		{SkillRating: models.SkillRating{Rating: 1400}, Rank: 1},
+		{SkillRating: models.SkillRating{Rating: 1800}, Rank: 2},
+	}
+
+	// When: Rating the play
+	updated := algorithm.Update(standings)
+
+	// Then: The underdog gains almost the whole K-factor
+	assert.InDelta(t, 1429.1, updated[0].Rating, 0.1)
+	assert.InDelta(t, 1770.9, updated[1].Rating, 0.1)
+}
+
+func TestAlgorithm_Update_Multiplayer(t *testing.T) {
+	// Given: Three equal players, two of whom draw for second place
+	algorithm := New()
+	standings := []models.Standing{
+		{SkillRating: algorithm.Initial(), Rank: 2},
+		{SkillRating: algorithm.Initial(), Rank: 1},
+		{SkillRating: algorithm.Initial(), Rank: 2},
+	}
+
+	// When: Rating the play
+	updated := algorithm.Update(standings)
+
+	// Then: The winner gains what the others lose, and no points are created
+	assert.Equal(t, 1492.0, updated[0].Rating)
+	assert.Equal(t, 1516.0, updated[1].Rating)
+	assert.InDelta(t, 4500.0, updated[0].Rating+updated[1].Rating+updated[2].Rating, 0.0001)
+}
+
+func TestExpected(t *testing.T) {
+	assert.Equal(t, 0.5, Expected(1500, 1500))
+	assert.InDelta(t, 0.909, Expected(1800, 1400), 0.001)
+}
@@ -0,0 +1,138 @@
+package rating
+
+import (
+	"sort"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+type Engine struct {
+	algorithm RatingAlgorithm
+}
+
+func NewEngine(algorithm RatingAlgorithm) *Engine {
+	return &Engine{
+		algorithm: algorithm,
+	}
+}
+
+func (e *Engine) SetAlgorithm(algorithm RatingAlgorithm) {
+	e.algorithm = algorithm
+}
+
+func (e *Engine) GetCurrentAlgorithm() RatingAlgorithm {
+	return e.algorithm
+}
+
+// Rated reports whether a play moves ratings. Cooperative plays and plays
+// with a single player have nobody to measure against, and plays a member
+// logged themselves are not official.
+func Rated(play *models.Play) bool {
+	return play.Official && !play.Cooperative && len(play.Players) > 1
+}
+
+// Apply rates one play. current holds whatever ratings the players already
+// have at the play's game and globally; missing ones start at the
+// algorithm's initial rating, and ratings from another algorithm are
+// ignored. It returns the players' new ratings, for the game and globally.
+func (e *Engine) Apply(current []models.PlayerRating, play *models.Play) []models.PlayerRating {
+	if !Rated(play) {
+		return nil
+	}
+
+	name := e.algorithm.GetAlgorithmName()
+	index := make(map[key]models.PlayerRating, len(current))
+	for _, existing := range current {
+		if existing.Algorithm == name {
+			index[key{existing.GameID, existing.UserID}] = existing
+		}
+	}
+
+	ranks := Ranks(play)
+	updated := make([]models.PlayerRating, 0, 2*len(play.Players))
+	for _, gameID := range []uint{play.GameID, models.GlobalRatingGameID} {
+		ratings := make([]models.PlayerRating, len(play.Players))
+		standings := make([]models.Standing, len(play.Players))
+		for i, player := range play.Players {
+			existing, ok := index[key{gameID, player.UserID}]
+			if !ok {
+				existing = models.PlayerRating{UserID: player.UserID, GameID: gameID, SkillRating: e.algorithm.Initial()}
+			}
+			existing.Algorithm = name
+			ratings[i] = existing
+			standings[i] = models.Standing{SkillRating: existing.SkillRating, Rank: ranks[i]}
+		}
+
+		for i, skill := range e.algorithm.Update(standings) {
+			ratings[i].SkillRating = skill
+			ratings[i].Plays++
+		}
+		updated = append(updated, ratings...)
+	}
+	return updated
+}
+
+// Replay rates plays from scratch, in the order given, and returns every
+// resulting rating ordered by game and player.
+func (e *Engine) Replay(plays []models.Play) []models.PlayerRating {
+	ratings := map[key]models.PlayerRating{}
+	for i := range plays {
+		play := &plays[i]
+		var current []models.PlayerRating
+		for _, player := range play.Players {
+			for _, gameID := range []uint{play.GameID, models.GlobalRatingGameID} {
+				if existing, ok := ratings[key{gameID, player.UserID}]; ok {
+					current = append(current, existing)
+				}
+			}
+		}
+		for _, updated := range e.Apply(current, play) {
+			ratings[key{updated.GameID, updated.UserID}] = updated
+		}
+	}
+
+	result := make([]models.PlayerRating, 0, len(ratings))
+	for _, r := range ratings {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GameID != result[j].GameID {
+			return result[i].GameID < result[j].GameID
+		}
+		return result[i].UserID < result[j].UserID
+	})
+	return result
+}
+
+// Ranks orders a play's players for rating. Winners share first place; the
+// others follow by score, highest first, and those without a score tie for
+// last.
+func Ranks(play *models.Play) []int {
+	var scores []int
+	seen := map[int]bool{}
+	for _, player := range play.Players {
+		if !player.Winner && player.Score != nil && !seen[*player.Score] {
+			seen[*player.Score] = true
+			scores = append(scores, *player.Score)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+
+	ranks := make([]int, len(play.Players))
+	for i, player := range play.Players {
+		switch {
+		case player.Winner:
+			ranks[i] = 1
+		case player.Score != nil:
+			ranks[i] = 2 + sort.Search(len(scores), func(j int) bool { return scores[j] <= *player.Score })
+		default:
+			ranks[i] = 2 + len(scores)
+		}
+	}
+	return ranks
+}
+
+type key struct {
+	gameID uint
+	userID uint
+}
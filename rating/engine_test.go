@@ -0,0 +1,137 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/elo"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/glicko2"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func newPlay(gameID uint, players ...models.PlayPlayer) models.Play {
+	return models.Play{GameID: gameID, Official: true, Players: players}
+}
+
+func TestRanks(t *testing.T) {
+	// Given: A winner, two scored players tied on points and one without a score
+	play := newPlay(1,
+		models.PlayPlayer{UserID: 1, Score: intPtr(30)},
+		models.PlayPlayer{UserID: 2, Score: intPtr(50), Winner: true},
+		models.PlayPlayer{UserID: 3},
+		models.PlayPlayer{UserID: 4, Score: intPtr(30)},
+		models.PlayPlayer{UserID: 5, Score: intPtr(40)},
+	)
+
+	// When: Ranking the players
+	ranks := Ranks(&play)
+
+	// Then: Ties share a place and the unscored player is last
+	assert.Equal(t, []int{3, 1, 4, 3, 2}, ranks)
+}
+
+func TestEngine_Apply(t *testing.T) {
+	// Given: A player with an existing rating at the game and a newcomer
+	engine := NewEngine(elo.New())
+	play := newPlay(7, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2})
+	current := []models.PlayerRating{
+		{UserID: 1, GameID: 7, Algorithm: elo.Name, SkillRating: models.SkillRating{Rating: 1500}, Plays: 4},
+		{UserID: 1, GameID: models.GlobalRatingGameID, Algorithm: glicko2.Name, SkillRating: models.SkillRating{Rating: 1900}},
+	}
+
+	// When: Applying the play
+	updated := engine.Apply(current, &play)
+
+	// Then: Both players get a game and a global rating, ignoring the other algorithm's
+	assert.Len(t, updated, 4)
+	assert.Equal(t, uint(7), updated[0].GameID)
+	assert.Equal(t, 1516.0, updated[0].Rating)
+	assert.Equal(t, 5, updated[0].Plays)
+	assert.Equal(t, 1484.0, updated[1].Rating)
+	assert.Equal(t, 1, updated[1].Plays)
+	assert.Equal(t, uint(models.GlobalRatingGameID), updated[2].GameID)
+	assert.Equal(t, 1516.0, updated[2].Rating)
+	for _, rating := range updated {
+		assert.Equal(t, elo.Name, rating.Algorithm)
+	}
+}
+
+func TestEngine_Apply_SkipsUnratedPlays(t *testing.T) {
+	engine := NewEngine(elo.New())
+	cooperative := newPlay(1, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2, Winner: true})
+	cooperative.Cooperative = true
+	solo := newPlay(1, models.PlayPlayer{UserID: 1, Winner: true})
+	unofficial := newPlay(1, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2})
+	unofficial.Official = false
+
+	assert.Empty(t, engine.Apply(nil, &cooperative))
+	assert.Empty(t, engine.Apply(nil, &solo))
+	assert.Empty(t, engine.Apply(nil, &unofficial))
+}
+
+func TestEngine_Replay(t *testing.T) {
+	// Given: Two games played in turn by the same players
+	engine := NewEngine(elo.New())
+	plays := []models.Play{
+		newPlay(1, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2}),
+		newPlay(2, models.PlayPlayer{UserID: 2, Winner: true}, models.PlayPlayer{UserID: 1}),
+		newPlay(1, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2}),
+	}
+
+	// When: Replaying the history
+	ratings := engine.Replay(plays)
+
+	// Then: Every player has a global rating and one per game, in order
+	assert.Len(t, ratings, 6)
+	assert.Equal(t, uint(models.GlobalRatingGameID), ratings[0].GameID)
+	assert.Equal(t, 3, ratings[0].Plays)
+	assert.Greater(t, ratings[0].Rating, ratings[1].Rating)
+	assert.Equal(t, uint(1), ratings[2].GameID)
+	assert.Equal(t, 2, ratings[2].Plays)
+	assert.Equal(t, uint(2), ratings[5].GameID)
+	assert.Equal(t, 1516.0, ratings[5].Rating)
+}
+
+func TestEngine_SetAlgorithm(t *testing.T) {
+	// Given: An engine using Elo
+	engine := NewEngine(elo.New())
+
+	// When: Switching to Glicko-2
+	engine.SetAlgorithm(glicko2.New())
+
+	// Then: New ratings carry a deviation
+	play := newPlay(1, models.PlayPlayer{UserID: 1, Winner: true}, models.PlayPlayer{UserID: 2})
+	updated := engine.Apply(nil, &play)
+	assert.Equal(t, glicko2.Name, engine.GetCurrentAlgorithm().GetAlgorithmName())
+	assert.Greater(t, updated[0].Deviation, 0.0)
+}
+
+func TestNewAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: elo.Name},
+		{name: "Elo", want: elo.Name},
+		{name: "glicko2", want: glicko2.Name},
+		{name: "trueskill", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm, err := NewAlgorithm(tt.name)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, algorithm.GetAlgorithmName())
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package glicko2
+
+import (
+	"math"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+const (
+	Name              = "glicko2"
+	InitialRating     = 1500.0
+	InitialDeviation  = 350.0
+	InitialVolatility = 0.06
+	DefaultTau        = 0.5
+
+	// scale converts between the Glicko and Glicko-2 scales.
+	scale     = 173.7178
+	tolerance = 0.000001
+)
+
+// Result is one game against an opponent: Score is 1 for a win, 0.5 for a
+// draw and 0 for a loss.
+type Result struct {
+	Opponent models.SkillRating
+	Score    float64
+}
+
+// Algorithm is Mark Glickman's Glicko-2. Each play is one rating period in
+// which every player meets every other player.
+type Algorithm struct {
+	tau float64
+}
+
+func New() *Algorithm {
+	return &Algorithm{tau: DefaultTau}
+}
+
+func (a *Algorithm) Initial() models.SkillRating {
+	return models.SkillRating{Rating: InitialRating, Deviation: InitialDeviation, Volatility: InitialVolatility}
+}
+
+func (a *Algorithm) Update(standings []models.Standing) []models.SkillRating {
+	updated := make([]models.SkillRating, len(standings))
+	for i, player := range standings {
+		results := make([]Result, 0, len(standings)-1)
+		for j, opponent := range standings {
+			if i != j {
+				results = append(results, Result{Opponent: opponent.SkillRating, Score: player.Outcome(opponent)})
+			}
+		}
+		updated[i] = a.Rate(player.SkillRating, results)
+	}
+	return updated
+}
+
+func (a *Algorithm) GetAlgorithmName() string {
+	return Name
+}
+
+// Rate applies one rating period's results to a player, following the steps
+// of Glickman's "Example of the Glicko-2 system".
+func (a *Algorithm) Rate(player models.SkillRating, results []Result) models.SkillRating {
+	mu := (player.Rating - InitialRating) / scale
+	phi := player.Deviation / scale
+	if len(results) == 0 {
+		phi = math.Sqrt(phi*phi + player.Volatility*player.Volatility)
+		return models.SkillRating{Rating: player.Rating, Deviation: phi * scale, Volatility: player.Volatility}
+	}
+
+	var variance, improvement float64
+	for _, result := range results {
+		opponentMu := (result.Opponent.Rating - InitialRating) / scale
+		g := gOf(result.Opponent.Deviation / scale)
+		expected := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+		variance += g * g * expected * (1 - expected)
+		improvement += g * (result.Score - expected)
+	}
+	variance = 1 / variance
+	delta := variance * improvement
+
+	volatility := a.volatility(phi, player.Volatility, variance, delta)
+	phiStar := math.Sqrt(phi*phi + volatility*volatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	newMu := mu + newPhi*newPhi*improvement
+
+	return models.SkillRating{
+		Rating:     newMu*scale + InitialRating,
+		Deviation:  newPhi * scale,
+		Volatility: volatility,
+	}
+}
+
+func gOf(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// volatility finds the new volatility with the Illinois algorithm (step 5).
+func (a *Algorithm) volatility(phi, sigma, variance, delta float64) float64 {
+	alpha := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		denominator := phi*phi + variance + ex
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*denominator*denominator) - (x-alpha)/(a.tau*a.tau)
+	}
+
+	lower := alpha
+	var upper float64
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(alpha-k*a.tau) < 0 {
+			k++
+		}
+		upper = alpha - k*a.tau
+	}
+
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > tolerance {
+		next := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fNext := f(next)
+		if fNext*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+		upper, fUpper = next, fNext
+	}
+	return math.Exp(lower / 2)
+}
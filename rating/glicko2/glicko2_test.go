@@ -0,0 +1,59 @@
+package glicko2
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlgorithm_Rate_GlickmanExample(t *testing.T) {
+	// Given: The worked example from Glickman's paper
+	algorithm := New()
+	player := models.SkillRating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: models.SkillRating{Rating: 1400, Deviation: 30}, Score: 1},
+		{Opponent: models.SkillRating{Rating: 1550, Deviation: 100}, Score: 0},
+		{Opponent: models.SkillRating{Rating: 1700, Deviation: 300}, Score: 0},
+	}
+
+	// When: Rating the period
+	rated := algorithm.Rate(player, results)
+
+	// Then: The paper's results are reproduced
+	assert.InDelta(t, 1464.06, rated.Rating, 0.01)
+	assert.InDelta(t, 151.52, rated.Deviation, 0.01)
+	assert.InDelta(t, 0.05999, rated.Volatility, 0.00001)
+}
+
+func TestAlgorithm_Rate_NoResults(t *testing.T) {
+	// Given: A player who did not play
+	algorithm := New()
+	player := models.SkillRating{Rating: 1600, Deviation: 50, Volatility: 0.06}
+
+	// When: Rating an empty period
+	rated := algorithm.Rate(player, nil)
+
+	// Then: Only the deviation grows
+	assert.InDelta(t, 1600.0, rated.Rating, 0.0001)
+	assert.Greater(t, rated.Deviation, player.Deviation)
+	assert.Equal(t, player.Volatility, rated.Volatility)
+}
+
+func TestAlgorithm_Update(t *testing.T) {
+	// Given: Two new players, one of whom wins
+	algorithm := New()
+	standings := []models.Standing{
+		{SkillRating: algorithm.Initial(), Rank: 2},
+		{SkillRating: algorithm.Initial(), Rank: 1},
+	}
+
+	// When: Rating the play
+	updated := algorithm.Update(standings)
+
+	// Then: The ratings move symmetrically and both become more certain
+	assert.Greater(t, updated[1].Rating, InitialRating)
+	assert.InDelta(t, 2*InitialRating, updated[0].Rating+updated[1].Rating, 0.0001)
+	assert.Less(t, updated[0].Deviation, InitialDeviation)
+	assert.InDelta(t, updated[0].Deviation, updated[1].Deviation, 0.0001)
+}
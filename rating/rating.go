@@ -0,0 +1,47 @@
+// Package rating measures player skill from recorded plays. Every rated play
+// moves each participant's rating at that game and their global rating; the
+// algorithm behind it is pluggable.
+package rating
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/elo"
+	"github.com/PI-Team04-GameClub/gameclub-backend/rating/glicko2"
+)
+
+type RatingAlgorithm interface {
+	// Initial is the rating of a player without results.
+	Initial() models.SkillRating
+
+	// Update rates one play, returning the standings' new ratings in order.
+	Update(standings []models.Standing) []models.SkillRating
+
+	GetAlgorithmName() string
+}
+
+var DefaultEngine = NewEngine(elo.New())
+
+// NewAlgorithm returns the algorithm with the given name.
+func NewAlgorithm(name string) (RatingAlgorithm, error) {
+	switch strings.ToLower(name) {
+	case "", elo.Name:
+		return elo.New(), nil
+	case glicko2.Name:
+		return glicko2.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported rating algorithm %q", name)
+	}
+}
+
+func Configure(cfg *config.Config) error {
+	algorithm, err := NewAlgorithm(cfg.RatingAlgorithm)
+	if err != nil {
+		return err
+	}
+	DefaultEngine.SetAlgorithm(algorithm)
+	return nil
+}
@@ -0,0 +1,151 @@
+// Package recommend ranks catalog games for a group sitting down to play:
+// how well each game fits the number of players, the time available, the
+// youngest player and the complexity the group wants, and how much the
+// players at the table liked it before.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+)
+
+// OvertimeAllowance is how far past the available time a game may run, as
+// a share of that time, before it is left out.
+const OvertimeAllowance = 0.25
+
+// Weights of the fit criteria. Without a complexity preference the other
+// two share the whole fit.
+const (
+	weightPlaytime   = 0.4
+	weightPlayers    = 0.2
+	weightComplexity = 0.4
+
+	// Boosts are added to the fit, so a game the table loves can overtake a
+	// slightly better fitting one, but never a poorly fitting one.
+	maxPlayerBoost = 0.3
+	maxClubBoost   = 0.1
+)
+
+var complexityLevels = map[models.GameComplexity]int{
+	models.ComplexityEasy:   0,
+	models.ComplexityMedium: 1,
+	models.ComplexityHard:   2,
+	models.ComplexityExpert: 3,
+}
+
+// Table describes the group. YoungestAge and Complexity are optional.
+type Table struct {
+	Players     int
+	Minutes     int
+	YoungestAge int
+	Complexity  models.GameComplexity
+}
+
+// Scores holds the review scores the players at the table gave, by game.
+type Scores map[uint][]int
+
+type Recommendation struct {
+	Game    models.Game
+	Score   float64
+	Reasons []string
+}
+
+// Rank returns up to limit games, best first. Games the group cannot play,
+// because of the player count, the youngest player's age or a playtime well
+// past the time available, are left out, as are expansions.
+func Rank(games []models.Game, table Table, scores Scores, limit int) []Recommendation {
+	recommendations := make([]Recommendation, 0, len(games))
+	for _, game := range games {
+		if recommendation, ok := score(game, table, scores[game.ID]); ok {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Game.Name < recommendations[j].Game.Name
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+func score(game models.Game, table Table, playerScores []int) (Recommendation, bool) {
+	if game.IsExpansion() || table.Players < game.MinPlayers || table.Players > game.MaxPlayers {
+		return Recommendation{}, false
+	}
+	if table.YoungestAge > 0 && game.MinAge > table.YoungestAge {
+		return Recommendation{}, false
+	}
+	overtime := float64(game.PlaytimeMinutes-table.Minutes) / float64(table.Minutes)
+	if overtime > OvertimeAllowance {
+		return Recommendation{}, false
+	}
+
+	var reasons []string
+
+	playersFit := 1.0
+	if game.MinPlayers == game.MaxPlayers {
+		reasons = append(reasons, fmt.Sprintf("Made for exactly %d players", table.Players))
+	} else if table.Players == game.MinPlayers || table.Players == game.MaxPlayers {
+		playersFit = 0.8
+		reasons = append(reasons, fmt.Sprintf("Plays %d, at the edge of its %d–%d player range", table.Players, game.MinPlayers, game.MaxPlayers))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Plays %d comfortably (%d–%d players)", table.Players, game.MinPlayers, game.MaxPlayers))
+	}
+
+	var timeFit float64
+	if overtime > 0 {
+		timeFit = 1 - 2*overtime
+		reasons = append(reasons, fmt.Sprintf("Takes about %d minutes, a little over the %d available", game.PlaytimeMinutes, table.Minutes))
+	} else {
+		timeFit = 1 + 0.5*overtime
+		reasons = append(reasons, fmt.Sprintf("Fits in %d minutes (takes about %d)", table.Minutes, game.PlaytimeMinutes))
+	}
+
+	if table.YoungestAge > 0 {
+		reasons = append(reasons, fmt.Sprintf("Suitable from age %d", game.MinAge))
+	}
+
+	fit := (weightPlaytime*timeFit + weightPlayers*playersFit) / (weightPlaytime + weightPlayers)
+	if want, ok := complexityLevels[table.Complexity]; ok {
+		distance := math.Abs(float64(complexityLevels[game.Complexity] - want))
+		complexityFit := 1 - distance/3
+		fit = weightPlaytime*timeFit + weightPlayers*playersFit + weightComplexity*complexityFit
+		switch distance {
+		case 0:
+			reasons = append(reasons, fmt.Sprintf("%s, as requested", game.Complexity))
+		default:
+			reasons = append(reasons, fmt.Sprintf("%s rather than %s", game.Complexity, table.Complexity))
+		}
+	}
+
+	total := fit
+	if len(playerScores) > 0 {
+		sum := 0
+		for _, s := range playerScores {
+			sum += s
+		}
+		average := float64(sum) / float64(len(playerScores))
+		total += maxPlayerBoost * (average - 5.5) / 4.5
+		reasons = append(reasons, fmt.Sprintf("Rated %.1f/10 by %d of the players", average, len(playerScores)))
+	}
+	if game.RatingCount > 0 {
+		total += maxClubBoost * (game.Rating - 5.5) / 4.5
+		if game.Rating >= 7 {
+			reasons = append(reasons, fmt.Sprintf("Club rating %.1f/10", game.Rating))
+		}
+	}
+
+	return Recommendation{
+		Game:    game,
+		Score:   math.Round(total*1000) / 1000,
+		Reasons: reasons,
+	}, true
+}
@@ -0,0 +1,126 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newGame(id uint, name string, minPlayers, maxPlayers, playtime, minAge int, complexity models.GameComplexity) models.Game {
+	return *models.NewGameBuilder().
+		SetModel(gorm.Model{ID: id}).
+		SetName(name).
+		SetPlayerRange(minPlayers, maxPlayers).
+		SetPlaytimeMinutes(playtime).
+		SetMinAge(minAge).
+		SetComplexity(complexity).
+		Build()
+}
+
+func names(recommendations []Recommendation) []string {
+	result := make([]string, len(recommendations))
+	for i, recommendation := range recommendations {
+		result[i] = recommendation.Game.Name
+	}
+	return result
+}
+
+func TestRank_LeavesOutGamesTheTableCannotPlay(t *testing.T) {
+	// Given: Games outside the player count, age, time budget, and an expansion
+	base := newGame(1, "Catan", 3, 4, 90, 10, models.ComplexityMedium)
+	expansion := newGame(2, "Catan: Seafarers", 3, 4, 60, 10, models.ComplexityMedium)
+	expansion.BaseGameID = &base.ID
+	games := []models.Game{
+		base,
+		expansion,
+		newGame(3, "Twilight Imperium", 3, 6, 480, 14, models.ComplexityExpert),
+		newGame(4, "Patchwork", 2, 2, 30, 8, models.ComplexityEasy),
+		newGame(5, "Gloomhaven", 1, 4, 100, 14, models.ComplexityHard),
+		newGame(6, "Azul", 2, 4, 45, 8, models.ComplexityEasy),
+	}
+
+	// When: Ranking for four players with 100 minutes and a ten year old
+	recommendations := Rank(games, Table{Players: 4, Minutes: 100, YoungestAge: 10}, nil, 10)
+
+	// Then: Only the playable base games are left
+	assert.ElementsMatch(t, []string{"Catan", "Azul"}, names(recommendations))
+}
+
+func TestRank_AllowsSlightOvertime(t *testing.T) {
+	// Given: Games running 20% and 50% over the time available
+	games := []models.Game{
+		newGame(1, "Brass", 2, 4, 120, 14, models.ComplexityHard),
+		newGame(2, "Terraforming Mars", 1, 5, 150, 12, models.ComplexityHard),
+	}
+
+	// When: Ranking for 100 minutes
+	recommendations := Rank(games, Table{Players: 3, Minutes: 100}, nil, 10)
+
+	// Then: Only the slight overrun is kept, and it says so
+	assert.Equal(t, []string{"Brass"}, names(recommendations))
+	assert.Contains(t, recommendations[0].Reasons, "Takes about 120 minutes, a little over the 100 available")
+}
+
+func TestRank_PrefersRequestedComplexity(t *testing.T) {
+	// Given: Two otherwise equal games of different weight
+	games := []models.Game{
+		newGame(1, "Heavy", 2, 5, 60, 10, models.ComplexityExpert),
+		newGame(2, "Light", 2, 5, 60, 10, models.ComplexityEasy),
+	}
+
+	// When: The table asks for an easy game
+	recommendations := Rank(games, Table{Players: 3, Minutes: 60, Complexity: models.ComplexityEasy}, nil, 10)
+
+	// Then: The easy game ranks first and explains why
+	assert.Equal(t, []string{"Light", "Heavy"}, names(recommendations))
+	assert.Contains(t, recommendations[0].Reasons, "Easy, as requested")
+	assert.Contains(t, recommendations[1].Reasons, "Expert rather than Easy")
+}
+
+func TestRank_BoostsGamesThePlayersRated(t *testing.T) {
+	// Given: Two equally fitting games, one the players loved
+	games := []models.Game{
+		newGame(1, "Azul", 2, 4, 45, 8, models.ComplexityEasy),
+		newGame(2, "Sagrada", 2, 4, 45, 8, models.ComplexityEasy),
+	}
+	scores := Scores{2: {9, 10}}
+
+	// When: Ranking with their scores
+	recommendations := Rank(games, Table{Players: 3, Minutes: 45}, scores, 10)
+
+	// Then: The loved game comes first and names the rating
+	assert.Equal(t, []string{"Sagrada", "Azul"}, names(recommendations))
+	assert.Greater(t, recommendations[0].Score, recommendations[1].Score)
+	assert.Contains(t, recommendations[0].Reasons, "Rated 9.5/10 by 2 of the players")
+}
+
+func TestRank_PrefersGamesThatFillTheTime(t *testing.T) {
+	// Given: A filler and a game that uses the whole evening
+	games := []models.Game{
+		newGame(1, "Filler", 2, 6, 15, 8, models.ComplexityMedium),
+		newGame(2, "Evening", 2, 6, 110, 8, models.ComplexityMedium),
+	}
+
+	// When: The table has two hours
+	recommendations := Rank(games, Table{Players: 4, Minutes: 120}, nil, 10)
+
+	// Then: The longer game ranks first
+	assert.Equal(t, []string{"Evening", "Filler"}, names(recommendations))
+}
+
+func TestRank_Limit(t *testing.T) {
+	// Given: Three playable games
+	games := []models.Game{
+		newGame(1, "A", 2, 4, 30, 8, models.ComplexityEasy),
+		newGame(2, "B", 2, 4, 30, 8, models.ComplexityEasy),
+		newGame(3, "C", 2, 4, 30, 8, models.ComplexityEasy),
+	}
+
+	// When: Asking for two
+	recommendations := Rank(games, Table{Players: 3, Minutes: 30}, nil, 2)
+
+	// Then: Ties are broken by name
+	assert.Equal(t, []string{"A", "B"}, names(recommendations))
+}
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// AttemptStore keeps expiring failure counters and lock markers, used to
+// throttle repeated login attempts.
+type AttemptStore interface {
+	Increment(ctx context.Context, key string, window time.Duration) (int64, error)
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, keys ...string) error
+}
+
+type RedisAttemptStore struct {
+	client *goredis.Client
+}
+
+func NewRedisAttemptStore(client *goredis.Client) *RedisAttemptStore {
+	return &RedisAttemptStore{client: client}
+}
+
+func (s *RedisAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *goredis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (s *RedisAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	return s.client.Set(ctx, key, 1, duration).Err()
+}
+
+func (s *RedisAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *RedisAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.client.Del(ctx, keys...).Err()
+}
+
+const memoryPurgeThreshold = 10000
+
+type memoryEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryAttemptStore is a process-local AttemptStore used when Redis is not
+// available. Counters are not shared between instances.
+type MemoryAttemptStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.entries) >= memoryPurgeThreshold {
+		s.purgeExpired(now)
+	}
+
+	entry := s.liveEntry(key, now)
+	entry.count++
+	entry.expiresAt = now.Add(window)
+	s.entries[key] = entry
+	return entry.count, nil
+}
+
+func (s *MemoryAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = memoryEntry{count: 1, expiresAt: s.now().Add(duration)}
+	return nil
+}
+
+func (s *MemoryAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entry := s.liveEntry(key, now)
+	if entry.count == 0 {
+		return 0, nil
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+func (s *MemoryAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *MemoryAttemptStore) liveEntry(key string, now time.Time) memoryEntry {
+	entry, ok := s.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return memoryEntry{}
+	}
+	return entry
+}
+
+func (s *MemoryAttemptStore) purgeExpired(now time.Time) {
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
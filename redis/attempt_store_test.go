@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemoryAttemptStore(now *time.Time) *MemoryAttemptStore {
+	store := NewMemoryAttemptStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryAttemptStore_IncrementCountsWithinWindow(t *testing.T) {
+	// Given: An empty store
+	now := time.Now()
+	store := newTestMemoryAttemptStore(&now)
+	ctx := context.Background()
+
+	// When: Incrementing the same key twice
+	store.Increment(ctx, "key", time.Minute)
+	count, err := store.Increment(ctx, "key", time.Minute)
+
+	// Then: The counter should be two
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestMemoryAttemptStore_IncrementRestartsAfterWindow(t *testing.T) {
+	// Given: A counter whose window has passed
+	now := time.Now()
+	store := newTestMemoryAttemptStore(&now)
+	ctx := context.Background()
+	store.Increment(ctx, "key", time.Minute)
+	now = now.Add(2 * time.Minute)
+
+	// When: Incrementing again
+	count, _ := store.Increment(ctx, "key", time.Minute)
+
+	// Then: Counting should start over
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemoryAttemptStore_LockExpires(t *testing.T) {
+	// Given: A key locked for one minute
+	now := time.Now()
+	store := newTestMemoryAttemptStore(&now)
+	ctx := context.Background()
+	store.Lock(ctx, "lock", time.Minute)
+
+	// When: Checking the lock before and after it expires
+	before, _ := store.LockedFor(ctx, "lock")
+	now = now.Add(time.Minute)
+	after, _ := store.LockedFor(ctx, "lock")
+
+	// Then: The remaining time should drop to zero
+	assert.Equal(t, time.Minute, before)
+	assert.Equal(t, time.Duration(0), after)
+}
+
+func TestMemoryAttemptStore_Reset(t *testing.T) {
+	// Given: A counter and a lock
+	now := time.Now()
+	store := newTestMemoryAttemptStore(&now)
+	ctx := context.Background()
+	store.Increment(ctx, "count", time.Minute)
+	store.Lock(ctx, "lock", time.Minute)
+
+	// When: Resetting both keys
+	err := store.Reset(ctx, "count", "lock")
+
+	// Then: Both should be gone
+	assert.NoError(t, err)
+	lockedFor, _ := store.LockedFor(ctx, "lock")
+	assert.Equal(t, time.Duration(0), lockedFor)
+	count, _ := store.Increment(ctx, "count", time.Minute)
+	assert.Equal(t, int64(1), count)
+}
@@ -3,12 +3,35 @@ package redis
 import "fmt"
 
 const (
-	KeyGameAll     = "game:all"
-	KeyGameByID    = "game:id:%s"
-	KeyUserByID    = "user:id:%s"
-	KeyUserByEmail = "user:email:%s"
+	KeyGameAll      = "game:all"
+	KeyGameList     = "game:list:%s"
+	KeyGamePlayable = "game:list:playable:%d:%d"
+	KeyGameByID     = "game:id:%s"
+	KeyUserByID     = "user:id:%s"
+	KeyUserByEmail  = "user:email:%s"
+	KeyRevokedJTI   = "token:revoked:%s"
+	KeyLoginFails   = "login:failures:%s"
+	KeyLoginLock    = "login:lock:%s"
 )
 
+// GameListPattern matches every cached page of the game list, whatever
+// filters, sort and page it was requested with.
+const GameListPattern = "game:list:*"
+
+// GamePattern matches every cached game entry: single games, the full list
+// and its pages.
+const GamePattern = "game:*"
+
+func GameListKey(query string) string {
+	return fmt.Sprintf(KeyGameList, query)
+}
+
+// GamePlayableKey sits under GameListPattern, so every game write drops it
+// along with the list pages.
+func GamePlayableKey(players, youngestAge int) string {
+	return fmt.Sprintf(KeyGamePlayable, players, youngestAge)
+}
+
 func GameByIDKey(id string) string {
 	return fmt.Sprintf(KeyGameByID, id)
 }
@@ -24,3 +47,15 @@ func UserByIDKey(id string) string {
 func UserByEmailKey(email string) string {
 	return fmt.Sprintf(KeyUserByEmail, email)
 }
+
+func RevokedTokenKey(jti string) string {
+	return fmt.Sprintf(KeyRevokedJTI, jti)
+}
+
+func LoginFailuresKey(subject string) string {
+	return fmt.Sprintf(KeyLoginFails, subject)
+}
+
+func LoginLockKey(subject string) string {
+	return fmt.Sprintf(KeyLoginLock, subject)
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	FindActiveByUser(ctx context.Context, userID uint) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id, userID uint) (bool, error)
+	TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return gorm.G[models.APIKey](r.db).Create(ctx, key)
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	key, err := gorm.G[models.APIKey](r.db).Where("key_hash = ?", keyHash).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindActiveByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	return gorm.G[models.APIKey](r.db).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Order("created_at DESC").
+		Find(ctx)
+}
+
+// Revoke only matches keys owned by userID, so users cannot revoke each
+// other's keys by guessing IDs.
+func (r *apiKeyRepository) Revoke(ctx context.Context, id, userID uint) (bool, error) {
+	rows, err := gorm.G[models.APIKey](r.db).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update(ctx, "revoked_at", time.Now())
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	_, err := gorm.G[models.APIKey](r.db).Where("id = ?", id).Update(ctx, "last_used_at", usedAt)
+	return err
+}
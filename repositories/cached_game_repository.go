@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -48,6 +49,66 @@ func (r *CachedGameRepository) FindAll(ctx context.Context) ([]models.Game, erro
 	return games, nil
 }
 
+type cachedGamePage struct {
+	Games []models.Game
+	Total int64
+}
+
+func (r *CachedGameRepository) FindPage(ctx context.Context, query models.GameQuery) ([]models.Game, int64, error) {
+	var page cachedGamePage
+	key := redis.GameListKey(query.CacheKey())
+
+	err := r.cache.Get(ctx, key, &page)
+	if err == nil {
+		return page.Games, page.Total, nil
+	}
+
+	if err != goredis.Nil {
+		log.Printf("Cache get error for %s: %v", key, err)
+	}
+
+	games, total, err := r.base.FindPage(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if cacheErr := r.cache.Set(ctx, key, cachedGamePage{Games: games, Total: total}, r.ttl); cacheErr != nil {
+		log.Printf("Cache set error for %s: %v", key, cacheErr)
+	}
+
+	return games, total, nil
+}
+
+func (r *CachedGameRepository) FindPlayable(ctx context.Context, players, youngestAge int) ([]models.Game, error) {
+	var games []models.Game
+	key := redis.GamePlayableKey(players, youngestAge)
+
+	err := r.cache.Get(ctx, key, &games)
+	if err == nil {
+		return games, nil
+	}
+
+	if err != goredis.Nil {
+		log.Printf("Cache get error for %s: %v", key, err)
+	}
+
+	games, err = r.base.FindPlayable(ctx, players, youngestAge)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheErr := r.cache.Set(ctx, key, games, r.ttl); cacheErr != nil {
+		log.Printf("Cache set error for %s: %v", key, cacheErr)
+	}
+
+	return games, nil
+}
+
+// Search is not cached: autocomplete queries rarely repeat.
+func (r *CachedGameRepository) Search(ctx context.Context, query string, limit int) ([]models.Game, string, error) {
+	return r.base.Search(ctx, query, limit)
+}
+
 func (r *CachedGameRepository) FindByID(ctx context.Context, id string) (*models.Game, error) {
 	var game models.Game
 	key := redis.GameByIDKey(id)
@@ -73,6 +134,10 @@ func (r *CachedGameRepository) FindByID(ctx context.Context, id string) (*models
 	return result, nil
 }
 
+func (r *CachedGameRepository) FindByNames(ctx context.Context, names []string) ([]models.Game, error) {
+	return r.base.FindByNames(ctx, names)
+}
+
 func (r *CachedGameRepository) Create(ctx context.Context, game *models.Game) error {
 	if err := r.base.Create(ctx, game); err != nil {
 		return err
@@ -81,32 +146,127 @@ func (r *CachedGameRepository) Create(ctx context.Context, game *models.Game) er
 	if err := r.cache.Delete(ctx, redis.KeyGameAll); err != nil {
 		log.Printf("Cache invalidation error for %s: %v", redis.KeyGameAll, err)
 	}
+	r.invalidateLists(ctx)
+	r.invalidateBaseGames(ctx, game.BaseGameID)
 
 	return nil
 }
 
 func (r *CachedGameRepository) Update(ctx context.Context, game *models.Game) error {
+	previousBase := r.baseGameID(ctx, game.ID)
 	if err := r.base.Update(ctx, game); err != nil {
 		return err
 	}
 
-	key := redis.GameByIDKeyUint(game.ID)
-	if err := r.cache.Delete(ctx, key, redis.KeyGameAll); err != nil {
-		log.Printf("Cache invalidation error: %v", err)
-	}
-
+	invalidateGame(ctx, r.cache, game.ID)
+	r.invalidateBaseGames(ctx, previousBase, game.BaseGameID)
 	return nil
 }
 
 func (r *CachedGameRepository) Delete(ctx context.Context, id uint) error {
+	previousBase := r.baseGameID(ctx, id)
 	if err := r.base.Delete(ctx, id); err != nil {
 		return err
 	}
 
-	key := redis.GameByIDKeyUint(id)
-	if err := r.cache.Delete(ctx, key, redis.KeyGameAll); err != nil {
+	invalidateGame(ctx, r.cache, id)
+	r.invalidateBaseGames(ctx, previousBase)
+	return nil
+}
+
+func (r *CachedGameRepository) SetTags(ctx context.Context, gameID uint, tags []models.Tag) error {
+	if err := r.base.SetTags(ctx, gameID, tags); err != nil {
+		return err
+	}
+
+	invalidateGame(ctx, r.cache, gameID)
+	return nil
+}
+
+// baseGameID reads the stored base game of a game about to change, bypassing
+// the cache, so the base game's list of expansions can be refreshed too.
+func (r *CachedGameRepository) baseGameID(ctx context.Context, id uint) *uint {
+	game, err := r.base.FindByID(ctx, fmt.Sprint(id))
+	if err != nil {
+		return nil
+	}
+	return game.BaseGameID
+}
+
+// invalidateBaseGames drops the cached base games of changed expansions,
+// since a game's response lists its expansions.
+func (r *CachedGameRepository) invalidateBaseGames(ctx context.Context, ids ...*uint) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != nil {
+			keys = append(keys, redis.GameByIDKeyUint(*id))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := r.cache.Delete(ctx, keys...); err != nil {
 		log.Printf("Cache invalidation error: %v", err)
 	}
+}
 
+func (r *CachedGameRepository) Upsert(ctx context.Context, games []*models.Game) error {
+	if err := r.base.Upsert(ctx, games); err != nil {
+		return err
+	}
+
+	r.invalidateGames(ctx, games)
 	return nil
 }
+
+// UpsertEach invalidates the cache once for the whole batch rather than once
+// per game.
+func (r *CachedGameRepository) UpsertEach(ctx context.Context, games []*models.Game) []error {
+	errs := r.base.UpsertEach(ctx, games)
+
+	saved := make([]*models.Game, 0, len(games))
+	for i, game := range games {
+		if errs[i] == nil {
+			saved = append(saved, game)
+		}
+	}
+	if len(saved) > 0 {
+		r.invalidateGames(ctx, saved)
+	}
+	return errs
+}
+
+func (r *CachedGameRepository) invalidateGames(ctx context.Context, games []*models.Game) {
+	keys := make([]string, 0, len(games)+1)
+	for _, game := range games {
+		keys = append(keys, redis.GameByIDKeyUint(game.ID))
+		if game.BaseGameID != nil {
+			keys = append(keys, redis.GameByIDKeyUint(*game.BaseGameID))
+		}
+	}
+	keys = append(keys, redis.KeyGameAll)
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		log.Printf("Cache invalidation error: %v", err)
+	}
+	r.invalidateLists(ctx)
+}
+
+// invalidateLists drops every cached page of the game list, since any write
+// can move a game into or out of a filtered page.
+func (r *CachedGameRepository) invalidateLists(ctx context.Context) {
+	invalidateGameLists(ctx, r.cache)
+}
+
+// invalidateGame drops every cached entry that can contain the game.
+func invalidateGame(ctx context.Context, cache redis.Cache, id uint) {
+	if err := cache.Delete(ctx, redis.GameByIDKeyUint(id), redis.KeyGameAll); err != nil {
+		log.Printf("Cache invalidation error: %v", err)
+	}
+	invalidateGameLists(ctx, cache)
+}
+
+func invalidateGameLists(ctx context.Context, cache redis.Cache) {
+	if err := cache.DeleteByPattern(ctx, redis.GameListPattern); err != nil {
+		log.Printf("Cache invalidation error for %s: %v", redis.GameListPattern, err)
+	}
+}
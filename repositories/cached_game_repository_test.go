@@ -131,6 +131,7 @@ func TestCachedGameRepository_Create_InvalidatesCache(t *testing.T) {
 
 	mockRepo.On("Create", mock.Anything, game).Return(nil)
 	mockCache.On("Delete", mock.Anything, []string{redis.KeyGameAll}).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil)
 
 	ctx := context.Background()
 	err := cachedRepo.Create(ctx, game)
@@ -150,8 +151,10 @@ func TestCachedGameRepository_Update_InvalidatesCache(t *testing.T) {
 	game := &models.Game{Name: "Updated Game"}
 	game.ID = 123
 
+	mockRepo.On("FindByID", mock.Anything, "123").Return(&models.Game{}, nil)
 	mockRepo.On("Update", mock.Anything, game).Return(nil)
 	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(123), redis.KeyGameAll}).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil)
 
 	ctx := context.Background()
 	err := cachedRepo.Update(ctx, game)
@@ -170,8 +173,10 @@ func TestCachedGameRepository_Delete_InvalidatesCache(t *testing.T) {
 
 	gameID := uint(123)
 
+	mockRepo.On("FindByID", mock.Anything, "123").Return(&models.Game{}, nil)
 	mockRepo.On("Delete", mock.Anything, gameID).Return(nil)
 	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(gameID), redis.KeyGameAll}).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil)
 
 	ctx := context.Background()
 	err := cachedRepo.Delete(ctx, gameID)
@@ -181,6 +186,29 @@ func TestCachedGameRepository_Delete_InvalidatesCache(t *testing.T) {
 	mockCache.AssertExpectations(t)
 }
 
+func TestCachedGameRepository_Update_InvalidatesBaseGames(t *testing.T) {
+	// Given: An expansion moving from one base game to another
+	mockRepo := new(mocks.MockGameRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedGameRepository(mockRepo, mockCache, 5*time.Minute)
+	oldBase, newBase := uint(1), uint(2)
+	expansion := models.NewGameBuilder().SetID(9).SetBaseGameID(&newBase).Build()
+
+	mockRepo.On("FindByID", mock.Anything, "9").Return(models.NewGameBuilder().SetID(9).SetBaseGameID(&oldBase).Build(), nil)
+	mockRepo.On("Update", mock.Anything, expansion).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(9), redis.KeyGameAll}).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(oldBase), redis.GameByIDKeyUint(newBase)}).Return(nil)
+
+	// When: Updating it
+	err := cachedRepo.Update(context.Background(), expansion)
+
+	// Then: Both base games are dropped, since they list their expansions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
 func TestCachedGameRepository_FailOpen_OnCacheError(t *testing.T) {
 	mockRepo := new(mocks.MockGameRepository)
 	mockCache := new(mocks.MockCache)
@@ -202,3 +230,171 @@ func TestCachedGameRepository_FailOpen_OnCacheError(t *testing.T) {
 	assert.Equal(t, expectedGames, games)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCachedGameRepository_FindPage_CachesByQuery(t *testing.T) {
+	mockRepo := new(mocks.MockGameRepository)
+	mockCache := new(mocks.MockCache)
+	ttl := 5 * time.Minute
+
+	cachedRepo := NewCachedGameRepository(mockRepo, mockCache, ttl)
+
+	query := models.GameQuery{Category: "Party", Page: 2, Limit: 10}
+	expectedGames := []models.Game{{Name: "Codenames"}}
+	key := redis.GameListKey(query.CacheKey())
+
+	mockCache.On("Get", mock.Anything, key, mock.AnythingOfType("*repositories.cachedGamePage")).
+		Return(goredis.Nil)
+	mockRepo.On("FindPage", mock.Anything, query).Return(expectedGames, int64(11), nil)
+	mockCache.On("Set", mock.Anything, key, cachedGamePage{Games: expectedGames, Total: 11}, ttl).Return(nil)
+
+	games, total, err := cachedRepo.FindPage(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedGames, games)
+	assert.Equal(t, int64(11), total)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedGameRepository_FindPage_CacheHit(t *testing.T) {
+	mockRepo := new(mocks.MockGameRepository)
+	mockCache := new(mocks.MockCache)
+
+	cachedRepo := NewCachedGameRepository(mockRepo, mockCache, 5*time.Minute)
+
+	query := models.GameQuery{Page: 1, Limit: 20}
+	mockCache.On("Get", mock.Anything, redis.GameListKey(query.CacheKey()), mock.AnythingOfType("*repositories.cachedGamePage")).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*cachedGamePage) = cachedGamePage{Games: []models.Game{{Name: "Catan"}}, Total: 1}
+		}).
+		Return(nil)
+
+	games, total, err := cachedRepo.FindPage(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.Len(t, games, 1)
+	assert.Equal(t, int64(1), total)
+	mockRepo.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything)
+}
+
+func TestCachedGameRepository_FindPlayable_CachesByTable(t *testing.T) {
+	mockRepo := new(mocks.MockGameRepository)
+	mockCache := new(mocks.MockCache)
+	ttl := 5 * time.Minute
+
+	cachedRepo := NewCachedGameRepository(mockRepo, mockCache, ttl)
+
+	expectedGames := []models.Game{{Name: "Azul"}}
+	key := redis.GamePlayableKey(4, 8)
+
+	mockCache.On("Get", mock.Anything, key, mock.AnythingOfType("*[]models.Game")).Return(goredis.Nil)
+	mockRepo.On("FindPlayable", mock.Anything, 4, 8).Return(expectedGames, nil)
+	mockCache.On("Set", mock.Anything, key, expectedGames, ttl).Return(nil)
+
+	games, err := cachedRepo.FindPlayable(context.Background(), 4, 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedGames, games)
+	assert.Regexp(t, "^game:list:", key)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedGameRepository_UpsertEach_InvalidatesOnce(t *testing.T) {
+	// Given: A batch where one of three games fails to save
+	mockRepo := new(mocks.MockGameRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedGameRepository(mockRepo, mockCache, 5*time.Minute)
+	games := []*models.Game{
+		models.NewGameBuilder().SetID(1).Build(),
+		models.NewGameBuilder().SetID(2).Build(),
+		models.NewGameBuilder().SetID(3).Build(),
+	}
+
+	mockRepo.On("UpsertEach", mock.Anything, games).Return([]error{nil, errors.New("duplicate"), nil})
+	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(1), redis.GameByIDKeyUint(3), redis.KeyGameAll}).Return(nil).Once()
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil).Once()
+
+	// When: Saving the batch
+	errs := cachedRepo.UpsertEach(context.Background(), games)
+
+	// Then: The saved games and the lists are dropped in one pass
+	assert.Len(t, errs, 3)
+	assert.Error(t, errs[1])
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedGameReviewRepository_Create_InvalidatesGame(t *testing.T) {
+	// Given: A cached review repository
+	mockRepo := new(mocks.MockGameReviewRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedGameReviewRepository(mockRepo, mockCache)
+	review := &models.GameReview{GameID: 7, UserID: 3, Score: 8}
+
+	mockRepo.On("Create", mock.Anything, review).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{redis.GameByIDKeyUint(7), redis.KeyGameAll}).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GameListPattern).Return(nil)
+
+	// When: A review is created
+	err := cachedRepo.Create(context.Background(), review)
+
+	// Then: The game and every cached list should be dropped
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedGameReviewRepository_Delete_KeepsCacheOnError(t *testing.T) {
+	// Given: A review repository that fails to delete
+	mockRepo := new(mocks.MockGameReviewRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedGameReviewRepository(mockRepo, mockCache)
+	review := &models.GameReview{GameID: 7}
+
+	mockRepo.On("Delete", mock.Anything, review).Return(errors.New("database error"))
+
+	// When: Deleting the review
+	err := cachedRepo.Delete(context.Background(), review)
+
+	// Then: The error is returned and the cache is untouched
+	assert.Error(t, err)
+	mockCache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockCache.AssertNotCalled(t, "DeleteByPattern", mock.Anything, mock.Anything)
+}
+
+func TestCachedTagRepository_Update_InvalidatesGames(t *testing.T) {
+	// Given: A cached tag repository
+	mockRepo := new(mocks.MockTagRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedTagRepository(mockRepo, mockCache)
+	tag := &models.Tag{ID: 4, Name: "Cooperative"}
+
+	mockRepo.On("Update", mock.Anything, tag).Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, redis.GamePattern).Return(nil)
+
+	// When: The tag is renamed
+	err := cachedRepo.Update(context.Background(), tag)
+
+	// Then: Every cached game is dropped, since games embed their tags
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedTagRepository_Create_KeepsCache(t *testing.T) {
+	// Given: A cached tag repository
+	mockRepo := new(mocks.MockTagRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedTagRepository(mockRepo, mockCache)
+	tag := &models.Tag{Name: "Dice"}
+
+	mockRepo.On("Create", mock.Anything, tag).Return(nil)
+
+	// When: A tag is created
+	err := cachedRepo.Create(context.Background(), tag)
+
+	// Then: No cached game is touched
+	assert.NoError(t, err)
+	mockCache.AssertNotCalled(t, "DeleteByPattern", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+)
+
+// CachedGameReviewRepository drops the cached copies of a game whenever one of
+// its reviews changes, because the game's rating changes with it. Reviews
+// themselves are not cached.
+type CachedGameReviewRepository struct {
+	base  GameReviewRepository
+	cache redis.Cache
+}
+
+func NewCachedGameReviewRepository(base GameReviewRepository, c redis.Cache) *CachedGameReviewRepository {
+	return &CachedGameReviewRepository{base: base, cache: c}
+}
+
+func (r *CachedGameReviewRepository) Create(ctx context.Context, review *models.GameReview) error {
+	if err := r.base.Create(ctx, review); err != nil {
+		return err
+	}
+	invalidateGame(ctx, r.cache, review.GameID)
+	return nil
+}
+
+func (r *CachedGameReviewRepository) Update(ctx context.Context, review *models.GameReview) error {
+	if err := r.base.Update(ctx, review); err != nil {
+		return err
+	}
+	invalidateGame(ctx, r.cache, review.GameID)
+	return nil
+}
+
+func (r *CachedGameReviewRepository) Delete(ctx context.Context, review *models.GameReview) error {
+	if err := r.base.Delete(ctx, review); err != nil {
+		return err
+	}
+	invalidateGame(ctx, r.cache, review.GameID)
+	return nil
+}
+
+func (r *CachedGameReviewRepository) FindByID(ctx context.Context, id uint) (*models.GameReview, error) {
+	return r.base.FindByID(ctx, id)
+}
+
+func (r *CachedGameReviewRepository) FindByGameID(ctx context.Context, gameID uint) ([]models.GameReview, error) {
+	return r.base.FindByGameID(ctx, gameID)
+}
+
+func (r *CachedGameReviewRepository) FindByUserID(ctx context.Context, userID uint) ([]models.GameReview, error) {
+	return r.base.FindByUserID(ctx, userID)
+}
+
+func (r *CachedGameReviewRepository) FindByGameAndUser(ctx context.Context, gameID, userID uint) (*models.GameReview, error) {
+	return r.base.FindByGameAndUser(ctx, gameID, userID)
+}
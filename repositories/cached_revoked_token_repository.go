@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// revocationLookupTTL bounds how long an answer read from the database is
+// cached. Revoke overwrites a cached "not revoked" straight away, so this
+// only matters when that cache write failed.
+const revocationLookupTTL = time.Minute
+
+type CachedRevokedTokenRepository struct {
+	base  RevokedTokenRepository
+	cache redis.Cache
+}
+
+func NewCachedRevokedTokenRepository(base RevokedTokenRepository, c redis.Cache) *CachedRevokedTokenRepository {
+	return &CachedRevokedTokenRepository{
+		base:  base,
+		cache: c,
+	}
+}
+
+func NewDefaultRevokedTokenRepository(db *gorm.DB) RevokedTokenRepository {
+	base := NewRevokedTokenRepository(db)
+	if redis.Client == nil {
+		return base
+	}
+	return NewCachedRevokedTokenRepository(base, redis.NewRedisCache(redis.Client))
+}
+
+func (r *CachedRevokedTokenRepository) Revoke(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	if err := r.base.Revoke(ctx, jti, userID, expiresAt); err != nil {
+		return err
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := redis.RevokedTokenKey(jti)
+	if err := r.cache.Set(ctx, key, true, ttl); err != nil {
+		log.Printf("Cache set error for %s: %v", key, err)
+	}
+
+	return nil
+}
+
+func (r *CachedRevokedTokenRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	key := redis.RevokedTokenKey(jti)
+
+	err := r.cache.Get(ctx, key, &revoked)
+	if err == nil {
+		return revoked, nil
+	}
+
+	if err != goredis.Nil {
+		log.Printf("Cache get error for %s, falling back to database: %v", key, err)
+		return r.base.IsRevoked(ctx, jti)
+	}
+
+	revoked, err = r.base.IsRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+	if cacheErr := r.cache.Set(ctx, key, revoked, revocationLookupTTL); cacheErr != nil {
+		log.Printf("Cache set error for %s: %v", key, cacheErr)
+	}
+	return revoked, nil
+}
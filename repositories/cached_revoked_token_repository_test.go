@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedRevokedTokenRepository_Revoke_WritesThrough(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockRepo.On("Revoke", mock.Anything, "jti-1", uint(7), expiresAt).Return(nil)
+	mockCache.On("Set", mock.Anything, redis.RevokedTokenKey("jti-1"), true, mock.AnythingOfType("time.Duration")).Return(nil)
+
+	err := cachedRepo.Revoke(context.Background(), "jti-1", 7, expiresAt)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedRevokedTokenRepository_Revoke_ExpiredTokenSkipsCache(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	expiresAt := time.Now().Add(-time.Hour)
+	mockRepo.On("Revoke", mock.Anything, "jti-1", uint(7), expiresAt).Return(nil)
+
+	err := cachedRepo.Revoke(context.Background(), "jti-1", 7, expiresAt)
+
+	assert.NoError(t, err)
+	mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCachedRevokedTokenRepository_Revoke_DatabaseError(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockRepo.On("Revoke", mock.Anything, "jti-1", uint(7), expiresAt).Return(errors.New("db error"))
+
+	err := cachedRepo.Revoke(context.Background(), "jti-1", 7, expiresAt)
+
+	assert.Error(t, err)
+	mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCachedRevokedTokenRepository_IsRevoked_CacheHit(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	mockCache.On("Get", mock.Anything, redis.RevokedTokenKey("jti-1"), mock.AnythingOfType("*bool")).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*bool) = true
+		}).
+		Return(nil)
+
+	revoked, err := cachedRepo.IsRevoked(context.Background(), "jti-1")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	mockRepo.AssertNotCalled(t, "IsRevoked", mock.Anything, mock.Anything)
+}
+
+func TestCachedRevokedTokenRepository_IsRevoked_CacheMiss(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	mockCache.On("Get", mock.Anything, redis.RevokedTokenKey("jti-1"), mock.AnythingOfType("*bool")).Return(goredis.Nil)
+	mockRepo.On("IsRevoked", mock.Anything, "jti-1").Return(true, nil)
+	mockCache.On("Set", mock.Anything, redis.RevokedTokenKey("jti-1"), true, revocationLookupTTL).Return(nil)
+
+	revoked, err := cachedRepo.IsRevoked(context.Background(), "jti-1")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedRevokedTokenRepository_IsRevoked_CacheMissNotRevoked(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	mockCache.On("Get", mock.Anything, redis.RevokedTokenKey("jti-1"), mock.AnythingOfType("*bool")).Return(goredis.Nil)
+	mockRepo.On("IsRevoked", mock.Anything, "jti-1").Return(false, nil)
+	mockCache.On("Set", mock.Anything, redis.RevokedTokenKey("jti-1"), false, revocationLookupTTL).Return(nil)
+
+	revoked, err := cachedRepo.IsRevoked(context.Background(), "jti-1")
+
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCachedRevokedTokenRepository_IsRevoked_CacheMissDatabaseError(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	mockCache.On("Get", mock.Anything, redis.RevokedTokenKey("jti-1"), mock.AnythingOfType("*bool")).Return(goredis.Nil)
+	mockRepo.On("IsRevoked", mock.Anything, "jti-1").Return(false, errors.New("db error"))
+
+	_, err := cachedRepo.IsRevoked(context.Background(), "jti-1")
+
+	assert.Error(t, err)
+	mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCachedRevokedTokenRepository_IsRevoked_FallsBackToDatabase(t *testing.T) {
+	mockRepo := new(mocks.MockRevokedTokenRepository)
+	mockCache := new(mocks.MockCache)
+	cachedRepo := NewCachedRevokedTokenRepository(mockRepo, mockCache)
+
+	mockCache.On("Get", mock.Anything, redis.RevokedTokenKey("jti-1"), mock.AnythingOfType("*bool")).
+		Return(errors.New("connection refused"))
+	mockRepo.On("IsRevoked", mock.Anything, "jti-1").Return(true, nil)
+
+	revoked, err := cachedRepo.IsRevoked(context.Background(), "jti-1")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"log"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+)
+
+// CachedTagRepository drops every cached game when a tag is renamed or
+// deleted, because game responses embed their tags. Tags themselves are not
+// cached.
+type CachedTagRepository struct {
+	base  TagRepository
+	cache redis.Cache
+}
+
+func NewCachedTagRepository(base TagRepository, c redis.Cache) *CachedTagRepository {
+	return &CachedTagRepository{base: base, cache: c}
+}
+
+func (r *CachedTagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	return r.base.FindAll(ctx)
+}
+
+func (r *CachedTagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	return r.base.FindByID(ctx, id)
+}
+
+func (r *CachedTagRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error) {
+	return r.base.FindByIDs(ctx, ids)
+}
+
+func (r *CachedTagRepository) FindByName(ctx context.Context, name string) (*models.Tag, error) {
+	return r.base.FindByName(ctx, name)
+}
+
+// Create leaves the cache alone: a new tag is not on any game yet.
+func (r *CachedTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	return r.base.Create(ctx, tag)
+}
+
+func (r *CachedTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	if err := r.base.Update(ctx, tag); err != nil {
+		return err
+	}
+	r.invalidateGames(ctx)
+	return nil
+}
+
+func (r *CachedTagRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.base.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidateGames(ctx)
+	return nil
+}
+
+func (r *CachedTagRepository) invalidateGames(ctx context.Context) {
+	if err := r.cache.DeleteByPattern(ctx, redis.GamePattern); err != nil {
+		log.Printf("Cache invalidation error for %s: %v", redis.GamePattern, err)
+	}
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	preloadGame      = "Game"
+	preloadOpenLoans = "Loans"
+	openLoanFilter   = "returned_at IS NULL"
+)
+
+type GameCopyRepository interface {
+	Create(ctx context.Context, gameCopy *models.GameCopy) error
+	Update(ctx context.Context, gameCopy *models.GameCopy) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*models.GameCopy, error)
+	FindByBarcode(ctx context.Context, barcode string) (*models.GameCopy, error)
+	FindByGameID(ctx context.Context, gameID uint) ([]models.GameCopy, error)
+}
+
+type gameCopyRepository struct {
+	db *gorm.DB
+}
+
+func NewGameCopyRepository(db *gorm.DB) GameCopyRepository {
+	return &gameCopyRepository{db: db}
+}
+
+func (r *gameCopyRepository) Create(ctx context.Context, gameCopy *models.GameCopy) error {
+	return r.db.WithContext(ctx).Omit(preloadGame).Create(gameCopy).Error
+}
+
+func (r *gameCopyRepository) Update(ctx context.Context, gameCopy *models.GameCopy) error {
+	return r.db.WithContext(ctx).Omit(preloadGame, preloadOpenLoans).Save(gameCopy).Error
+}
+
+// Delete retires the copy unless it is out on loan. The copy's row is
+// locked, as CheckOut does, so it cannot be lent while it is being retired.
+func (r *gameCopyRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockCopy(tx, id); err != nil {
+			return err
+		}
+
+		var open int64
+		if err := tx.Model(&models.Loan{}).Where("copy_id = ? AND "+openLoanFilter, id).Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrCopyOnLoan
+		}
+
+		return tx.Delete(&models.GameCopy{}, id).Error
+	})
+}
+
+// FindByID loads the copy with its game and, in Loans, its open loan if it
+// is checked out.
+func (r *gameCopyRepository) FindByID(ctx context.Context, id uint) (*models.GameCopy, error) {
+	var gameCopy models.GameCopy
+	if err := r.withOpenLoans(ctx).First(&gameCopy, id).Error; err != nil {
+		return nil, err
+	}
+	return &gameCopy, nil
+}
+
+func (r *gameCopyRepository) FindByBarcode(ctx context.Context, barcode string) (*models.GameCopy, error) {
+	var gameCopy models.GameCopy
+	if err := r.withOpenLoans(ctx).Where("barcode = ?", barcode).First(&gameCopy).Error; err != nil {
+		return nil, err
+	}
+	return &gameCopy, nil
+}
+
+func (r *gameCopyRepository) FindByGameID(ctx context.Context, gameID uint) ([]models.GameCopy, error) {
+	var copies []models.GameCopy
+	if err := r.withOpenLoans(ctx).Where("game_id = ?", gameID).Order("id").Find(&copies).Error; err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+func lockCopy(tx *gorm.DB, id uint) error {
+	var gameCopy models.GameCopy
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&gameCopy, id).Error
+}
+
+func (r *gameCopyRepository) withOpenLoans(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload(preloadGame).Preload(preloadOpenLoans, openLoanFilter)
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+)
+
+func applyGameFilters(db *gorm.DB, q models.GameQuery) *gorm.DB {
+	if q.Category != "" {
+		db = db.Where("category = ?", q.Category)
+	}
+	if q.Complexity != "" {
+		db = db.Where("complexity = ?", q.Complexity)
+	}
+	if q.Players != 0 {
+		db = db.Where("min_players <= ? AND max_players >= ?", q.Players, q.Players)
+	}
+	if q.MaxPlaytime != 0 {
+		db = db.Where("playtime_minutes <= ?", q.MaxPlaytime)
+	}
+	if q.MinAge != 0 {
+		db = db.Where("min_age <= ?", q.MinAge)
+	}
+	if q.Publisher != "" {
+		db = db.Where("LOWER(publisher) = ?", strings.ToLower(q.Publisher))
+	}
+	if q.MinYear != 0 {
+		db = db.Where("year_published >= ?", q.MinYear)
+	}
+	if q.MaxYear != 0 {
+		db = db.Where("year_published <= ?", q.MaxYear)
+	}
+	if q.MinRating != 0 {
+		db = db.Where("rating >= ?", q.MinRating)
+	}
+	if q.MaxRating != 0 {
+		db = db.Where("rating <= ?", q.MaxRating)
+	}
+	if len(q.Tags) != 0 {
+		db = db.Where(`id IN (
+			SELECT game_tags.game_id FROM game_tags
+			JOIN tags ON tags.id = game_tags.tag_id
+			WHERE LOWER(tags.name) IN ?
+			GROUP BY game_tags.game_id
+			HAVING COUNT(DISTINCT tags.id) = ?
+		)`, q.Tags, len(q.Tags))
+	}
+	return db
+}
+
+// applyGameSort sorts by the requested columns and then by ID, so pages
+// stay stable when sort values tie.
+func applyGameSort(db *gorm.DB, q models.GameQuery) *gorm.DB {
+	for _, sort := range q.Sort {
+		column, ok := models.GameSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		db = db.Order(fmt.Sprintf("%s %s", column, direction))
+	}
+	return db.Order("id ASC")
+}
@@ -2,51 +2,257 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-const gameWhereIDEquals = "id = ?"
+const (
+	gameWhereIDEquals = "id = ?"
+	preloadTags       = "Tags"
+	preloadExpansions = "Expansions"
+)
+
+var (
+	ErrBaseGameNotFound    = errors.New("base game not found")
+	ErrBaseGameIsExpansion = errors.New("an expansion cannot be the base game of another expansion")
+	ErrBaseGameIsSelf      = errors.New("a game cannot be its own base game")
+	ErrGameHasExpansions   = errors.New("game still has expansions")
+)
 
 type GameRepository interface {
 	FindAll(ctx context.Context) ([]models.Game, error)
+	FindPage(ctx context.Context, query models.GameQuery) ([]models.Game, int64, error)
+	FindPlayable(ctx context.Context, players, youngestAge int) ([]models.Game, error)
+	Search(ctx context.Context, query string, limit int) ([]models.Game, string, error)
 	FindByID(ctx context.Context, id string) (*models.Game, error)
+	FindByNames(ctx context.Context, names []string) ([]models.Game, error)
 	Create(ctx context.Context, game *models.Game) error
 	Update(ctx context.Context, game *models.Game) error
 	Delete(ctx context.Context, id uint) error
+	Upsert(ctx context.Context, games []*models.Game) error
+	UpsertEach(ctx context.Context, games []*models.Game) []error
+	SetTags(ctx context.Context, gameID uint, tags []models.Tag) error
 }
 
 type gameRepository struct {
 	db *gorm.DB
+
+	vocabularyMu sync.Mutex
+	vocabulary   []string
+	vocabularyAt time.Time
 }
 
 func NewGameRepository(db *gorm.DB) GameRepository {
 	return &gameRepository{db: db}
 }
 
+// withGameRelations loads what a game response shows besides the game
+// itself: its tags and its expansions, both by name.
+func withGameRelations(db *gorm.DB) *gorm.DB {
+	byName := func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }
+	return db.Preload(preloadTags, byName).Preload(preloadExpansions, byName)
+}
+
 func (r *gameRepository) FindAll(ctx context.Context) ([]models.Game, error) {
-	return gorm.G[models.Game](r.db).Find(ctx)
+	var games []models.Game
+	err := r.db.WithContext(ctx).Scopes(withGameRelations).Order("id ASC").Find(&games).Error
+	return games, err
 }
 
-func (r *gameRepository) FindByID(ctx context.Context, id string) (*models.Game, error) {
-	game, err := gorm.G[models.Game](r.db).Where(gameWhereIDEquals, id).First(ctx)
+func (r *gameRepository) FindPage(ctx context.Context, query models.GameQuery) ([]models.Game, int64, error) {
+	filtered := applyGameFilters(r.db.WithContext(ctx).Model(&models.Game{}), query)
+
+	var total int64
+	if err := filtered.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var games []models.Game
+	err := applyGameSort(filtered, query).
+		Scopes(withGameRelations).
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&games).Error
 	if err != nil {
+		return nil, 0, err
+	}
+	return games, total, nil
+}
+
+// FindPlayable returns the base games that support the player count and,
+// when youngestAge is set, are rated for players that young.
+func (r *gameRepository) FindPlayable(ctx context.Context, players, youngestAge int) ([]models.Game, error) {
+	filtered := applyGameFilters(r.db.WithContext(ctx).Model(&models.Game{}), models.GameQuery{Players: players, MinAge: youngestAge})
+
+	var games []models.Game
+	err := filtered.Where("base_game_id IS NULL").Scopes(withGameRelations).Order("id ASC").Find(&games).Error
+	return games, err
+}
+
+func (r *gameRepository) FindByID(ctx context.Context, id string) (*models.Game, error) {
+	var game models.Game
+	if err := r.db.WithContext(ctx).Scopes(withGameRelations).Where(gameWhereIDEquals, id).First(&game).Error; err != nil {
 		return nil, err
 	}
 	return &game, nil
 }
 
+// FindByNames matches names case-insensitively, so an import does not
+// create "Catan" next to an existing "CATAN".
+func (r *gameRepository) FindByNames(ctx context.Context, names []string) ([]models.Game, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+	}
+	return gorm.G[models.Game](r.db).Where("LOWER(name) IN ?", lowered).Find(ctx)
+}
+
+// Create refuses an expansion whose base game is missing or is itself an
+// expansion.
 func (r *gameRepository) Create(ctx context.Context, game *models.Game) error {
-	return gorm.G[models.Game](r.db).Create(ctx, game)
+	defer r.forgetSearchVocabulary()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createGame(ctx, tx, game)
+	})
 }
 
+// Update leaves the rating alone: it is only written by the review
+// repository, in the same transaction as the reviews it is computed from.
+// It applies the same base game rules as Create, and a game that has
+// expansions cannot become one.
 func (r *gameRepository) Update(ctx context.Context, game *models.Game) error {
-	_, err := gorm.G[models.Game](r.db).Where(gameWhereIDEquals, game.ID).Updates(ctx, *game)
+	defer r.forgetSearchVocabulary()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateGame(ctx, tx, game)
+	})
+}
+
+// Upsert creates the games without an ID and updates the others, all or
+// nothing. Like Update it never writes the rating.
+func (r *gameRepository) Upsert(ctx context.Context, games []*models.Game) error {
+	defer r.forgetSearchVocabulary()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, game := range games {
+			if err := upsertGame(ctx, tx, game); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertEach writes the games independently and returns one error per game,
+// nil for those that were saved.
+func (r *gameRepository) UpsertEach(ctx context.Context, games []*models.Game) []error {
+	defer r.forgetSearchVocabulary()
+
+	errs := make([]error, len(games))
+	for i, game := range games {
+		errs[i] = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return upsertGame(ctx, tx, game)
+		})
+	}
+	return errs
+}
+
+func upsertGame(ctx context.Context, tx *gorm.DB, game *models.Game) error {
+	if game.ID == 0 {
+		return createGame(ctx, tx, game)
+	}
+	return updateGame(ctx, tx, game)
+}
+
+func createGame(ctx context.Context, tx *gorm.DB, game *models.Game) error {
+	if err := checkBaseGame(tx, game); err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Omit(clause.Associations).Create(game).Error
+}
+
+// updateGame writes the game's non-zero fields and always its base game, so
+// an expansion can be detached.
+func updateGame(ctx context.Context, tx *gorm.DB, game *models.Game) error {
+	if err := checkBaseGame(tx, game); err != nil {
+		return err
+	}
+	if game.BaseGameID != nil {
+		var expansions int64
+		if err := tx.Model(&models.Game{}).Where("base_game_id = ?", game.ID).Count(&expansions).Error; err != nil {
+			return err
+		}
+		if expansions > 0 {
+			return ErrBaseGameIsExpansion
+		}
+	}
+
+	if _, err := gorm.G[models.Game](tx).Omit("rating", "rating_count", clause.Associations).Where(gameWhereIDEquals, game.ID).Updates(ctx, *game); err != nil {
+		return err
+	}
+	_, err := gorm.G[models.Game](tx).Where(gameWhereIDEquals, game.ID).Update(ctx, "base_game_id", game.BaseGameID)
 	return err
 }
 
+// checkBaseGame locks the base game of an expansion so it cannot be deleted
+// or turned into an expansion while the expansion is saved.
+func checkBaseGame(tx *gorm.DB, game *models.Game) error {
+	if game.BaseGameID == nil {
+		return nil
+	}
+	if *game.BaseGameID == game.ID {
+		return ErrBaseGameIsSelf
+	}
+
+	var base models.Game
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "base_game_id").First(&base, *game.BaseGameID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBaseGameNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if base.IsExpansion() {
+		return ErrBaseGameIsExpansion
+	}
+	return nil
+}
+
+// Delete refuses to delete a base game while it still has expansions.
 func (r *gameRepository) Delete(ctx context.Context, id uint) error {
-	_, err := gorm.G[models.Game](r.db).Where(gameWhereIDEquals, id).Delete(ctx)
-	return err
+	defer r.forgetSearchVocabulary()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var game models.Game
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&game, id).Error; err != nil {
+			return err
+		}
+
+		var expansions int64
+		if err := tx.Model(&models.Game{}).Where("base_game_id = ?", id).Count(&expansions).Error; err != nil {
+			return err
+		}
+		if expansions > 0 {
+			return ErrGameHasExpansions
+		}
+
+		_, err := gorm.G[models.Game](tx).Where(gameWhereIDEquals, id).Delete(ctx)
+		return err
+	})
+}
+
+// SetTags replaces the game's tags.
+func (r *gameRepository) SetTags(ctx context.Context, gameID uint, tags []models.Tag) error {
+	game := models.Game{Model: gorm.Model{ID: gameID}}
+	return r.db.WithContext(ctx).Model(&game).Association(preloadTags).Replace(tags)
 }
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"math"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GameReviewRepository writes reviews and the rating of their game in the
+// same transaction, so Game.Rating and Game.RatingCount always match the
+// reviews that exist.
+type GameReviewRepository interface {
+	Create(ctx context.Context, review *models.GameReview) error
+	Update(ctx context.Context, review *models.GameReview) error
+	Delete(ctx context.Context, review *models.GameReview) error
+	FindByID(ctx context.Context, id uint) (*models.GameReview, error)
+	FindByGameID(ctx context.Context, gameID uint) ([]models.GameReview, error)
+	FindByUserID(ctx context.Context, userID uint) ([]models.GameReview, error)
+	FindByGameAndUser(ctx context.Context, gameID, userID uint) (*models.GameReview, error)
+}
+
+type gameReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewGameReviewRepository(db *gorm.DB) GameReviewRepository {
+	return &gameReviewRepository{db: db}
+}
+
+func (r *gameReviewRepository) Create(ctx context.Context, review *models.GameReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Game", "User").Create(review).Error; err != nil {
+			return err
+		}
+		return recalculateGameRating(tx, review.GameID)
+	})
+}
+
+func (r *gameReviewRepository) Update(ctx context.Context, review *models.GameReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Game", "User").Save(review).Error; err != nil {
+			return err
+		}
+		return recalculateGameRating(tx, review.GameID)
+	})
+}
+
+// Delete removes the review for good rather than soft-deleting it, so the
+// member can review the game again without hitting the unique index.
+func (r *gameReviewRepository) Delete(ctx context.Context, review *models.GameReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&models.GameReview{}, review.ID).Error; err != nil {
+			return err
+		}
+		return recalculateGameRating(tx, review.GameID)
+	})
+}
+
+func (r *gameReviewRepository) FindByID(ctx context.Context, id uint) (*models.GameReview, error) {
+	var review models.GameReview
+	if err := r.db.WithContext(ctx).Preload(preloadUser).First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *gameReviewRepository) FindByGameID(ctx context.Context, gameID uint) ([]models.GameReview, error) {
+	var reviews []models.GameReview
+	err := r.db.WithContext(ctx).Preload(preloadUser).
+		Where("game_id = ?", gameID).
+		Order("updated_at DESC, id DESC").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *gameReviewRepository) FindByUserID(ctx context.Context, userID uint) ([]models.GameReview, error) {
+	var reviews []models.GameReview
+	err := r.db.WithContext(ctx).Preload("Game").
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *gameReviewRepository) FindByGameAndUser(ctx context.Context, gameID, userID uint) (*models.GameReview, error) {
+	var review models.GameReview
+	if err := r.db.WithContext(ctx).Where("game_id = ? AND user_id = ?", gameID, userID).First(&review).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// recalculateGameRating stores the average score and number of reviews of a
+// game. It runs inside the transaction that changed the reviews and locks the
+// game row first, so concurrent reviews of one game are averaged in turn and
+// each sees the others' committed scores.
+func recalculateGameRating(tx *gorm.DB, gameID uint) error {
+	var game models.Game
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&game, gameID).Error; err != nil {
+		return err
+	}
+
+	var stats struct {
+		Average float64
+		Count   int
+	}
+	err := tx.Model(&models.GameReview{}).
+		Select("COALESCE(AVG(score), 0) AS average, COUNT(*) AS count").
+		Where("game_id = ?", gameID).
+		Scan(&stats).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Game{}).Where(gameWhereIDEquals, gameID).Updates(map[string]interface{}{
+		"rating":       math.Round(stats.Average*100) / 100,
+		"rating_count": stats.Count,
+	}).Error
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/search"
+	"gorm.io/gorm"
+)
+
+// searchVocabularyTTL is how long the indexed vocabulary is reused for typo
+// correction. Reading it scans the whole index (ts_stat on Postgres), which
+// is too costly to repeat for every autocomplete query that matches nothing.
+// Writes through this repository drop it straight away.
+const searchVocabularyTTL = 5 * time.Minute
+
+// ErrFTS5Unavailable means the SQLite driver was built without the
+// sqlite_fts5 tag.
+var ErrFTS5Unavailable = errors.New("game search needs SQLite with FTS5; build with -tags sqlite_fts5")
+
+// Relative weight of a match in each searchable column, in the order the
+// columns are indexed: name, description, publisher.
+var gameSearchWeights = []float64{10, 1, 4}
+
+// SetupGameSearch creates the full-text index over game names,
+// descriptions and publishers. The database keeps it current on every
+// insert and update: Postgres through a generated tsvector column, SQLite
+// through triggers on an external-content FTS5 table. The SQLite driver
+// only includes FTS5 when built with the sqlite_fts5 tag, as the test script
+// and CI do; without it, setup fails with ErrFTS5Unavailable.
+func SetupGameSearch(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return setupPostgresGameSearch(db)
+	case "sqlite":
+		return setupSQLiteGameSearch(db)
+	}
+	return fmt.Errorf("full-text search is not supported on %s", db.Dialector.Name())
+}
+
+func setupPostgresGameSearch(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE games ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(publisher, '')), 'B') ||
+				setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+			) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_games_search_vector ON games USING GIN (search_vector)`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func setupSQLiteGameSearch(db *gorm.DB) error {
+	var existing int64
+	if err := db.Raw(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'games_fts'`).Scan(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	if err := db.Exec(sqliteFTS5Statements[0]).Error; err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			return ErrFTS5Unavailable
+		}
+		return err
+	}
+
+	for _, statement := range sqliteFTS5Statements[1:] {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return db.Exec(`INSERT INTO games_fts(games_fts) VALUES ('rebuild')`).Error
+}
+
+var sqliteFTS5Statements = []string{
+	`CREATE VIRTUAL TABLE games_fts USING fts5(name, description, publisher, content='games', content_rowid='id', tokenize='unicode61 remove_diacritics 2')`,
+	`CREATE VIRTUAL TABLE games_fts_vocab USING fts5vocab(games_fts, 'row')`,
+	`CREATE TRIGGER games_fts_insert AFTER INSERT ON games BEGIN
+		INSERT INTO games_fts(rowid, name, description, publisher) VALUES (new.id, new.name, new.description, new.publisher);
+	END`,
+	`CREATE TRIGGER games_fts_delete AFTER DELETE ON games BEGIN
+		INSERT INTO games_fts(games_fts, rowid, name, description, publisher) VALUES ('delete', old.id, old.name, old.description, old.publisher);
+	END`,
+	`CREATE TRIGGER games_fts_update AFTER UPDATE ON games BEGIN
+		INSERT INTO games_fts(games_fts, rowid, name, description, publisher) VALUES ('delete', old.id, old.name, old.description, old.publisher);
+		INSERT INTO games_fts(rowid, name, description, publisher) VALUES (new.id, new.name, new.description, new.publisher);
+	END`,
+}
+
+// Search ranks games whose name, description or publisher contain words
+// starting with every term of the query. When nothing matches, the terms
+// are corrected against the indexed vocabulary and the search is retried;
+// the corrected query is returned so it can be shown to the user.
+func (r *gameRepository) Search(ctx context.Context, query string, limit int) ([]models.Game, string, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return []models.Game{}, "", nil
+	}
+
+	db := r.db.WithContext(ctx)
+	games, err := r.searchTerms(db, terms, limit)
+	if err != nil || len(games) > 0 {
+		return games, "", err
+	}
+
+	vocabulary, err := r.cachedSearchVocabulary(db)
+	if err != nil {
+		return nil, "", err
+	}
+	corrected, changed := search.Correct(terms, vocabulary)
+	if !changed {
+		return games, "", nil
+	}
+
+	games, err = r.searchTerms(db, corrected, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return games, strings.Join(corrected, " "), nil
+}
+
+func (r *gameRepository) searchTerms(db *gorm.DB, terms []string, limit int) ([]models.Game, error) {
+	var ids []uint
+
+	if db.Dialector.Name() == "postgres" {
+		prefixes := make([]string, len(terms))
+		for i, term := range terms {
+			prefixes[i] = term + ":*"
+		}
+		err := db.Raw(`
+			SELECT games.id FROM games, to_tsquery('simple', ?) AS query
+			WHERE games.deleted_at IS NULL AND games.search_vector @@ query
+			ORDER BY ts_rank(games.search_vector, query) DESC, games.name ASC
+			LIMIT ?
+		`, strings.Join(prefixes, " & "), limit).Scan(&ids).Error
+		if err != nil {
+			return nil, err
+		}
+		return findGamesInOrder(db, ids)
+	}
+
+	match := strings.Join(terms, "* ") + "*"
+	err := db.Raw(`
+		SELECT games.id FROM games_fts
+		JOIN games ON games.id = games_fts.rowid
+		WHERE games_fts MATCH ? AND games.deleted_at IS NULL
+		ORDER BY bm25(games_fts, ?, ?, ?) ASC, games.name ASC
+		LIMIT ?
+	`, match, gameSearchWeights[0], gameSearchWeights[1], gameSearchWeights[2], limit).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return findGamesInOrder(db, ids)
+}
+
+// findGamesInOrder loads the games with their relations and returns them in
+// the order of ids, which is the search ranking.
+func findGamesInOrder(db *gorm.DB, ids []uint) ([]models.Game, error) {
+	var found []models.Game
+	if err := db.Scopes(withGameRelations).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Game, len(found))
+	for _, game := range found {
+		byID[game.ID] = game
+	}
+	games := make([]models.Game, 0, len(ids))
+	for _, id := range ids {
+		if game, ok := byID[id]; ok {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+func (r *gameRepository) cachedSearchVocabulary(db *gorm.DB) ([]string, error) {
+	r.vocabularyMu.Lock()
+	defer r.vocabularyMu.Unlock()
+
+	if r.vocabulary != nil && time.Since(r.vocabularyAt) < searchVocabularyTTL {
+		return r.vocabulary, nil
+	}
+
+	words, err := r.searchVocabulary(db)
+	if err != nil {
+		return nil, err
+	}
+	if words == nil {
+		words = []string{}
+	}
+	r.vocabulary, r.vocabularyAt = words, time.Now()
+	return words, nil
+}
+
+func (r *gameRepository) forgetSearchVocabulary() {
+	r.vocabularyMu.Lock()
+	defer r.vocabularyMu.Unlock()
+	r.vocabulary = nil
+}
+
+func (r *gameRepository) searchVocabulary(db *gorm.DB) ([]string, error) {
+	var words []string
+	if db.Dialector.Name() == "postgres" {
+		err := db.Raw(`SELECT word FROM ts_stat('SELECT search_vector FROM games WHERE deleted_at IS NULL')`).Scan(&words).Error
+		return words, err
+	}
+	err := db.Raw(`SELECT term FROM games_fts_vocab`).Scan(&words).Error
+	return words, err
+}
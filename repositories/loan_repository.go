@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCopyOnLoan          = errors.New("copy is already on loan")
+	ErrLoanLimitReached    = errors.New("member has reached the loan limit")
+	ErrLoanAlreadyReturned = errors.New("loan has already been returned")
+)
+
+const preloadLoanDetails = "Copy.Game"
+
+type LoanRepository interface {
+	CheckOut(ctx context.Context, loan *models.Loan, maxOpenLoans int) error
+	Return(ctx context.Context, loan *models.Loan, returnedAt time.Time, condition models.CopyCondition) error
+	FindByID(ctx context.Context, id uint) (*models.Loan, error)
+	FindByCopyID(ctx context.Context, copyID uint) ([]models.Loan, error)
+	FindByUserID(ctx context.Context, userID uint) ([]models.Loan, error)
+	FindOverdue(ctx context.Context, now time.Time) ([]models.Loan, error)
+}
+
+type loanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &loanRepository{db: db}
+}
+
+// CheckOut opens the loan unless the copy is already out or the member holds
+// maxOpenLoans loans. The member's row is locked so two desks cannot lend
+// past the limit at the same time, and the copy's row so they cannot lend
+// the same copy twice or lend one that is being retired.
+func (r *loanRepository) CheckOut(ctx context.Context, loan *models.Loan, maxOpenLoans int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, loan.UserID).Error; err != nil {
+			return err
+		}
+		if err := lockCopy(tx, loan.CopyID); err != nil {
+			return err
+		}
+
+		var open int64
+		if err := tx.Model(&models.Loan{}).Where("user_id = ? AND "+openLoanFilter, loan.UserID).Count(&open).Error; err != nil {
+			return err
+		}
+		if open >= int64(maxOpenLoans) {
+			return ErrLoanLimitReached
+		}
+
+		if err := tx.Model(&models.Loan{}).Where("copy_id = ? AND "+openLoanFilter, loan.CopyID).Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrCopyOnLoan
+		}
+
+		return tx.Omit("Copy", "User").Create(loan).Error
+	})
+}
+
+// Return closes the loan and, when a condition is given, records the state
+// the copy came back in.
+func (r *loanRepository) Return(ctx context.Context, loan *models.Loan, returnedAt time.Time, condition models.CopyCondition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Loan{}).
+			Where("id = ? AND "+openLoanFilter, loan.ID).
+			Update("returned_at", returnedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLoanAlreadyReturned
+		}
+
+		if condition != "" {
+			if err := tx.Model(&models.GameCopy{}).Where("id = ?", loan.CopyID).Update("condition", condition).Error; err != nil {
+				return err
+			}
+			loan.Copy.Condition = condition
+		}
+		loan.ReturnedAt = &returnedAt
+		return nil
+	})
+}
+
+func (r *loanRepository) FindByID(ctx context.Context, id uint) (*models.Loan, error) {
+	var loan models.Loan
+	if err := r.withDetails(ctx).First(&loan, id).Error; err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r *loanRepository) FindByCopyID(ctx context.Context, copyID uint) ([]models.Loan, error) {
+	return r.find(r.withDetails(ctx).Where("copy_id = ?", copyID).Order("checked_out_at DESC, id DESC"))
+}
+
+func (r *loanRepository) FindByUserID(ctx context.Context, userID uint) ([]models.Loan, error) {
+	return r.find(r.withDetails(ctx).Where("user_id = ?", userID).Order("checked_out_at DESC, id DESC"))
+}
+
+// FindOverdue lists open loans past their due date, longest overdue first.
+func (r *loanRepository) FindOverdue(ctx context.Context, now time.Time) ([]models.Loan, error) {
+	return r.find(r.withDetails(ctx).Where(openLoanFilter+" AND due_at < ?", now).Order("due_at, id"))
+}
+
+func (r *loanRepository) withDetails(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload(preloadLoanDetails).Preload(preloadUser)
+}
+
+func (r *loanRepository) find(query *gorm.DB) ([]models.Loan, error) {
+	var loans []models.Loan
+	if err := query.Find(&loans).Error; err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+	InvalidateAllForUser(ctx context.Context, userID uint) error
+}
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	return gorm.G[models.PasswordResetToken](r.db).Create(ctx, token)
+}
+
+func (r *passwordResetTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	token, err := gorm.G[models.PasswordResetToken](r.db).Where("token_hash = ?", tokenHash).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed reports whether this call consumed the token, so two concurrent
+// resets with the same token cannot both succeed.
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	rows, err := gorm.G[models.PasswordResetToken](r.db).
+		Where("id = ? AND used_at IS NULL", id).
+		Update(ctx, "used_at", time.Now())
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *passwordResetTokenRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	_, err := gorm.G[models.PasswordResetToken](r.db).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update(ctx, "used_at", time.Now())
+	return err
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrPlayerNotFound = errors.New("a listed player does not exist")
+
+const (
+	preloadPlayGame    = "Game"
+	preloadPlayPlayers = "Players"
+	preloadPlayUsers   = "Players.User"
+
+	// playerPlays joins each participation with its play, leaving out
+	// deleted plays.
+	playerPlays = "JOIN plays ON plays.id = play_players.play_id AND plays.deleted_at IS NULL"
+
+	// ratingHistory selects the plays that move ratings.
+	ratingHistory = "official AND NOT cooperative"
+)
+
+type PlayRepository interface {
+	Create(ctx context.Context, play *models.Play) error
+	FindByID(ctx context.Context, id uint) (*models.Play, error)
+	FindByUserID(ctx context.Context, userID uint) ([]models.Play, error)
+	FindByGameID(ctx context.Context, gameID uint) ([]models.Play, error)
+	FindHistory(ctx context.Context) ([]models.Play, error)
+	HasHistoryAfter(ctx context.Context, playedAt time.Time) (bool, error)
+	Delete(ctx context.Context, play *models.Play) error
+	MostPlayed(ctx context.Context, from, to time.Time, limit int) ([]models.GamePlayCount, error)
+	WinRates(ctx context.Context, userID uint) ([]models.GameWinRate, error)
+	Nemesis(ctx context.Context, userID uint) (*models.Opponent, error)
+}
+
+type playRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayRepository(db *gorm.DB) PlayRepository {
+	return &playRepository{db: db}
+}
+
+// Create stores the play and its participants, who must all be members.
+func (r *playRepository) Create(ctx context.Context, play *models.Play) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userIDs := make([]uint, len(play.Players))
+		for i, player := range play.Players {
+			userIDs[i] = player.UserID
+		}
+		var found int64
+		if err := tx.Model(&models.User{}).Where("id IN ?", userIDs).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(userIDs)) {
+			return ErrPlayerNotFound
+		}
+
+		if err := tx.Omit(clause.Associations).Create(play).Error; err != nil {
+			return err
+		}
+		for i := range play.Players {
+			play.Players[i].PlayID = play.ID
+		}
+		return tx.Omit("User").Create(&play.Players).Error
+	})
+}
+
+func (r *playRepository) FindByID(ctx context.Context, id uint) (*models.Play, error) {
+	var play models.Play
+	if err := r.withDetails(ctx).First(&play, id).Error; err != nil {
+		return nil, err
+	}
+	return &play, nil
+}
+
+// FindByUserID lists the plays a user took part in, latest first.
+func (r *playRepository) FindByUserID(ctx context.Context, userID uint) ([]models.Play, error) {
+	participations := r.db.Model(&models.PlayPlayer{}).Select("play_id").Where("user_id = ?", userID)
+	return r.find(r.withDetails(ctx).Where("id IN (?)", participations))
+}
+
+func (r *playRepository) FindByGameID(ctx context.Context, gameID uint) ([]models.Play, error) {
+	return r.find(r.withDetails(ctx).Where("game_id = ?", gameID))
+}
+
+// FindHistory lists every official competitive play with its players,
+// oldest first, for replaying ratings.
+func (r *playRepository) FindHistory(ctx context.Context) ([]models.Play, error) {
+	var plays []models.Play
+	err := r.db.WithContext(ctx).
+		Preload(preloadPlayPlayers, func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where(ratingHistory).
+		Order("played_at, id").
+		Find(&plays).Error
+	if err != nil {
+		return nil, err
+	}
+	return plays, nil
+}
+
+// HasHistoryAfter reports whether FindHistory holds a play from after
+// playedAt.
+func (r *playRepository) HasHistoryAfter(ctx context.Context, playedAt time.Time) (bool, error) {
+	var later int64
+	err := r.db.WithContext(ctx).Model(&models.Play{}).
+		Where(ratingHistory+" AND played_at > ?", playedAt).
+		Limit(1).
+		Count(&later).Error
+	return later > 0, err
+}
+
+func (r *playRepository) Delete(ctx context.Context, play *models.Play) error {
+	return r.db.WithContext(ctx).Delete(&models.Play{}, play.ID).Error
+}
+
+// MostPlayed counts the plays of each game in [from, to), most played first.
+func (r *playRepository) MostPlayed(ctx context.Context, from, to time.Time, limit int) ([]models.GamePlayCount, error) {
+	var counts []models.GamePlayCount
+	err := r.db.WithContext(ctx).Model(&models.Play{}).
+		Select("plays.game_id, games.name AS game_name, COUNT(*) AS plays, COALESCE(SUM(plays.duration_minutes), 0) AS minutes").
+		Joins("JOIN games ON games.id = plays.game_id").
+		Where("plays.played_at >= ? AND plays.played_at < ?", from, to).
+		Group("plays.game_id, games.name").
+		Order("plays DESC, minutes DESC, games.name").
+		Limit(limit).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// WinRates counts, for every game the user played, their plays and wins.
+func (r *playRepository) WinRates(ctx context.Context, userID uint) ([]models.GameWinRate, error) {
+	var rates []models.GameWinRate
+	err := r.db.WithContext(ctx).Model(&models.PlayPlayer{}).
+		Select("plays.game_id, games.name AS game_name, COUNT(*) AS plays, SUM(CASE WHEN play_players.winner THEN 1 ELSE 0 END) AS wins").
+		Joins(playerPlays).
+		Joins("JOIN games ON games.id = plays.game_id").
+		Where("play_players.user_id = ?", userID).
+		Group("plays.game_id, games.name").
+		Order("plays DESC, games.name").
+		Scan(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Nemesis finds the opponent who most often won a competitive play the user
+// lost. It returns nil when nobody has beaten the user yet.
+func (r *playRepository) Nemesis(ctx context.Context, userID uint) (*models.Opponent, error) {
+	var opponents []models.Opponent
+	err := r.db.WithContext(ctx).Model(&models.PlayPlayer{}).
+		Select("opponent.user_id, users.first_name, users.last_name, COUNT(*) AS plays, "+
+			"SUM(CASE WHEN opponent.winner AND NOT play_players.winner THEN 1 ELSE 0 END) AS losses").
+		Joins(playerPlays+" AND NOT plays.cooperative").
+		Joins("JOIN play_players opponent ON opponent.play_id = play_players.play_id AND opponent.user_id <> play_players.user_id").
+		Joins("JOIN users ON users.id = opponent.user_id").
+		Where("play_players.user_id = ?", userID).
+		Group("opponent.user_id, users.first_name, users.last_name").
+		Having("SUM(CASE WHEN opponent.winner AND NOT play_players.winner THEN 1 ELSE 0 END) > 0").
+		Order("losses DESC, plays, opponent.user_id").
+		Limit(1).
+		Scan(&opponents).Error
+	if err != nil || len(opponents) == 0 {
+		return nil, err
+	}
+	return &opponents[0], nil
+}
+
+func (r *playRepository) withDetails(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload(preloadPlayGame).
+		Preload(preloadPlayPlayers, func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload(preloadPlayUsers)
+}
+
+func (r *playRepository) find(query *gorm.DB) ([]models.Play, error) {
+	var plays []models.Play
+	if err := query.Order("played_at DESC, id DESC").Find(&plays).Error; err != nil {
+		return nil, err
+	}
+	return plays, nil
+}
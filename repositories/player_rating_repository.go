@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const playerRatingBatchSize = 500
+
+var playerRatingConflict = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "user_id"}, {Name: "game_id"}},
+	DoUpdates: clause.AssignmentColumns([]string{"algorithm", "rating", "deviation", "volatility", "plays", "updated_at"}),
+}
+
+type PlayerRatingRepository interface {
+	Apply(ctx context.Context, gameID uint, userIDs []uint, rate func(current []models.PlayerRating) []models.PlayerRating) error
+	ReplaceAll(ctx context.Context, ratings []models.PlayerRating) error
+	FindLeaderboard(ctx context.Context, gameID uint, algorithm string, limit int) ([]models.PlayerRating, error)
+	FindByUserID(ctx context.Context, userID uint, algorithm string) ([]models.PlayerRating, error)
+}
+
+type playerRatingRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerRatingRepository(db *gorm.DB) PlayerRatingRepository {
+	return &playerRatingRepository{db: db}
+}
+
+// Apply loads the players' ratings at the game and their global ratings,
+// locked so that two plays cannot rate from the same starting point, and
+// stores what rate makes of them.
+func (r *playerRatingRepository) Apply(ctx context.Context, gameID uint, userIDs []uint, rate func(current []models.PlayerRating) []models.PlayerRating) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []models.PlayerRating
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id IN ? AND game_id IN ?", userIDs, []uint{gameID, models.GlobalRatingGameID}).
+			Find(&current).Error
+		if err != nil {
+			return err
+		}
+
+		updated := rate(current)
+		if len(updated) == 0 {
+			return nil
+		}
+		return tx.Omit("User").Clauses(playerRatingConflict).Create(&updated).Error
+	})
+}
+
+// ReplaceAll swaps every stored rating for the given ones, as after
+// recalculating from history.
+func (r *playerRatingRepository) ReplaceAll(ctx context.Context, ratings []models.PlayerRating) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.PlayerRating{}).Error; err != nil {
+			return err
+		}
+		if len(ratings) == 0 {
+			return nil
+		}
+		return tx.Omit("User").CreateInBatches(&ratings, playerRatingBatchSize).Error
+	})
+}
+
+// FindLeaderboard lists the best rated players of a game, or globally for
+// models.GlobalRatingGameID. Deleted accounts are left out, since replaying
+// history rates them again from the plays they took part in.
+func (r *playerRatingRepository) FindLeaderboard(ctx context.Context, gameID uint, algorithm string, limit int) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+	err := r.db.WithContext(ctx).Preload(preloadUser).
+		Where("game_id = ? AND algorithm = ?", gameID, algorithm).
+		Where("user_id IN (?)", r.db.Model(&models.User{}).Select("id")).
+		Order("rating DESC, plays DESC, user_id").
+		Limit(limit).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+// FindByUserID lists a player's ratings with the names of their games, the
+// global rating first.
+func (r *playerRatingRepository) FindByUserID(ctx context.Context, userID uint, algorithm string) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+	err := r.db.WithContext(ctx).
+		Select("player_ratings.*, games.name AS game_name").
+		Joins("LEFT JOIN games ON games.id = player_ratings.game_id").
+		Where("player_ratings.user_id = ? AND player_ratings.algorithm = ?", userID, algorithm).
+		Order("player_ratings.game_id = 0 DESC, player_ratings.rating DESC, games.name").
+		Find(&ratings).Error
+	if err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
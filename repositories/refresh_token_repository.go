@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenAlreadyRevoked is returned by Revoke when another request
+// revoked the token first, which means it was presented twice.
+var ErrRefreshTokenAlreadyRevoked = errors.New("refresh token is already revoked")
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	return gorm.G[models.RefreshToken](r.db).Create(ctx, token)
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	token, err := gorm.G[models.RefreshToken](r.db).Where("token_hash = ?", tokenHash).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id uint) error {
+	rows, err := gorm.G[models.RefreshToken](r.db).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update(ctx, "revoked_at", time.Now())
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRefreshTokenAlreadyRevoked
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := gorm.G[models.RefreshToken](r.db).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update(ctx, "revoked_at", time.Now())
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint) error {
+	_, err := gorm.G[models.RefreshToken](r.db).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update(ctx, "revoked_at", time.Now())
+	return err
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	FindAll(ctx context.Context) ([]models.Tag, error)
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error)
+	FindByName(ctx context.Context, name string) (*models.Tag, error)
+	Create(ctx context.Context, tag *models.Tag) error
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	return gorm.G[models.Tag](r.db).Order("name ASC").Find(ctx)
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	tag, err := gorm.G[models.Tag](r.db).Where("id = ?", id).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error) {
+	if len(ids) == 0 {
+		return []models.Tag{}, nil
+	}
+	return gorm.G[models.Tag](r.db).Where("id IN ?", ids).Order("name ASC").Find(ctx)
+}
+
+// FindByName ignores case, so "Co-op" and "co-op" are the same tag.
+func (r *tagRepository) FindByName(ctx context.Context, name string) (*models.Tag, error) {
+	tag, err := gorm.G[models.Tag](r.db).Where("LOWER(name) = ?", strings.ToLower(name)).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	return gorm.G[models.Tag](r.db).Create(ctx, tag)
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	_, err := gorm.G[models.Tag](r.db).Where("id = ?", tag.ID).Updates(ctx, models.Tag{Name: tag.Name})
+	return err
+}
+
+// Delete removes the tag from every game before deleting it.
+func (r *tagRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM game_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		_, err := gorm.G[models.Tag](tx).Where("id = ?", id).Delete(ctx)
+		return err
+	})
+}
@@ -2,12 +2,24 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-const tournamentWhereIDEquals = "id = ?"
+const (
+	tournamentWhereIDEquals = "id = ?"
+	tournamentTeams         = "Teams"
+)
+
+var (
+	ErrTournamentNotUpcoming = errors.New("tournament is no longer upcoming")
+	ErrTeamAlreadyRegistered = errors.New("team is already registered")
+	ErrTeamNotRegistered     = errors.New("team is not registered")
+)
 
 type TournamentRepository interface {
 	FindAll(ctx context.Context) ([]models.Tournament, error)
@@ -15,6 +27,8 @@ type TournamentRepository interface {
 	Create(ctx context.Context, tournament *models.Tournament) error
 	Update(ctx context.Context, tournament *models.Tournament) error
 	Delete(ctx context.Context, id int) error
+	AddTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error
+	RemoveTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error
 }
 
 type tournamentRepository struct {
@@ -26,11 +40,11 @@ func NewTournamentRepository(db *gorm.DB) TournamentRepository {
 }
 
 func (r *tournamentRepository) FindAll(ctx context.Context) ([]models.Tournament, error) {
-	return gorm.G[models.Tournament](r.db).Preload("Game", nil).Find(ctx)
+	return gorm.G[models.Tournament](r.db).Preload("Game", nil).Preload(tournamentTeams, orderTeamsByName).Find(ctx)
 }
 
 func (r *tournamentRepository) FindByID(ctx context.Context, id int) (*models.Tournament, error) {
-	tournament, err := gorm.G[models.Tournament](r.db).Preload("Game", nil).Where(tournamentWhereIDEquals, id).First(ctx)
+	tournament, err := gorm.G[models.Tournament](r.db).Preload("Game", nil).Preload(tournamentTeams, orderTeamsByName).Where(tournamentWhereIDEquals, id).First(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +64,52 @@ func (r *tournamentRepository) Delete(ctx context.Context, id int) error {
 	_, err := gorm.G[models.Tournament](r.db).Where(tournamentWhereIDEquals, id).Delete(ctx)
 	return err
 }
+
+// AddTeam registers a team. The tournament row is locked and its status and
+// start date re-read, so a team cannot slip in as the tournament starts.
+// Nothing moves the status on by itself, so a start date that has passed
+// closes registration too.
+func (r *tournamentRepository) AddTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error {
+	return r.changeTeams(ctx, tournament, team, func(tx *gorm.DB, registered bool) error {
+		if registered {
+			return ErrTeamAlreadyRegistered
+		}
+		return tx.Model(tournament).Association(tournamentTeams).Append(team)
+	})
+}
+
+// RemoveTeam withdraws a team, under the same rules as AddTeam.
+func (r *tournamentRepository) RemoveTeam(ctx context.Context, tournament *models.Tournament, team *models.Team) error {
+	return r.changeTeams(ctx, tournament, team, func(tx *gorm.DB, registered bool) error {
+		if !registered {
+			return ErrTeamNotRegistered
+		}
+		return tx.Model(tournament).Association(tournamentTeams).Delete(team)
+	})
+}
+
+func (r *tournamentRepository) changeTeams(ctx context.Context, tournament *models.Tournament, team *models.Team, change func(tx *gorm.DB, registered bool) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Tournament
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status", "start_date").First(&current, tournament.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != models.StatusUpcoming || !current.StartDate.After(time.Now()) {
+			return ErrTournamentNotUpcoming
+		}
+
+		var registered int64
+		err := tx.Table("team_tournaments").
+			Where("tournament_id = ? AND team_id = ?", tournament.ID, team.ID).
+			Count(&registered).Error
+		if err != nil {
+			return err
+		}
+		return change(tx, registered > 0)
+	})
+}
+
+func orderTeamsByName(db gorm.PreloadBuilder) error {
+	db.Order("name")
+	return nil
+}
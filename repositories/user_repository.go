@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"gorm.io/gorm"
@@ -15,6 +17,9 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	FindAll(ctx context.Context) ([]models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	UpdateFields(ctx context.Context, id uint, fields map[string]interface{}) error
+	FindForExport(ctx context.Context, id uint) (*models.User, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type userRepository struct {
@@ -53,3 +58,65 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	_, err := gorm.G[models.User](r.db).Where(userWhereIDEquals, user.ID).Updates(ctx, *user)
 	return err
 }
+
+// UpdateFields writes the given columns even when they hold zero values,
+// which Update skips.
+func (r *userRepository) UpdateFields(ctx context.Context, id uint, fields map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where(userWhereIDEquals, id).Updates(fields).Error
+}
+
+// FindForExport loads the user together with their teams and news.
+func (r *userRepository) FindForExport(ctx context.Context, id uint) (*models.User, error) {
+	user, err := gorm.G[models.User](r.db).Preload("Teams", nil).Preload("News", nil).Where(userWhereIDEquals, id).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Delete anonymizes and soft-deletes the user in one transaction. News and
+// comments stay in place but point at the anonymized row, so no foreign key
+// is left dangling and nothing relies on ON DELETE CASCADE, which SQLite
+// only enforces when foreign keys are switched on. Credentials, sessions,
+// friendships, team memberships and ratings are removed outright.
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&models.RefreshToken{},
+			&models.PasswordResetToken{},
+			&models.RecoveryCode{},
+			&models.UserIdentity{},
+			&models.APIKey{},
+			&models.PlayerRating{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("sender_id = ? OR receiver_id = ?", id, id).Delete(&models.FriendRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM user_teams WHERE user_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		anonymized := map[string]interface{}{
+			"first_name":          "Deleted",
+			"last_name":           "User",
+			"email":               fmt.Sprintf("deleted-%d@users.invalid", id),
+			"password":            "",
+			"email_verified":      false,
+			"email_verified_at":   nil,
+			"totp_secret":         "",
+			"totp_enabled":        false,
+			"totp_last_step":      0,
+			"sessions_revoked_at": now,
+		}
+		if err := tx.Model(&models.User{}).Where(userWhereIDEquals, id).Updates(anonymized).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.User{}, id).Error
+	})
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	adminBasePath        = "/admin"
+	adminUserRolePath    = "/users/:id/role"
+	adminUserLockoutPath = "/users/:id/lockout"
+	adminAuditPath       = "/audit"
+	adminImpersonatePath = "/users/:id/impersonate"
+	adminRatingsPath     = "/ratings/recalculate"
+)
+
+func SetupAdminRoutes(api fiber.Router, db *gorm.DB) {
+	userHandler := handlers.NewUserHandler(db)
+	authHandler := handlers.NewAuthHandler(db)
+	auditHandler := handlers.NewAuditHandler(db)
+	ratingHandler := handlers.NewRatingHandler(db)
+
+	admin := api.Group(adminBasePath, middleware.JWTMiddleware(db), middleware.RequireRole(models.RoleAdmin))
+	admin.Put(adminUserRolePath, userHandler.UpdateUserRole)
+	admin.Delete(adminUserLockoutPath, authHandler.UnlockUser)
+	admin.Post(adminImpersonatePath, userHandler.ImpersonateUser)
+	admin.Get(adminAuditPath, auditHandler.GetAuditEvents)
+	admin.Post(adminRatingsPath, ratingHandler.RecalculateRatings)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeysBasePath = "/api-keys"
+	apiKeysByIDPath = apiKeysBasePath + "/:id"
+)
+
+// API keys can only be managed from an interactive session, never with
+// another API key, and an impersonating admin cannot mint keys that would
+// outlive the impersonation.
+func SetupAPIKeyRoutes(api fiber.Router, db *gorm.DB) {
+	apiKeyHandler := handlers.NewAPIKeyHandler(db)
+
+	authRequired := middleware.JWTMiddleware(db)
+	ownerOnly := middleware.DenyImpersonation()
+	api.Get(apiKeysBasePath, authRequired, apiKeyHandler.GetAPIKeys)
+	api.Post(apiKeysBasePath, authRequired, ownerOnly, apiKeyHandler.CreateAPIKey)
+	api.Delete(apiKeysByIDPath, authRequired, ownerOnly, apiKeyHandler.RevokeAPIKey)
+}
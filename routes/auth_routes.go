@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
 	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -11,5 +12,25 @@ func SetupAuthRoutes(api fiber.Router, db *gorm.DB) {
 	authHandler := handlers.NewAuthHandler(db)
 	api.Post("/auth/register", authHandler.Register)
 	api.Post("/auth/login", authHandler.Login)
-	api.Get("/auth/me", middleware.JWTMiddleware(db), authHandler.GetCurrentUser)
+	api.Post("/auth/refresh", authHandler.Refresh)
+	api.Post("/auth/verify-email", authHandler.VerifyEmail)
+	api.Post("/auth/forgot-password", authHandler.ForgotPassword)
+	api.Post("/auth/reset-password", authHandler.ResetPassword)
+	api.Post("/auth/mfa/verify", authHandler.VerifyMFA)
+
+	oidcHandler := handlers.NewOIDCHandler(db)
+	api.Get("/auth/oidc/:provider/login", oidcHandler.Login)
+	api.Get("/auth/oidc/:provider/callback", oidcHandler.Callback)
+
+	authRequired := middleware.JWTMiddleware(db)
+	ownerOnly := middleware.DenyImpersonation()
+	api.Get("/auth/me", authRequired, authHandler.GetCurrentUser)
+	api.Post("/auth/logout", authRequired, authHandler.Logout)
+	api.Post("/auth/logout-all", authRequired, ownerOnly, authHandler.LogoutAll)
+	api.Post("/auth/resend-verification", authRequired, authHandler.ResendVerification)
+
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	api.Post("/auth/mfa/enroll", authRequired, ownerOnly, organizerOnly, authHandler.EnrollMFA)
+	api.Post("/auth/mfa/confirm", authRequired, ownerOnly, organizerOnly, authHandler.ConfirmMFA)
+	api.Post("/auth/mfa/disable", authRequired, ownerOnly, authHandler.DisableMFA)
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -10,9 +11,10 @@ import (
 func SetupCommentRoutes(api fiber.Router, db *gorm.DB) {
 	commentHandler := handlers.NewCommentHandler(db)
 
+	authRequired := middleware.JWTMiddleware(db)
 	comments := api.Group("/comments")
-	comments.Post("/", commentHandler.CreateComment)
-	comments.Put("/:id", commentHandler.UpdateComment)
+	comments.Post("/", authRequired, commentHandler.CreateComment)
+	comments.Put("/:id", authRequired, commentHandler.UpdateComment)
 
 	api.Get("/users/:id/comments", commentHandler.GetCommentsByUserID)
 
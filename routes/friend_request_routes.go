@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -17,8 +18,8 @@ const (
 func SetupFriendRequestRoutes(api fiber.Router, db *gorm.DB) {
 	friendRequestHandler := handlers.NewFriendRequestHandler(db)
 
-	friendRequests := api.Group(friendRequestsBasePath)
-	friendRequests.Post("/", friendRequestHandler.CreateFriendRequest)
+	friendRequests := api.Group(friendRequestsBasePath, middleware.JWTMiddleware(db))
+	friendRequests.Post("/", middleware.RequireVerifiedEmail(), friendRequestHandler.CreateFriendRequest)
 	friendRequests.Put(friendRequestsByIDPath+"/accept", friendRequestHandler.AcceptFriendRequest)
 	friendRequests.Put(friendRequestsByIDPath+"/decline", friendRequestHandler.DeclineFriendRequest)
 	friendRequests.Delete(friendRequestsByIDPath, friendRequestHandler.DeleteFriendRequest)
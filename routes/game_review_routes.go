@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	gameReviewsPath = gamesByIDPath + "/reviews"
+	reviewByIDPath  = "/reviews/:id"
+)
+
+func SetupGameReviewRoutes(api fiber.Router, db *gorm.DB) {
+	var reviewRepo repositories.GameReviewRepository = repositories.NewGameReviewRepository(db)
+	if redis.Client != nil {
+		reviewRepo = repositories.NewCachedGameReviewRepository(reviewRepo, redis.NewRedisCache(redis.Client))
+	}
+	reviewHandler := handlers.NewGameReviewHandlerWithRepo(reviewRepo, repositories.NewGameRepository(db))
+
+	authRequired := middleware.JWTMiddleware(db)
+	api.Get(gameReviewsPath, reviewHandler.GetGameReviews)
+	api.Post(gameReviewsPath, authRequired, reviewHandler.CreateGameReview)
+	api.Put(reviewByIDPath, authRequired, reviewHandler.UpdateGameReview)
+	api.Delete(reviewByIDPath, authRequired, reviewHandler.DeleteGameReview)
+}
@@ -3,6 +3,8 @@ package routes
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/config"
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
 	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
 	"github.com/gofiber/fiber/v2"
@@ -10,25 +12,38 @@ import (
 )
 
 const (
-	gamesBasePath = "/games"
-	gamesByIDPath = gamesBasePath + "/:id"
+	gamesBasePath      = "/games"
+	gamesSearchPath    = gamesBasePath + "/search"
+	gamesRecommendPath = gamesBasePath + "/recommend"
+	gamesByIDPath      = gamesBasePath + "/:id"
+	gamesExportPath    = gamesBasePath + "/export"
+	gamesImportPath    = gamesBasePath + "/import"
+	gamesBGGPath       = gamesImportPath + "/bgg"
 )
 
 func SetupGameRoutes(api fiber.Router, db *gorm.DB, cfg *config.Config) {
-	baseRepo := repositories.NewGameRepository(db)
-
-	var gameHandler *handlers.GameHandler
+	var gameRepo repositories.GameRepository = repositories.NewGameRepository(db)
+	var reviewRepo repositories.GameReviewRepository = repositories.NewGameReviewRepository(db)
 	if redis.Client != nil {
 		redisCache := redis.NewRedisCache(redis.Client)
-		cachedRepo := repositories.NewCachedGameRepository(baseRepo, redisCache, cfg.CacheTTL)
-		gameHandler = handlers.NewGameHandlerWithRepo(cachedRepo)
-	} else {
-		gameHandler = handlers.NewGameHandlerWithRepo(baseRepo)
+		gameRepo = repositories.NewCachedGameRepository(gameRepo, redisCache, cfg.CacheTTL)
+		reviewRepo = repositories.NewCachedGameReviewRepository(reviewRepo, redisCache)
 	}
+	gameHandler := handlers.NewGameHandlerWithRepo(gameRepo)
+	recommendationHandler := handlers.NewRecommendationHandlerWithRepo(gameRepo, reviewRepo)
 
 	api.Get(gamesBasePath, gameHandler.GetAllGames)
+	api.Get(gamesSearchPath, gameHandler.SearchGames)
+	api.Get(gamesExportPath, gameHandler.ExportGames)
+	api.Get(gamesRecommendPath, recommendationHandler.RecommendGames)
 	api.Get(gamesByIDPath, gameHandler.GetGameByID)
-	api.Post(gamesBasePath, gameHandler.CreateGame)
-	api.Put(gamesByIDPath, gameHandler.UpdateGame)
-	api.Delete(gamesByIDPath, gameHandler.DeleteGame)
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeGamesWrite)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	api.Post(gamesBasePath, authRequired, canWrite, organizerOnly, gameHandler.CreateGame)
+	api.Post(gamesImportPath, authRequired, canWrite, organizerOnly, gameHandler.ImportGames)
+	api.Post(gamesBGGPath, authRequired, canWrite, organizerOnly, gameHandler.ImportBGGGames)
+	api.Put(gamesByIDPath, authRequired, canWrite, organizerOnly, gameHandler.UpdateGame)
+	api.Delete(gamesByIDPath, authRequired, canWrite, organizerOnly, gameHandler.DeleteGame)
 }
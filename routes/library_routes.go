@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	gameCopiesPath   = gamesByIDPath + "/copies"
+	copyByIDPath     = "/copies/:id"
+	copyLoansPath    = copyByIDPath + "/loans"
+	loansBasePath    = "/loans"
+	loansOverduePath = loansBasePath + "/overdue"
+	loanReturnPath   = loansBasePath + "/:id/return"
+	userLoansPath    = usersByIDPath + "/loans"
+)
+
+func SetupLibraryRoutes(api fiber.Router, db *gorm.DB, cfg *config.Config) {
+	policy := handlers.DefaultLoanPolicy
+	if cfg.LibraryMaxLoans > 0 {
+		policy.MaxOpenLoans = cfg.LibraryMaxLoans
+	}
+	if cfg.LibraryLoanPeriod > 0 {
+		policy.LoanPeriod = cfg.LibraryLoanPeriod
+	}
+	libraryHandler := handlers.NewLibraryHandler(db, policy)
+
+	authRequired := middleware.JWTMiddleware(db)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+
+	api.Get(gameCopiesPath, libraryHandler.GetGameCopies)
+	api.Post(gameCopiesPath, authRequired, organizerOnly, libraryHandler.CreateGameCopy)
+	api.Get(copyByIDPath, libraryHandler.GetGameCopy)
+	api.Put(copyByIDPath, authRequired, organizerOnly, libraryHandler.UpdateGameCopy)
+	api.Delete(copyByIDPath, authRequired, organizerOnly, libraryHandler.DeleteGameCopy)
+	api.Get(copyLoansPath, authRequired, organizerOnly, libraryHandler.GetCopyLoans)
+
+	api.Post(loansBasePath, authRequired, organizerOnly, libraryHandler.CheckOut)
+	api.Get(loansOverduePath, authRequired, organizerOnly, libraryHandler.GetOverdueLoans)
+	api.Post(loanReturnPath, authRequired, organizerOnly, libraryHandler.ReturnLoan)
+	api.Get(userLoansPath, authRequired, libraryHandler.GetUserLoans)
+}
@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -14,7 +16,12 @@ const (
 func SetupNewsRoutes(api fiber.Router, db *gorm.DB) {
 	newsHandler := handlers.NewNewsHandler(db)
 	api.Get(newsBasePath, newsHandler.GetNews)
-	api.Post(newsBasePath, newsHandler.CreateNews)
-	api.Put(newsByIDPath, newsHandler.UpdateNews)
-	api.Delete(newsByIDPath, newsHandler.DeleteNews)
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeNewsWrite)
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+	verifiedOnly := middleware.RequireVerifiedEmail()
+	api.Post(newsBasePath, authRequired, canWrite, adminOnly, verifiedOnly, newsHandler.CreateNews)
+	api.Put(newsByIDPath, authRequired, canWrite, adminOnly, newsHandler.UpdateNews)
+	api.Delete(newsByIDPath, authRequired, canWrite, adminOnly, newsHandler.DeleteNews)
 }
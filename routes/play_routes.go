@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	playsBasePath       = "/plays"
+	playsMostPlayedPath = playsBasePath + "/most-played"
+	playByIDPath        = playsBasePath + "/:id"
+	gamePlaysPath       = gamesByIDPath + "/plays"
+	userPlaysPath       = usersByIDPath + "/plays"
+	userWinRatesPath    = usersByIDPath + "/win-rates"
+	userNemesisPath     = usersByIDPath + "/nemesis"
+	gameLeaderboardPath = gamesByIDPath + "/leaderboard"
+	userRatingsPath     = usersByIDPath + "/ratings"
+)
+
+func SetupPlayRoutes(api fiber.Router, db *gorm.DB) {
+	playHandler := handlers.NewPlayHandler(db)
+	ratingHandler := handlers.NewRatingHandler(db)
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeResultsWrite)
+
+	api.Post(playsBasePath, authRequired, canWrite, playHandler.CreatePlay)
+	api.Get(playsMostPlayedPath, playHandler.GetMostPlayed)
+	api.Get(playByIDPath, playHandler.GetPlay)
+	api.Delete(playByIDPath, authRequired, canWrite, playHandler.DeletePlay)
+	api.Get(gamePlaysPath, playHandler.GetGamePlays)
+	api.Get(userPlaysPath, playHandler.GetUserPlays)
+	api.Get(userWinRatesPath, playHandler.GetUserWinRates)
+	api.Get(userNemesisPath, playHandler.GetUserNemesis)
+	api.Get(gameLeaderboardPath, ratingHandler.GetGameLeaderboard)
+	api.Get(userRatingsPath, ratingHandler.GetUserRatings)
+}
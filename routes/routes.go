@@ -7,14 +7,22 @@ import (
 )
 
 func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config) {
+	SetupWellKnownRoutes(app)
+
 	api := app.Group("/api")
 
 	SetupAuthRoutes(api, db)
 	SetupUserRoutes(api, db)
 	SetupGameRoutes(api, db, cfg)
+	SetupGameReviewRoutes(api, db)
+	SetupTagRoutes(api, db, cfg)
+	SetupLibraryRoutes(api, db, cfg)
+	SetupPlayRoutes(api, db)
 	SetupTeamRoutes(api, db)
 	SetupTournamentRoutes(api, db)
 	SetupNewsRoutes(api, db)
 	SetupCommentRoutes(api, db)
 	SetupFriendRequestRoutes(api, db)
+	SetupAdminRoutes(api, db)
+	SetupAPIKeyRoutes(api, db)
 }
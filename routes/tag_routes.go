@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	tagsBasePath = "/tags"
+	tagByIDPath  = tagsBasePath + "/:id"
+	gameTagsPath = gamesByIDPath + "/tags"
+)
+
+func SetupTagRoutes(api fiber.Router, db *gorm.DB, cfg *config.Config) {
+	var tagRepo repositories.TagRepository = repositories.NewTagRepository(db)
+	var gameRepo repositories.GameRepository = repositories.NewGameRepository(db)
+	if redis.Client != nil {
+		redisCache := redis.NewRedisCache(redis.Client)
+		tagRepo = repositories.NewCachedTagRepository(tagRepo, redisCache)
+		gameRepo = repositories.NewCachedGameRepository(gameRepo, redisCache, cfg.CacheTTL)
+	}
+	tagHandler := handlers.NewTagHandlerWithRepo(tagRepo, gameRepo)
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeGamesWrite)
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	api.Get(tagsBasePath, tagHandler.GetAllTags)
+	api.Post(tagsBasePath, authRequired, canWrite, adminOnly, tagHandler.CreateTag)
+	api.Put(tagByIDPath, authRequired, canWrite, adminOnly, tagHandler.UpdateTag)
+	api.Delete(tagByIDPath, authRequired, canWrite, adminOnly, tagHandler.DeleteTag)
+	api.Put(gameTagsPath, authRequired, canWrite, organizerOnly, tagHandler.SetGameTags)
+}
@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -15,9 +17,14 @@ func SetupTeamRoutes(api fiber.Router, db *gorm.DB) {
 	teamHandler := handlers.NewTeamHandler(db)
 	api.Get(teamsBasePath, teamHandler.GetAllTeams)
 	api.Get(teamsByIDPath, teamHandler.GetTeamByID)
-	api.Post(teamsBasePath, teamHandler.CreateTeam)
-	api.Put(teamsByIDPath, teamHandler.UpdateTeam)
-	api.Delete(teamsByIDPath, teamHandler.DeleteTeam)
 	api.Get(teamsByIDPath+"/members", teamHandler.GetTeamMembers)
-	api.Post(teamsByIDPath+"/members/:userId", teamHandler.JoinTeam)
+
+	authRequired := middleware.JWTMiddleware(db)
+	apiKeyOrAuth := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeTeamsWrite)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	api.Post(teamsBasePath, apiKeyOrAuth, canWrite, teamHandler.CreateTeam)
+	api.Put(teamsByIDPath, apiKeyOrAuth, canWrite, organizerOnly, teamHandler.UpdateTeam)
+	api.Delete(teamsByIDPath, apiKeyOrAuth, canWrite, organizerOnly, teamHandler.DeleteTeam)
+	api.Post(teamsByIDPath+"/members/:userId", authRequired, teamHandler.JoinTeam)
 }
@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -9,13 +11,23 @@ import (
 const (
 	tournamentsBasePath = "/tournaments"
 	tournamentsByIDPath = tournamentsBasePath + "/:id"
+	tournamentTeamsPath = tournamentsByIDPath + "/teams"
+	tournamentTeamPath  = tournamentTeamsPath + "/:teamId"
 )
 
 func SetupTournamentRoutes(api fiber.Router, db *gorm.DB) {
 	tournamentHandler := handlers.NewTournamentHandler(db)
 	api.Get(tournamentsBasePath, tournamentHandler.GetTournaments)
 	api.Get(tournamentsByIDPath, tournamentHandler.GetTournamentByID)
-	api.Post(tournamentsBasePath, tournamentHandler.CreateTournament)
-	api.Put(tournamentsByIDPath, tournamentHandler.UpdateTournament)
-	api.Delete(tournamentsByIDPath, tournamentHandler.DeleteTournament)
+	api.Get(tournamentTeamsPath, tournamentHandler.GetTournamentTeams)
+
+	authRequired := middleware.JWTOrAPIKeyMiddleware(db)
+	canWrite := middleware.RequireScope(models.ScopeTournamentsWrite)
+	organizerOnly := middleware.RequireRole(models.RoleOrganizer)
+	verifiedOnly := middleware.RequireVerifiedEmail()
+	api.Post(tournamentsBasePath, authRequired, canWrite, organizerOnly, verifiedOnly, tournamentHandler.CreateTournament)
+	api.Put(tournamentsByIDPath, authRequired, canWrite, organizerOnly, tournamentHandler.UpdateTournament)
+	api.Delete(tournamentsByIDPath, authRequired, canWrite, organizerOnly, tournamentHandler.DeleteTournament)
+	api.Post(tournamentTeamsPath, authRequired, canWrite, tournamentHandler.RegisterTeam)
+	api.Delete(tournamentTeamPath, authRequired, canWrite, tournamentHandler.WithdrawTeam)
 }
@@ -2,18 +2,27 @@ package routes
 
 import (
 	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/middleware"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 const (
-	usersBasePath = "/users"
-	usersByIDPath = usersBasePath + "/:id"
+	usersBasePath     = "/users"
+	usersByIDPath     = usersBasePath + "/:id"
+	usersMePath       = usersBasePath + "/me"
+	usersMeExportPath = usersMePath + "/export"
 )
 
 func SetupUserRoutes(api fiber.Router, db *gorm.DB) {
 	userHandler := handlers.NewUserHandler(db)
+	accountHandler := handlers.NewAccountHandler(db)
+
+	ownerOnly := middleware.DenyImpersonation()
+	api.Get(usersMeExportPath, middleware.JWTMiddleware(db), ownerOnly, accountHandler.ExportData)
+	api.Delete(usersMePath, middleware.JWTMiddleware(db), ownerOnly, accountHandler.DeleteAccount)
+
 	api.Get(usersBasePath, userHandler.GetAllUsers)
 	api.Get(usersByIDPath, userHandler.GetUserByID)
-	api.Put(usersByIDPath, userHandler.UpdateUser)
+	api.Put(usersByIDPath, middleware.JWTMiddleware(db), userHandler.UpdateUser)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/PI-Team04-GameClub/gameclub-backend/handlers"
+	"github.com/PI-Team04-GameClub/gameclub-backend/security"
+	"github.com/gofiber/fiber/v2"
+)
+
+const jwksPath = "/.well-known/jwks.json"
+
+func SetupWellKnownRoutes(app fiber.Router) {
+	jwksHandler := handlers.NewJWKSHandler(security.DefaultKeyRing)
+	app.Get(jwksPath, jwksHandler.GetJWKS)
+}
@@ -1,5 +1,5 @@
 #!/bin/bash
 set -e
 
-go test -v -race -coverprofile=coverage.out -covermode=atomic ./...
+go test -v -race -tags sqlite_fts5 -coverprofile=coverage.out -covermode=atomic ./...
 go tool cover -func=coverage.out
@@ -0,0 +1,121 @@
+// Package search holds the driver-independent parts of full-text search:
+// turning user input into safe prefix terms and correcting typos against
+// the indexed vocabulary.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxTerms bounds how many words of a query are searched for.
+const MaxTerms = 8
+
+// Terms splits a query into lower-case words. Everything but letters and
+// digits is treated as a separator, so the terms can be embedded in FTS and
+// tsquery syntax without escaping.
+func Terms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > MaxTerms {
+		words = words[:MaxTerms]
+	}
+	return words
+}
+
+// Correct replaces every term that is not a prefix of any vocabulary word
+// with the closest word, allowing one typo in short terms and two in long
+// ones. It reports whether anything was changed.
+func Correct(terms, vocabulary []string) ([]string, bool) {
+	corrected := make([]string, len(terms))
+	changed := false
+
+	for i, term := range terms {
+		corrected[i] = term
+		if hasPrefixMatch(term, vocabulary) {
+			continue
+		}
+
+		allowed := allowedEdits(term)
+		best, bestDistance := "", allowed+1
+		for _, word := range vocabulary {
+			if distance := prefixDistance(term, word); distance < bestDistance {
+				best, bestDistance = word, distance
+			}
+		}
+		if best != "" {
+			corrected[i] = best
+			changed = true
+		}
+	}
+
+	return corrected, changed
+}
+
+func hasPrefixMatch(term string, vocabulary []string) bool {
+	for _, word := range vocabulary {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// prefixDistance compares term with the start of word, so a typo in what
+// the user has typed so far still finds the longer word.
+func prefixDistance(term, word string) int {
+	t, w := []rune(term), []rune(word)
+	best := -1
+	for length := len(t) - 1; length <= len(t)+1; length++ {
+		if length < 1 || length > len(w) {
+			continue
+		}
+		if d := Distance(string(t), string(w[:length])); best < 0 || d < best {
+			best = d
+		}
+	}
+	if best < 0 {
+		return Distance(term, word)
+	}
+	return best
+}
+
+// Distance is the optimal string alignment distance: insertions, deletions,
+// substitutions and transpositions of adjacent characters each cost one.
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	rows := make([][]int, len(s)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(s)][len(t)]
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms_StripsSyntax(t *testing.T) {
+	// Given: A query containing FTS and tsquery operators
+	query := `Ticket to "Ride": Europe* | NOT (x)`
+
+	// When: Splitting it into terms
+	terms := Terms(query)
+
+	// Then: Only lower-case words should remain
+	assert.Equal(t, []string{"ticket", "to", "ride", "europe", "not", "x"}, terms)
+}
+
+func TestTerms_LimitsCount(t *testing.T) {
+	// When: Splitting a very long query
+	terms := Terms("a b c d e f g h i j k")
+
+	// Then: Only the first terms should be kept
+	assert.Len(t, terms, MaxTerms)
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"catan", "catan", 0},
+		{"catn", "catan", 1},
+		{"ctaan", "catan", 1},
+		{"kosmso", "kosmos", 1},
+		{"azul", "carcassonne", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, Distance(tt.a, tt.b))
+		})
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	vocabulary := []string{"catan", "carcassonne", "codenames", "kosmos", "azul"}
+
+	tests := []struct {
+		name    string
+		terms   []string
+		want    []string
+		changed bool
+	}{
+		{name: "prefix kept", terms: []string{"carc"}, want: []string{"carc"}, changed: false},
+		{name: "typo in word", terms: []string{"catna"}, want: []string{"catan"}, changed: true},
+		{name: "typo in prefix", terms: []string{"codne"}, want: []string{"codenames"}, changed: true},
+		{name: "long word allows two typos", terms: []string{"carcasssone"}, want: []string{"carcassonne"}, changed: true},
+		{name: "short terms are not guessed", terms: []string{"azl"}, want: []string{"azl"}, changed: false},
+		{name: "unrelated word kept", terms: []string{"monopoly"}, want: []string{"monopoly"}, changed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Correcting the terms
+			corrected, changed := Correct(tt.terms, vocabulary)
+
+			// Then: Only unmatched terms with a close word should change
+			assert.Equal(t, tt.want, corrected)
+			assert.Equal(t, tt.changed, changed)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyPrefix        = "gck_"
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+)
+
+var (
+	MaxAPIKeysPerUser = 20
+
+	// APIKeyTouchInterval limits how often last-used timestamps are written,
+	// so a busy bot does not turn every request into a database update.
+	APIKeyTouchInterval = time.Minute
+)
+
+var (
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrInvalidAPIKeyScope = errors.New("unknown API key scope")
+	ErrTooManyAPIKeys     = errors.New("API key limit reached")
+)
+
+type APIKeyManager struct {
+	apiKeyRepo repositories.APIKeyRepository
+	now        func() time.Time
+}
+
+func NewAPIKeyManager(apiKeyRepo repositories.APIKeyRepository) *APIKeyManager {
+	return &APIKeyManager{apiKeyRepo: apiKeyRepo, now: time.Now}
+}
+
+// Create issues a new key and returns it in plain text together with the
+// stored record. The plain key is never persisted and cannot be shown again.
+func (m *APIKeyManager) Create(ctx context.Context, userID uint, name string, scopes []string, ttl time.Duration) (string, *models.APIKey, error) {
+	normalized, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	active, err := m.apiKeyRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(active) >= MaxAPIKeysPerUser {
+		return "", nil, ErrTooManyAPIKeys
+	}
+
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	plain := apiKeyPrefix + secret
+
+	key := &models.APIKey{
+		UserID:  userID,
+		Name:    name,
+		Prefix:  plain[:apiKeyDisplayLength],
+		KeyHash: HashToken(plain),
+		Scopes:  strings.Join(normalized, " "),
+	}
+	if ttl > 0 {
+		expiresAt := m.now().Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := m.apiKeyRepo.Create(ctx, key); err != nil {
+		return "", nil, err
+	}
+	return plain, key, nil
+}
+
+// Authenticate looks the key up by hash and rejects revoked or expired keys.
+func (m *APIKeyManager) Authenticate(ctx context.Context, plain string) (*models.APIKey, error) {
+	if !strings.HasPrefix(plain, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := m.apiKeyRepo.FindByHash(ctx, HashToken(plain))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := m.now()
+	if key.IsRevoked() || key.IsExpired(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= APIKeyTouchInterval {
+		if err := m.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+	return key, nil
+}
+
+func (m *APIKeyManager) List(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	return m.apiKeyRepo.FindActiveByUser(ctx, userID)
+}
+
+func (m *APIKeyManager) Revoke(ctx context.Context, id, userID uint) (bool, error) {
+	return m.apiKeyRepo.Revoke(ctx, id, userID)
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !models.IsValidAPIKeyScope(scope) {
+			return nil, ErrInvalidAPIKeyScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
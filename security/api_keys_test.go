@@ -0,0 +1,163 @@
+package security
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyManager_Create_StoresOnlyKeyHash(t *testing.T) {
+	// Given: A user without API keys
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+
+	var stored *models.APIKey
+	apiKeyRepo.On("FindActiveByUser", mock.Anything, uint(3)).Return([]models.APIKey{}, nil)
+	apiKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.APIKey")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.APIKey)
+		}).
+		Return(nil)
+
+	// When: Creating a key with duplicate and mixed-case scopes
+	plain, key, err := manager.Create(context.Background(), 3, "Discord bot", []string{"Tournaments:Write", "tournaments:write", "news:write"}, 0)
+
+	// Then: Only the hash should be stored, with normalized scopes and no expiry
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, apiKeyPrefix))
+	assert.Same(t, stored, key)
+	assert.Equal(t, HashToken(plain), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, plain)
+	assert.Equal(t, plain[:apiKeyDisplayLength], stored.Prefix)
+	assert.Equal(t, []string{"tournaments:write", "news:write"}, stored.ScopeList())
+	assert.Nil(t, stored.ExpiresAt)
+}
+
+func TestAPIKeyManager_Create_SetsExpiry(t *testing.T) {
+	// Given: A manager with a fixed clock
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	apiKeyRepo.On("FindActiveByUser", mock.Anything, uint(3)).Return([]models.APIKey{}, nil)
+	apiKeyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	// When: Creating a key valid for a day
+	_, key, err := manager.Create(context.Background(), 3, "Scoreboard", []string{models.ScopeGamesWrite}, 24*time.Hour)
+
+	// Then: The expiry should be a day from now
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour), *key.ExpiresAt)
+}
+
+func TestAPIKeyManager_Create_RejectsUnknownScope(t *testing.T) {
+	// Given: A manager
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+
+	// When: Requesting a scope that does not exist
+	_, _, err := manager.Create(context.Background(), 3, "Bot", []string{"admin:everything"}, 0)
+
+	// Then: The key should not be created
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+	apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyManager_Create_EnforcesLimit(t *testing.T) {
+	// Given: A user who already has the maximum number of keys
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+	apiKeyRepo.On("FindActiveByUser", mock.Anything, uint(3)).Return(make([]models.APIKey, MaxAPIKeysPerUser), nil)
+
+	// When: Creating another key
+	_, _, err := manager.Create(context.Background(), 3, "Bot", []string{models.ScopeNewsWrite}, 0)
+
+	// Then: It should be refused
+	assert.ErrorIs(t, err, ErrTooManyAPIKeys)
+}
+
+func TestAPIKeyManager_Authenticate_Success(t *testing.T) {
+	// Given: A stored, active key that was never used
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+	stored := &models.APIKey{Model: gorm.Model{ID: 5}, UserID: 3, Scopes: "news:write"}
+	apiKeyRepo.On("FindByHash", mock.Anything, HashToken("gck_valid")).Return(stored, nil)
+	apiKeyRepo.On("TouchLastUsed", mock.Anything, uint(5), mock.AnythingOfType("time.Time")).Return(nil)
+
+	// When: Authenticating with it
+	key, err := manager.Authenticate(context.Background(), "gck_valid")
+
+	// Then: The key should be returned and its use recorded
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), key.UserID)
+	assert.NotNil(t, key.LastUsedAt)
+	apiKeyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyManager_Authenticate_SkipsRecentTouch(t *testing.T) {
+	// Given: A key used a few seconds ago
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+	lastUsed := time.Now().Add(-5 * time.Second)
+	stored := &models.APIKey{Model: gorm.Model{ID: 5}, UserID: 3, LastUsedAt: &lastUsed}
+	apiKeyRepo.On("FindByHash", mock.Anything, HashToken("gck_valid")).Return(stored, nil)
+
+	// When: Authenticating again
+	_, err := manager.Authenticate(context.Background(), "gck_valid")
+
+	// Then: No extra write should happen
+	assert.NoError(t, err)
+	apiKeyRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyManager_Authenticate_RejectsInvalidKeys(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		stored *models.APIKey
+	}{
+		{name: "revoked", stored: &models.APIKey{Model: gorm.Model{ID: 1}, RevokedAt: &past}},
+		{name: "expired", stored: &models.APIKey{Model: gorm.Model{ID: 2}, ExpiresAt: &past}},
+		{name: "unknown", stored: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: A key that must not authenticate
+			apiKeyRepo := new(mocks.MockAPIKeyRepository)
+			manager := NewAPIKeyManager(apiKeyRepo)
+			if tt.stored != nil {
+				apiKeyRepo.On("FindByHash", mock.Anything, HashToken("gck_key")).Return(tt.stored, nil)
+			} else {
+				apiKeyRepo.On("FindByHash", mock.Anything, HashToken("gck_key")).Return(nil, gorm.ErrRecordNotFound)
+			}
+
+			// When: Authenticating with it
+			_, err := manager.Authenticate(context.Background(), "gck_key")
+
+			// Then: It should be rejected
+			assert.ErrorIs(t, err, ErrInvalidAPIKey)
+		})
+	}
+}
+
+func TestAPIKeyManager_Authenticate_RejectsForeignFormat(t *testing.T) {
+	// Given: A value that is not one of our keys, e.g. a JWT
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	manager := NewAPIKeyManager(apiKeyRepo)
+
+	// When: Authenticating with it
+	_, err := manager.Authenticate(context.Background(), "eyJhbGciOi.x.y")
+
+	// Then: It should be rejected without a lookup
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	apiKeyRepo.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,52 @@
+package security
+
+import (
+	"log"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+)
+
+func Configure(cfg *config.Config) error {
+	hasher, err := NewPasswordHasher(cfg)
+	if err != nil {
+		return err
+	}
+	DefaultPasswordHasher = hasher
+
+	keyRing, err := NewKeyRingFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if keyRing != nil {
+		DefaultKeyRing = keyRing
+	} else {
+		log.Println("No JWT signing keys configured, using an ephemeral key; tokens will not survive a restart")
+	}
+
+	if cfg.AccessTokenTTL > 0 {
+		AccessTokenTTL = cfg.AccessTokenTTL
+	}
+	if cfg.RefreshTokenTTL > 0 {
+		RefreshTokenTTL = cfg.RefreshTokenTTL
+	}
+	if cfg.EmailVerificationTTL > 0 {
+		EmailVerificationTTL = cfg.EmailVerificationTTL
+	}
+	if cfg.PasswordResetTTL > 0 {
+		PasswordResetTTL = cfg.PasswordResetTTL
+	}
+	if cfg.LoginMaxFailures > 0 {
+		DefaultLoginThrottlePolicy.MaxAccountFailures = cfg.LoginMaxFailures
+	}
+	if cfg.LoginMaxIPFailures > 0 {
+		DefaultLoginThrottlePolicy.MaxIPFailures = cfg.LoginMaxIPFailures
+	}
+	if cfg.LoginLockoutBase > 0 {
+		DefaultLoginThrottlePolicy.BaseLockout = cfg.LoginLockoutBase
+	}
+	if cfg.LoginLockoutMax > 0 {
+		DefaultLoginThrottlePolicy.MaxLockout = cfg.LoginLockoutMax
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package security
+
+import (
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const purposeEmailVerification = "email_verification"
+
+var EmailVerificationTTL = 24 * time.Hour
+
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+
+type EmailVerificationClaims struct {
+	UserID uint
+	Email  string
+}
+
+// GenerateEmailVerificationToken signs a token bound to the user's current
+// email address, so changing the address invalidates tokens sent earlier.
+func GenerateEmailVerificationToken(user *models.User) (string, error) {
+	return signPurposeToken(purposeEmailVerification, user.ID, EmailVerificationTTL, jwt.MapClaims{
+		"email": user.Email,
+	})
+}
+
+func ParseEmailVerificationToken(tokenString string) (*EmailVerificationClaims, error) {
+	userID, claims, ok := parsePurposeToken(tokenString, purposeEmailVerification)
+	if !ok {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	return &EmailVerificationClaims{UserID: userID, Email: email}, nil
+}
@@ -0,0 +1,63 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestEmailVerificationToken_RoundTrip(t *testing.T) {
+	// Given: A registered user
+	user := &models.User{Model: gorm.Model{ID: 12}, Email: "verify@example.com"}
+
+	// When: Generating and parsing a verification token
+	token, err := GenerateEmailVerificationToken(user)
+	assert.NoError(t, err)
+	claims, err := ParseEmailVerificationToken(token)
+
+	// Then: The claims should identify the user and address
+	assert.NoError(t, err)
+	assert.Equal(t, uint(12), claims.UserID)
+	assert.Equal(t, "verify@example.com", claims.Email)
+}
+
+func TestParseEmailVerificationToken_RejectsAccessToken(t *testing.T) {
+	// Given: A regular access token
+	token, _ := GenerateToken(12, "verify@example.com", "Jane", "Doe", "Member")
+
+	// When: Parsing it as a verification token
+	_, err := ParseEmailVerificationToken(token)
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+}
+
+func TestParseEmailVerificationToken_RejectsExpiredToken(t *testing.T) {
+	// Given: A verification token that has expired
+	token, _ := DefaultKeyRing.Sign(jwt.MapClaims{
+		"sub":     "12",
+		"email":   "verify@example.com",
+		"purpose": purposeEmailVerification,
+		"exp":     time.Now().Add(-time.Minute).Unix(),
+	})
+
+	// When: Parsing it
+	_, err := ParseEmailVerificationToken(token)
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+}
+
+func TestParseEmailVerificationToken_RejectsGarbage(t *testing.T) {
+	// Given: A string that is not a token
+
+	// When: Parsing it
+	_, err := ParseEmailVerificationToken("not-a-token")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+}
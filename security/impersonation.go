@@ -0,0 +1,50 @@
+package security
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ImpersonationTTL is deliberately short: impersonation tokens cannot be
+// refreshed, so support staff have to start a new session explicitly.
+var ImpersonationTTL = 15 * time.Minute
+
+// GenerateImpersonationToken issues an access token for user that names
+// the impersonating admin in an RFC 8693 "act" claim.
+func GenerateImpersonationToken(user *models.User, actorID uint) (string, time.Time, error) {
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	claims := accessTokenClaims(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role), jti, now, ImpersonationTTL)
+	claims["act"] = map[string]interface{}{"sub": strconv.FormatUint(uint64(actorID), 10)}
+
+	token, err := DefaultKeyRing.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, now.Add(ImpersonationTTL), nil
+}
+
+// ImpersonatorID reports whether the access token carries an "act" claim
+// and, if so, which user is acting. A malformed claim yields ok with a zero
+// ID so callers reject the token instead of ignoring the claim.
+func ImpersonatorID(claims jwt.MapClaims) (id uint, ok bool) {
+	act, present := claims["act"]
+	if !present {
+		return 0, false
+	}
+
+	actor, _ := act.(map[string]interface{})
+	subject, _ := actor["sub"].(string)
+	parsed, err := strconv.ParseUint(subject, 10, 64)
+	if err != nil {
+		return 0, true
+	}
+	return uint(parsed), true
+}
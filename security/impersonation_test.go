@@ -0,0 +1,53 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGenerateImpersonationToken_CarriesActor(t *testing.T) {
+	// Given: A member to impersonate
+	user := &models.User{Model: gorm.Model{ID: 5}, Email: "member@example.com", Role: models.RoleMember}
+
+	// When: An admin starts impersonating them
+	token, expiresAt, err := GenerateImpersonationToken(user, 1)
+
+	// Then: The token should act for the member, name the admin and expire soon
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(ImpersonationTTL), expiresAt, time.Second)
+	claims, err := ExtractClaims(token)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), claims["id"])
+	impersonatorID, ok := ImpersonatorID(claims)
+	assert.True(t, ok)
+	assert.Equal(t, uint(1), impersonatorID)
+}
+
+func TestImpersonatorID(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		id     uint
+		ok     bool
+	}{
+		{name: "regular token", claims: jwt.MapClaims{"id": 5.0}, id: 0, ok: false},
+		{name: "actor claim", claims: jwt.MapClaims{"act": map[string]interface{}{"sub": "3"}}, id: 3, ok: true},
+		{name: "malformed actor", claims: jwt.MapClaims{"act": "admin"}, id: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: Reading the impersonator
+			id, ok := ImpersonatorID(tt.claims)
+
+			// Then: Only a well-formed actor should yield an ID
+			assert.Equal(t, tt.id, id)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -1,43 +1,49 @@
 package security
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var JwtSecret = []byte("your-secret-key-change-this-in-production")
+var AccessTokenTTL = 15 * time.Minute
 
-func GenerateToken(id uint, email, firstName, lastName string) (string, error) {
-	claims := jwt.MapClaims{
+func GenerateToken(id uint, email, firstName, lastName, role string) (string, error) {
+	return generateTokenAt(id, email, firstName, lastName, role, time.Now())
+}
+
+func generateTokenAt(id uint, email, firstName, lastName, role string, issuedAt time.Time) (string, error) {
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	return DefaultKeyRing.Sign(accessTokenClaims(id, email, firstName, lastName, role, jti, issuedAt, AccessTokenTTL))
+}
+
+func accessTokenClaims(id uint, email, firstName, lastName, role, jti string, now time.Time, ttl time.Duration) jwt.MapClaims {
+	return jwt.MapClaims{
 		"id":         id,
 		"email":      email,
 		"first_name": firstName,
 		"last_name":  lastName,
-		"exp":        time.Now().Add(time.Hour * 72).Unix(),
+		"role":       role,
+		"jti":        jti,
+		"iat":        now.Unix(),
+		"exp":        now.Add(ttl).Unix(),
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(JwtSecret)
 }
 
 func ExtractClaims(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return JwtSecret, nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if !token.Valid {
-		return nil, jwt.ErrSignatureInvalid
-	}
+	return DefaultKeyRing.Parse(tokenString)
+}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, jwt.ErrTokenMalformed
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-
-	return claims, nil
+	return hex.EncodeToString(b), nil
 }
@@ -16,7 +16,7 @@ func TestGenerateToken_ValidInput(t *testing.T) {
 	lastName := "Doe"
 
 	// When: Generating a token
-	token, err := GenerateToken(id, email, firstName, lastName)
+	token, err := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// Then: The token should be generated without error
 	assert.NoError(t, err)
@@ -31,12 +31,12 @@ func TestGenerateToken_TokenIsValidJWT(t *testing.T) {
 	lastName := "Smith"
 
 	// When: Generating a token
-	token, err := GenerateToken(id, email, firstName, lastName)
+	token, err := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// Then: The token should be a valid JWT that can be parsed
 	assert.NoError(t, err)
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return JwtSecret, nil
+		return DefaultKeyRing.ActiveKey().verifyKey, nil
 	})
 	assert.NoError(t, err)
 	assert.True(t, parsedToken.Valid)
@@ -48,9 +48,10 @@ func TestGenerateToken_ContainsCorrectClaims(t *testing.T) {
 	email := "claims@test.com"
 	firstName := "Alice"
 	lastName := "Wonder"
+	role := "Organizer"
 
 	// When: Generating a token and extracting claims
-	token, _ := GenerateToken(id, email, firstName, lastName)
+	token, _ := GenerateToken(id, email, firstName, lastName, role)
 	claims, err := ExtractClaims(token)
 
 	// Then: The claims should contain the correct user data
@@ -59,6 +60,7 @@ func TestGenerateToken_ContainsCorrectClaims(t *testing.T) {
 	assert.Equal(t, email, claims["email"])
 	assert.Equal(t, firstName, claims["first_name"])
 	assert.Equal(t, lastName, claims["last_name"])
+	assert.Equal(t, role, claims["role"])
 }
 
 func TestGenerateToken_ContainsExpirationClaim(t *testing.T) {
@@ -69,7 +71,7 @@ func TestGenerateToken_ContainsExpirationClaim(t *testing.T) {
 	lastName := "Builder"
 
 	// When: Generating a token and extracting claims
-	token, _ := GenerateToken(id, email, firstName, lastName)
+	token, _ := GenerateToken(id, email, firstName, lastName, "Member")
 	claims, err := ExtractClaims(token)
 
 	// Then: The claims should contain an expiration time in the future
@@ -80,22 +82,22 @@ func TestGenerateToken_ContainsExpirationClaim(t *testing.T) {
 	assert.True(t, expTime.After(time.Now()))
 }
 
-func TestGenerateToken_ExpiresIn72Hours(t *testing.T) {
+func TestGenerateToken_ExpiresAfterAccessTokenTTL(t *testing.T) {
 	// Given: Valid user data
 	id := uint(1)
-	email := "72hours@test.com"
+	email := "ttl@test.com"
 	firstName := "Time"
 	lastName := "Traveler"
 
 	// When: Generating a token
 	beforeGeneration := time.Now()
-	token, _ := GenerateToken(id, email, firstName, lastName)
+	token, _ := GenerateToken(id, email, firstName, lastName, "Member")
 	claims, _ := ExtractClaims(token)
 
-	// Then: The expiration should be approximately 72 hours from now
+	// Then: The expiration should be approximately one access token lifetime from now
 	exp := claims["exp"].(float64)
 	expTime := time.Unix(int64(exp), 0)
-	expectedExp := beforeGeneration.Add(72 * time.Hour)
+	expectedExp := beforeGeneration.Add(AccessTokenTTL)
 	diff := expTime.Sub(expectedExp)
 	assert.True(t, diff < time.Minute && diff > -time.Minute)
 }
@@ -108,7 +110,7 @@ func TestGenerateToken_EmptyEmail(t *testing.T) {
 	lastName := "Email"
 
 	// When: Generating a token
-	token, err := GenerateToken(id, email, firstName, lastName)
+	token, err := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// Then: The token should still be generated (no validation on empty values)
 	assert.NoError(t, err)
@@ -123,7 +125,7 @@ func TestGenerateToken_ZeroID(t *testing.T) {
 	lastName := "ID"
 
 	// When: Generating a token
-	token, err := GenerateToken(id, email, firstName, lastName)
+	token, err := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// Then: The token should still be generated
 	assert.NoError(t, err)
@@ -136,7 +138,7 @@ func TestExtractClaims_ValidToken(t *testing.T) {
 	email := "extract@test.com"
 	firstName := "Extract"
 	lastName := "Claims"
-	token, _ := GenerateToken(id, email, firstName, lastName)
+	token, _ := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// When: Extracting claims from the token
 	claims, err := ExtractClaims(token)
@@ -208,8 +210,7 @@ func TestExtractClaims_ExpiredToken(t *testing.T) {
 		"email": "expired@test.com",
 		"exp":   time.Now().Add(-time.Hour).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, _ := token.SignedString(JwtSecret)
+	tokenString, _ := DefaultKeyRing.Sign(claims)
 
 	// When: Trying to extract claims
 	extractedClaims, err := ExtractClaims(tokenString)
@@ -219,19 +220,32 @@ func TestExtractClaims_ExpiredToken(t *testing.T) {
 	assert.Nil(t, extractedClaims)
 }
 
-func TestJwtSecret_IsNotEmpty(t *testing.T) {
-	// Given: The JwtSecret variable
+func TestDefaultKeyRing_HasActiveSigningKey(t *testing.T) {
+	// Given: The default key ring
 
-	// When: Checking its value
+	// When: Checking its active key
 
-	// Then: It should not be empty
-	assert.NotEmpty(t, JwtSecret)
+	// Then: It should be able to sign tokens
+	assert.NotNil(t, DefaultKeyRing.ActiveKey())
+	assert.True(t, DefaultKeyRing.ActiveKey().CanSign())
+}
+
+func TestGenerateToken_HasKeyIDHeader(t *testing.T) {
+	// Given: Valid user data
+	tokenString, _ := GenerateToken(1, "kid@test.com", "Key", "Id", "Member")
+
+	// When: Parsing the token without verification
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+
+	// Then: The header should name the active key
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultKeyRing.ActiveKey().ID, token.Header["kid"])
 }
 
 func TestGenerateToken_DifferentUsersGetDifferentTokens(t *testing.T) {
 	// Given: Two different users
-	token1, _ := GenerateToken(1, "user1@test.com", "User", "One")
-	token2, _ := GenerateToken(2, "user2@test.com", "User", "Two")
+	token1, _ := GenerateToken(1, "user1@test.com", "User", "One", "Member")
+	token2, _ := GenerateToken(2, "user2@test.com", "User", "Two", "Member")
 
 	// When: Comparing the tokens
 
@@ -247,7 +261,7 @@ func TestGenerateToken_SpecialCharactersInNames(t *testing.T) {
 	lastName := "O'Brien"
 
 	// When: Generating a token
-	token, err := GenerateToken(id, email, firstName, lastName)
+	token, err := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// Then: The token should be generated successfully with special characters preserved
 	assert.NoError(t, err)
@@ -262,7 +276,7 @@ func TestExtractClaims_ReturnsAllExpectedFields(t *testing.T) {
 	email := "allfields@test.com"
 	firstName := "All"
 	lastName := "Fields"
-	token, _ := GenerateToken(id, email, firstName, lastName)
+	token, _ := GenerateToken(id, email, firstName, lastName, "Member")
 
 	// When: Extracting claims
 	claims, err := ExtractClaims(token)
@@ -274,4 +288,20 @@ func TestExtractClaims_ReturnsAllExpectedFields(t *testing.T) {
 	assert.Contains(t, claims, "first_name")
 	assert.Contains(t, claims, "last_name")
 	assert.Contains(t, claims, "exp")
+	assert.Contains(t, claims, "iat")
+	assert.Contains(t, claims, "jti")
+}
+
+func TestGenerateToken_UniqueTokenIDs(t *testing.T) {
+	// Given: The same user data used twice
+	token1, _ := GenerateToken(1, "same@test.com", "Same", "User", "Member")
+	token2, _ := GenerateToken(1, "same@test.com", "Same", "User", "Member")
+
+	// When: Extracting the token IDs
+	claims1, _ := ExtractClaims(token1)
+	claims2, _ := ExtractClaims(token2)
+
+	// Then: Each token should carry its own jti
+	assert.NotEmpty(t, claims1["jti"])
+	assert.NotEqual(t, claims1["jti"], claims2["jti"])
 }
@@ -0,0 +1,182 @@
+package security
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type KeyRing struct {
+	active *SigningKey
+	keys   []*SigningKey
+}
+
+var DefaultKeyRing = newEphemeralKeyRing()
+
+func NewKeyRing(active *SigningKey, previous ...*SigningKey) (*KeyRing, error) {
+	if active == nil || !active.CanSign() {
+		return nil, ErrKeyCannotSign
+	}
+
+	ring := &KeyRing{active: active}
+	seen := map[string]bool{}
+	for _, key := range append([]*SigningKey{active}, previous...) {
+		if key == nil || seen[key.ID] {
+			continue
+		}
+		seen[key.ID] = true
+		ring.keys = append(ring.keys, key)
+	}
+
+	return ring, nil
+}
+
+// NewKeyRingFromConfig builds the key ring from the configured private key
+// files, public key files and HMAC secrets. The first private key (or the
+// first secret when no private key is configured) signs new tokens; every
+// other key is only used to verify tokens issued before a rotation.
+// It returns nil when no keys are configured.
+func NewKeyRingFromConfig(cfg *config.Config) (*KeyRing, error) {
+	var signing, verifying []*SigningKey
+
+	for _, path := range cfg.JWTPrivateKeyFiles {
+		key, err := loadKeyFile(path, ParsePrivateKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		signing = append(signing, key)
+	}
+
+	for _, path := range cfg.JWTPublicKeyFiles {
+		key, err := loadKeyFile(path, ParsePublicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		verifying = append(verifying, key)
+	}
+
+	for _, secret := range cfg.JWTSecrets {
+		key, err := NewHMACKey([]byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		signing = append(signing, key)
+	}
+
+	if len(signing) == 0 {
+		if len(verifying) > 0 {
+			return nil, ErrKeyCannotSign
+		}
+		return nil, nil
+	}
+
+	return NewKeyRing(signing[0], append(signing[1:], verifying...)...)
+}
+
+func loadKeyFile(path string, parse func([]byte) (*SigningKey, error)) (*SigningKey, error) {
+	data, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT key %s: %w", path, err)
+	}
+	key, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+func newEphemeralKeyRing() *KeyRing {
+	secret := make([]byte, minHMACSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	key, err := NewHMACKey(secret)
+	if err != nil {
+		panic(err)
+	}
+	ring, err := NewKeyRing(key)
+	if err != nil {
+		panic(err)
+	}
+	return ring
+}
+
+func (r *KeyRing) ActiveKey() *SigningKey {
+	return r.active
+}
+
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.active.Method(), claims)
+	token.Header["kid"] = r.active.ID
+	return token.SignedString(r.active.signKey)
+}
+
+func (r *KeyRing) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, r.keyFunc, jwt.WithValidMethods(r.algorithms()))
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, jwt.ErrTokenMalformed
+	}
+
+	return claims, nil
+}
+
+func (r *KeyRing) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range r.keys {
+		if jwk, ok := key.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+func (r *KeyRing) keyFunc(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+
+	if kid, ok := token.Header["kid"].(string); ok {
+		for _, key := range r.keys {
+			if key.ID == kid && key.Algorithm == alg {
+				return key.verifyKey, nil
+			}
+		}
+		return nil, ErrUnknownSigningKey
+	}
+
+	// Tokens issued before key IDs were introduced are checked against
+	// every key of the matching algorithm.
+	var candidates jwt.VerificationKeySet
+	for _, key := range r.keys {
+		if key.Algorithm == alg {
+			candidates.Keys = append(candidates.Keys, key.verifyKey)
+		}
+	}
+	if len(candidates.Keys) == 0 {
+		return nil, ErrUnknownSigningKey
+	}
+	return candidates, nil
+}
+
+func (r *KeyRing) algorithms() []string {
+	var algorithms []string
+	seen := map[string]bool{}
+	for _, key := range r.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
@@ -0,0 +1,256 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"id":  1,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func testRSAKey(t *testing.T) (*rsa.PrivateKey, *SigningKey) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	key, err := NewPrivateSigningKey(private)
+	if err != nil {
+		t.Fatalf("Failed to create signing key: %v", err)
+	}
+	return private, key
+}
+
+func testEd25519Key(t *testing.T) (ed25519.PrivateKey, *SigningKey) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+	key, err := NewPrivateSigningKey(private)
+	if err != nil {
+		t.Fatalf("Failed to create signing key: %v", err)
+	}
+	return private, key
+}
+
+func testHMACKey(t *testing.T, secret string) *SigningKey {
+	key, err := NewHMACKey([]byte(secret))
+	if err != nil {
+		t.Fatalf("Failed to create HMAC key: %v", err)
+	}
+	return key
+}
+
+func TestKeyRing_SignAndParse_RS256(t *testing.T) {
+	// Given: A key ring with an RSA key
+	_, key := testRSAKey(t)
+	ring, _ := NewKeyRing(key)
+
+	// When: Signing and parsing a token
+	tokenString, err := ring.Sign(testClaims())
+	assert.NoError(t, err)
+	claims, err := ring.Parse(tokenString)
+
+	// Then: The token should use RS256 and verify
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), claims["id"])
+	token, _, _ := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	assert.Equal(t, AlgorithmRS256, token.Method.Alg())
+	assert.Equal(t, key.ID, token.Header["kid"])
+}
+
+func TestKeyRing_SignAndParse_EdDSA(t *testing.T) {
+	// Given: A key ring with an Ed25519 key
+	_, key := testEd25519Key(t)
+	ring, _ := NewKeyRing(key)
+
+	// When: Signing and parsing a token
+	tokenString, err := ring.Sign(testClaims())
+	assert.NoError(t, err)
+	_, err = ring.Parse(tokenString)
+
+	// Then: The token should use EdDSA and verify
+	assert.NoError(t, err)
+	token, _, _ := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	assert.Equal(t, AlgorithmEdDSA, token.Method.Alg())
+}
+
+func TestKeyRing_AcceptsTokensFromPreviousKeys(t *testing.T) {
+	// Given: A token signed before a key rotation
+	_, oldKey := testEd25519Key(t)
+	_, newKey := testRSAKey(t)
+	oldRing, _ := NewKeyRing(oldKey)
+	tokenString, _ := oldRing.Sign(testClaims())
+
+	// When: Parsing it with the rotated key ring
+	rotated, _ := NewKeyRing(newKey, oldKey)
+	_, err := rotated.Parse(tokenString)
+
+	// Then: The token should still verify
+	assert.NoError(t, err)
+}
+
+func TestKeyRing_RejectsRetiredKeys(t *testing.T) {
+	// Given: A token signed with a key that is no longer in the ring
+	_, oldKey := testEd25519Key(t)
+	_, newKey := testEd25519Key(t)
+	oldRing, _ := NewKeyRing(oldKey)
+	tokenString, _ := oldRing.Sign(testClaims())
+
+	// When: Parsing it with a ring that dropped the old key
+	ring, _ := NewKeyRing(newKey)
+	_, err := ring.Parse(tokenString)
+
+	// Then: The token should be rejected
+	assert.Error(t, err)
+}
+
+func TestKeyRing_AcceptsLegacyTokensWithoutKeyID(t *testing.T) {
+	// Given: An HS256 token without a kid header
+	secret := strings.Repeat("s", 32)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	tokenString, _ := token.SignedString([]byte(secret))
+
+	// When: Parsing it with a ring that still holds the secret
+	_, active := testRSAKey(t)
+	ring, _ := NewKeyRing(active, testHMACKey(t, secret))
+	_, err := ring.Parse(tokenString)
+
+	// Then: The token should verify
+	assert.NoError(t, err)
+}
+
+func TestKeyRing_RejectsAlgorithmMismatch(t *testing.T) {
+	// Given: An HS256 token that claims the kid of an RSA key
+	_, rsaKey := testRSAKey(t)
+	ring, _ := NewKeyRing(rsaKey)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	token.Header["kid"] = rsaKey.ID
+	tokenString, _ := token.SignedString([]byte(strings.Repeat("x", 32)))
+
+	// When: Parsing it
+	_, err := ring.Parse(tokenString)
+
+	// Then: The token should be rejected
+	assert.Error(t, err)
+}
+
+func TestKeyRing_JWKSPublishesOnlyPublicKeys(t *testing.T) {
+	// Given: A ring with RSA, Ed25519 and HMAC keys
+	_, rsaKey := testRSAKey(t)
+	_, edKey := testEd25519Key(t)
+	ring, _ := NewKeyRing(rsaKey, edKey, testHMACKey(t, strings.Repeat("h", 32)))
+
+	// When: Building the JWKS
+	set := ring.JWKS()
+
+	// Then: Only the asymmetric keys should be published
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, "RSA", set.Keys[0].KeyType)
+	assert.Equal(t, rsaKey.ID, set.Keys[0].KeyID)
+	assert.Equal(t, "AQAB", set.Keys[0].E)
+	assert.NotEmpty(t, set.Keys[0].N)
+	assert.Equal(t, "OKP", set.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", set.Keys[1].Curve)
+}
+
+func TestNewKeyRing_RequiresSigningKey(t *testing.T) {
+	// Given: A verification-only key
+	_, signing := testEd25519Key(t)
+	public, _ := NewPublicSigningKey(signing.verifyKey)
+
+	// When: Using it as the active key
+	ring, err := NewKeyRing(public)
+
+	// Then: An error should be returned
+	assert.ErrorIs(t, err, ErrKeyCannotSign)
+	assert.Nil(t, ring)
+}
+
+func TestNewHMACKey_RejectsShortSecrets(t *testing.T) {
+	// Given: A short secret
+
+	// When: Creating an HMAC key
+	key, err := NewHMACKey([]byte("short"))
+
+	// Then: An error should be returned
+	assert.Error(t, err)
+	assert.Nil(t, key)
+}
+
+func TestSigningKey_KeyIDIsStable(t *testing.T) {
+	// Given: The same public key loaded twice
+	private, key := testRSAKey(t)
+
+	// When: Deriving the key ID again
+	again, _ := NewPublicSigningKey(&private.PublicKey)
+
+	// Then: The IDs should match
+	assert.Equal(t, key.ID, again.ID)
+}
+
+func TestNewKeyRingFromConfig_LoadsKeyFiles(t *testing.T) {
+	// Given: A private key file for the active key and a public key file of a retired key
+	dir := t.TempDir()
+	rsaPrivate, _ := testRSAKey(t)
+	edPrivate, edKey := testEd25519Key(t)
+
+	privatePath := filepath.Join(dir, "active.pem")
+	os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPrivate)}), 0o600)
+
+	publicDER, _ := x509.MarshalPKIXPublicKey(edPrivate.Public())
+	publicPath := filepath.Join(dir, "retired.pem")
+	os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600)
+
+	cfg := &config.Config{JWTPrivateKeyFiles: []string{privatePath}, JWTPublicKeyFiles: []string{publicPath}}
+
+	// When: Building the key ring from config
+	ring, err := NewKeyRingFromConfig(cfg)
+
+	// Then: The RSA key should sign and the retired key should still verify
+	assert.NoError(t, err)
+	assert.Equal(t, AlgorithmRS256, ring.ActiveKey().Algorithm)
+	retiredRing, _ := NewKeyRing(edKey)
+	tokenString, _ := retiredRing.Sign(testClaims())
+	_, err = ring.Parse(tokenString)
+	assert.NoError(t, err)
+}
+
+func TestNewKeyRingFromConfig_NoKeys(t *testing.T) {
+	// Given: A configuration without keys
+	cfg := &config.Config{}
+
+	// When: Building the key ring
+	ring, err := NewKeyRingFromConfig(cfg)
+
+	// Then: No ring and no error should be returned
+	assert.NoError(t, err)
+	assert.Nil(t, ring)
+}
+
+func TestNewKeyRingFromConfig_MissingFile(t *testing.T) {
+	// Given: A configuration pointing at a missing key file
+	cfg := &config.Config{JWTPrivateKeyFiles: []string{filepath.Join(t.TempDir(), "missing.pem")}}
+
+	// When: Building the key ring
+	ring, err := NewKeyRingFromConfig(cfg)
+
+	// Then: An error should be returned
+	assert.Error(t, err)
+	assert.Nil(t, ring)
+}
@@ -0,0 +1,162 @@
+package security
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+)
+
+type LoginThrottlePolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+	FailureWindow      time.Duration
+}
+
+var DefaultLoginThrottlePolicy = LoginThrottlePolicy{
+	MaxAccountFailures: 5,
+	MaxIPFailures:      20,
+	BaseLockout:        30 * time.Second,
+	MaxLockout:         time.Hour,
+	FailureWindow:      24 * time.Hour,
+}
+
+// The in-memory store is shared so every handler instance sees the same
+// counters when Redis is not available.
+var memoryAttemptStore = redis.NewMemoryAttemptStore()
+
+// LoginThrottle counts failed logins per account and per client IP. Once a
+// subject reaches its failure limit it is locked out, and every further
+// failure doubles the lockout up to the policy maximum.
+type LoginThrottle struct {
+	store  redis.AttemptStore
+	policy LoginThrottlePolicy
+}
+
+func NewLoginThrottle(store redis.AttemptStore, policy LoginThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{store: store, policy: policy}
+}
+
+func NewDefaultLoginThrottle() *LoginThrottle {
+	if redis.Client == nil {
+		return NewLoginThrottle(memoryAttemptStore, DefaultLoginThrottlePolicy)
+	}
+	return NewLoginThrottle(
+		&fallbackAttemptStore{primary: redis.NewRedisAttemptStore(redis.Client), fallback: memoryAttemptStore},
+		DefaultLoginThrottlePolicy,
+	)
+}
+
+// RetryAfter returns how long the account or IP is still locked out, or zero
+// when a login attempt is allowed.
+func (t *LoginThrottle) RetryAfter(ctx context.Context, email, ip string) time.Duration {
+	var wait time.Duration
+	for _, subject := range []string{accountSubject(email), ipSubject(ip)} {
+		lockedFor, err := t.store.LockedFor(ctx, redis.LoginLockKey(subject))
+		if err != nil {
+			log.Printf("Failed to read login lockout for %s: %v", subject, err)
+			continue
+		}
+		if lockedFor > wait {
+			wait = lockedFor
+		}
+	}
+	return wait
+}
+
+func (t *LoginThrottle) RecordFailure(ctx context.Context, email, ip string) {
+	t.recordFailure(ctx, accountSubject(email), t.policy.MaxAccountFailures)
+	t.recordFailure(ctx, ipSubject(ip), t.policy.MaxIPFailures)
+}
+
+// RecordSuccess clears the account counters. The IP counters are kept so a
+// valid login on one account does not reset guessing against others.
+func (t *LoginThrottle) RecordSuccess(ctx context.Context, email string) {
+	if err := t.Unlock(ctx, email); err != nil {
+		log.Printf("Failed to reset login failures for %s: %v", email, err)
+	}
+}
+
+func (t *LoginThrottle) Unlock(ctx context.Context, email string) error {
+	subject := accountSubject(email)
+	return t.store.Reset(ctx, redis.LoginFailuresKey(subject), redis.LoginLockKey(subject))
+}
+
+func (t *LoginThrottle) recordFailure(ctx context.Context, subject string, maxFailures int) {
+	failures, err := t.store.Increment(ctx, redis.LoginFailuresKey(subject), t.policy.FailureWindow)
+	if err != nil {
+		log.Printf("Failed to record login failure for %s: %v", subject, err)
+		return
+	}
+
+	if maxFailures <= 0 || failures < int64(maxFailures) {
+		return
+	}
+
+	lockout := t.lockoutFor(failures - int64(maxFailures))
+	if err := t.store.Lock(ctx, redis.LoginLockKey(subject), lockout); err != nil {
+		log.Printf("Failed to lock out %s: %v", subject, err)
+	}
+}
+
+func (t *LoginThrottle) lockoutFor(excessFailures int64) time.Duration {
+	lockout := t.policy.BaseLockout
+	for i := int64(0); i < excessFailures; i++ {
+		lockout *= 2
+		if lockout >= t.policy.MaxLockout {
+			return t.policy.MaxLockout
+		}
+	}
+	return min(lockout, t.policy.MaxLockout)
+}
+
+func accountSubject(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
+
+type fallbackAttemptStore struct {
+	primary  redis.AttemptStore
+	fallback redis.AttemptStore
+}
+
+func (s *fallbackAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	count, err := s.primary.Increment(ctx, key, window)
+	if err != nil {
+		log.Printf("Attempt store error for %s, using in-memory fallback: %v", key, err)
+		return s.fallback.Increment(ctx, key, window)
+	}
+	return count, nil
+}
+
+func (s *fallbackAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	if err := s.primary.Lock(ctx, key, duration); err != nil {
+		log.Printf("Attempt store error for %s, using in-memory fallback: %v", key, err)
+		return s.fallback.Lock(ctx, key, duration)
+	}
+	return nil
+}
+
+func (s *fallbackAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	lockedFor, err := s.primary.LockedFor(ctx, key)
+	if err != nil {
+		log.Printf("Attempt store error for %s, using in-memory fallback: %v", key, err)
+		return s.fallback.LockedFor(ctx, key)
+	}
+	return lockedFor, nil
+}
+
+func (s *fallbackAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	fallbackErr := s.fallback.Reset(ctx, keys...)
+	if err := s.primary.Reset(ctx, keys...); err != nil {
+		return err
+	}
+	return fallbackErr
+}
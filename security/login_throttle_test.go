@@ -0,0 +1,141 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLoginThrottlePolicy = LoginThrottlePolicy{
+	MaxAccountFailures: 3,
+	MaxIPFailures:      10,
+	BaseLockout:        time.Minute,
+	MaxLockout:         5 * time.Minute,
+	FailureWindow:      time.Hour,
+}
+
+func TestLoginThrottle_AllowsAttemptsBelowLimit(t *testing.T) {
+	// Given: An account with fewer failures than the limit
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+	ctx := context.Background()
+	throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+
+	// When: Checking whether another attempt is allowed
+	retryAfter := throttle.RetryAfter(ctx, "user@example.com", "10.0.0.1")
+
+	// Then: It should be allowed
+	assert.Equal(t, time.Duration(0), retryAfter)
+}
+
+func TestLoginThrottle_LocksAccountAtLimit(t *testing.T) {
+	// Given: An account that reached the failure limit
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	}
+
+	// When: Checking from another IP and with different casing
+	retryAfter := throttle.RetryAfter(ctx, "User@Example.com", "10.0.0.2")
+
+	// Then: The account should be locked for the base lockout
+	assert.InDelta(t, time.Minute, retryAfter, float64(time.Second))
+}
+
+func TestLoginThrottle_LockoutGrowsExponentiallyUpToMax(t *testing.T) {
+	// Given: A throttle policy with a one minute base and five minute cap
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+
+	// When: Computing lockouts for growing numbers of excess failures
+	// Then: Each failure should double the lockout until it is capped
+	assert.Equal(t, time.Minute, throttle.lockoutFor(0))
+	assert.Equal(t, 2*time.Minute, throttle.lockoutFor(1))
+	assert.Equal(t, 4*time.Minute, throttle.lockoutFor(2))
+	assert.Equal(t, 5*time.Minute, throttle.lockoutFor(3))
+	assert.Equal(t, 5*time.Minute, throttle.lockoutFor(60))
+}
+
+func TestLoginThrottle_LocksIPAcrossAccounts(t *testing.T) {
+	// Given: One IP failing against many different accounts
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		throttle.RecordFailure(ctx, fmt.Sprintf("user%d@example.com", i), "10.0.0.9")
+	}
+
+	// When: Trying a fresh account from the same IP
+	retryAfter := throttle.RetryAfter(ctx, "fresh@example.com", "10.0.0.9")
+
+	// Then: The IP should be locked out
+	assert.Greater(t, retryAfter, time.Duration(0))
+}
+
+func TestLoginThrottle_SuccessClearsAccountFailures(t *testing.T) {
+	// Given: An account one failure away from lockout
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+	ctx := context.Background()
+	throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+
+	// When: Logging in successfully and failing once more
+	throttle.RecordSuccess(ctx, "user@example.com")
+	throttle.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+
+	// Then: The account should not be locked
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(ctx, "user@example.com", "10.0.0.1"))
+}
+
+func TestLoginThrottle_Unlock(t *testing.T) {
+	// Given: A locked account
+	throttle := NewLoginThrottle(redis.NewMemoryAttemptStore(), testLoginThrottlePolicy)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		throttle.RecordFailure(ctx, "locked@example.com", "10.0.0.1")
+	}
+
+	// When: Unlocking it
+	err := throttle.Unlock(ctx, "locked@example.com")
+
+	// Then: Login attempts should be allowed again
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), throttle.RetryAfter(ctx, "locked@example.com", "10.0.0.1"))
+}
+
+type failingAttemptStore struct{}
+
+func (failingAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return 0, errors.New("redis down")
+}
+
+func (failingAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	return errors.New("redis down")
+}
+
+func (failingAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	return 0, errors.New("redis down")
+}
+
+func (failingAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	return errors.New("redis down")
+}
+
+func TestLoginThrottle_FallsBackToMemoryWhenRedisFails(t *testing.T) {
+	// Given: A throttle whose Redis store is unavailable
+	store := &fallbackAttemptStore{primary: failingAttemptStore{}, fallback: redis.NewMemoryAttemptStore()}
+	throttle := NewLoginThrottle(store, testLoginThrottlePolicy)
+	ctx := context.Background()
+
+	// When: Reaching the failure limit
+	for i := 0; i < 3; i++ {
+		throttle.RecordFailure(ctx, "fallback@example.com", "10.0.0.1")
+	}
+
+	// Then: The in-memory fallback should still enforce the lockout
+	assert.Greater(t, throttle.RetryAfter(ctx, "fallback@example.com", "10.0.0.1"), time.Duration(0))
+}
@@ -0,0 +1,208 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+)
+
+const (
+	purposeMFAPending  = "mfa_pending"
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+var MFAPendingTokenTTL = 5 * time.Minute
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication has not been enrolled")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired two-factor token")
+)
+
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type MFAManager struct {
+	userRepo     repositories.UserRepository
+	recoveryRepo repositories.RecoveryCodeRepository
+	totp         *TOTP
+}
+
+func NewMFAManager(userRepo repositories.UserRepository, recoveryRepo repositories.RecoveryCodeRepository, totp *TOTP) *MFAManager {
+	return &MFAManager{
+		userRepo:     userRepo,
+		recoveryRepo: recoveryRepo,
+		totp:         totp,
+	}
+}
+
+// Enroll stores a new secret on the user. Two-factor login stays off until
+// the secret is confirmed with a code from the authenticator app.
+func (m *MFAManager) Enroll(ctx context.Context, user *models.User) (*MFAEnrollment, error) {
+	if user.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_last_step": 0,
+	}); err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+
+	return &MFAEnrollment{Secret: secret, URI: m.totp.URI(user.Email, secret)}, nil
+}
+
+// Confirm enables two-factor login and returns a fresh set of recovery
+// codes. The codes are only stored as hashes and cannot be shown again.
+func (m *MFAManager) Confirm(ctx context.Context, user *models.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	step, ok := m.totp.Verify(user.TOTPSecret, code, user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.recoveryRepo.ReplaceForUser(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := m.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{
+		"totp_enabled":   true,
+		"totp_last_step": step,
+	}); err != nil {
+		return nil, err
+	}
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+
+	return codes, nil
+}
+
+// Verify accepts either a current authenticator code or an unused recovery
+// code.
+func (m *MFAManager) Verify(ctx context.Context, user *models.User, code, recoveryCode string) error {
+	if !user.TOTPEnabled {
+		return ErrMFANotEnabled
+	}
+
+	if recoveryCode != "" {
+		consumed, err := m.recoveryRepo.Consume(ctx, user.ID, HashToken(normalizeRecoveryCode(recoveryCode)))
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	step, ok := m.totp.Verify(user.TOTPSecret, code, user.TOTPLastStep)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	if err := m.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{"totp_last_step": step}); err != nil {
+		return err
+	}
+	user.TOTPLastStep = step
+	return nil
+}
+
+func (m *MFAManager) Disable(ctx context.Context, user *models.User, code, recoveryCode string) error {
+	if err := m.Verify(ctx, user, code, recoveryCode); err != nil {
+		return err
+	}
+
+	if err := m.recoveryRepo.DeleteForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := m.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{
+		"totp_secret":    "",
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}); err != nil {
+		return err
+	}
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPLastStep = 0
+	return nil
+}
+
+// GenerateMFAPendingToken is issued by Login after the password check for
+// users with two-factor login enabled. It only proves the first factor and
+// must be exchanged at /auth/mfa/verify for a session.
+func GenerateMFAPendingToken(user *models.User) (string, error) {
+	return signPurposeToken(purposeMFAPending, user.ID, MFAPendingTokenTTL, nil)
+}
+
+// MFAPendingClaims identifies a pending login. The JTI is put on the revoked
+// token list once the login completes, so each token is good for one session.
+type MFAPendingClaims struct {
+	UserID    uint
+	JTI       string
+	ExpiresAt time.Time
+}
+
+func ParseMFAPendingToken(tokenString string) (*MFAPendingClaims, error) {
+	userID, claims, ok := parsePurposeToken(tokenString, purposeMFAPending)
+	if !ok {
+		return nil, ErrInvalidMFAToken
+	}
+
+	jti, _ := claims["jti"].(string)
+	expiresAt, err := claims.GetExpirationTime()
+	if jti == "" || err != nil || expiresAt == nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return &MFAPendingClaims{UserID: userID, JTI: jti, ExpiresAt: expiresAt.Time}, nil
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := totpEncoding.EncodeToString(b)[:recoveryCodeLength]
+		codes[i] = raw[:recoveryCodeLength/2] + "-" + raw[recoveryCodeLength/2:]
+		hashes[i] = HashToken(raw)
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,170 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestMFAManager(now time.Time) (*MFAManager, *mocks.MockUserRepository, *mocks.MockRecoveryCodeRepository) {
+	userRepo := new(mocks.MockUserRepository)
+	recoveryRepo := new(mocks.MockRecoveryCodeRepository)
+	return NewMFAManager(userRepo, recoveryRepo, NewTOTP(fakeClock(now))), userRepo, recoveryRepo
+}
+
+func TestMFAManager_Enroll_StoresSecret(t *testing.T) {
+	// Given: A user without two-factor authentication
+	manager, userRepo, _ := newTestMFAManager(time.Now())
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "org@example.com"}
+	userRepo.On("UpdateFields", mock.Anything, uint(1), mock.Anything).Return(nil)
+
+	// When: Enrolling
+	enrollment, err := manager.Enroll(context.Background(), user)
+
+	// Then: A secret and otpauth URI should be returned and stored
+	assert.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, enrollment.Secret)
+	assert.Equal(t, enrollment.Secret, user.TOTPSecret)
+	assert.False(t, user.TOTPEnabled)
+}
+
+func TestMFAManager_Enroll_AlreadyEnabled(t *testing.T) {
+	// Given: A user with two-factor authentication enabled
+	manager, _, _ := newTestMFAManager(time.Now())
+	user := &models.User{TOTPEnabled: true}
+
+	// When: Enrolling again
+	_, err := manager.Enroll(context.Background(), user)
+
+	// Then: It should be refused
+	assert.ErrorIs(t, err, ErrMFAAlreadyEnabled)
+}
+
+func TestMFAManager_Confirm_EnablesAndIssuesRecoveryCodes(t *testing.T) {
+	// Given: An enrolled user and a valid code from the fake clock
+	now := time.Unix(1700000000, 0)
+	manager, userRepo, recoveryRepo := newTestMFAManager(now)
+	user := &models.User{Model: gorm.Model{ID: 1}, TOTPSecret: rfc6238Secret}
+	code, _ := manager.totp.CodeAt(rfc6238Secret, manager.totp.Step(now))
+
+	var storedHashes []string
+	recoveryRepo.On("ReplaceForUser", mock.Anything, uint(1), mock.Anything).
+		Run(func(args mock.Arguments) { storedHashes = args.Get(2).([]string) }).
+		Return(nil)
+	userRepo.On("UpdateFields", mock.Anything, uint(1), mock.Anything).Return(nil)
+
+	// When: Confirming enrollment
+	codes, err := manager.Confirm(context.Background(), user, code)
+
+	// Then: Two-factor login should be on and only code hashes stored
+	assert.NoError(t, err)
+	assert.True(t, user.TOTPEnabled)
+	assert.Len(t, codes, recoveryCodeCount)
+	assert.Len(t, storedHashes, recoveryCodeCount)
+	assert.Equal(t, HashToken(normalizeRecoveryCode(codes[0])), storedHashes[0])
+}
+
+func TestMFAManager_Confirm_InvalidCode(t *testing.T) {
+	// Given: An enrolled user
+	manager, _, _ := newTestMFAManager(time.Unix(1700000000, 0))
+	user := &models.User{Model: gorm.Model{ID: 1}, TOTPSecret: rfc6238Secret}
+
+	// When: Confirming with a wrong code
+	_, err := manager.Confirm(context.Background(), user, "000000")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+	assert.False(t, user.TOTPEnabled)
+}
+
+func TestMFAManager_Confirm_NotEnrolled(t *testing.T) {
+	// Given: A user who never enrolled
+	manager, _, _ := newTestMFAManager(time.Now())
+
+	// When: Confirming
+	_, err := manager.Confirm(context.Background(), &models.User{}, "123456")
+
+	// Then: It should report the missing enrollment
+	assert.ErrorIs(t, err, ErrMFANotEnrolled)
+}
+
+func TestMFAManager_Verify_RecoveryCode(t *testing.T) {
+	// Given: A user with two-factor login and an unused recovery code
+	manager, _, recoveryRepo := newTestMFAManager(time.Now())
+	user := &models.User{Model: gorm.Model{ID: 1}, TOTPEnabled: true, TOTPSecret: rfc6238Secret}
+	recoveryRepo.On("Consume", mock.Anything, uint(1), HashToken("ABCDEFGHIJ")).Return(true, nil)
+
+	// When: Verifying with the code typed in lower case
+	err := manager.Verify(context.Background(), user, "", "abcde-fghij")
+
+	// Then: It should be accepted
+	assert.NoError(t, err)
+}
+
+func TestMFAManager_Verify_UsedRecoveryCode(t *testing.T) {
+	// Given: A recovery code that was already consumed
+	manager, _, recoveryRepo := newTestMFAManager(time.Now())
+	user := &models.User{Model: gorm.Model{ID: 1}, TOTPEnabled: true}
+	recoveryRepo.On("Consume", mock.Anything, uint(1), mock.Anything).Return(false, nil)
+
+	// When: Verifying with it
+	err := manager.Verify(context.Background(), user, "", "ABCDE-FGHIJ")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+func TestMFAManager_Verify_StoresLastStep(t *testing.T) {
+	// Given: A user with two-factor login and a valid code
+	now := time.Unix(1700000000, 0)
+	manager, userRepo, _ := newTestMFAManager(now)
+	user := &models.User{Model: gorm.Model{ID: 1}, TOTPEnabled: true, TOTPSecret: rfc6238Secret}
+	step := manager.totp.Step(now)
+	code, _ := manager.totp.CodeAt(rfc6238Secret, step)
+	userRepo.On("UpdateFields", mock.Anything, uint(1), map[string]interface{}{"totp_last_step": step}).Return(nil)
+
+	// When: Verifying the code twice
+	first := manager.Verify(context.Background(), user, code, "")
+	second := manager.Verify(context.Background(), user, code, "")
+
+	// Then: Only the first use should succeed
+	assert.NoError(t, first)
+	assert.ErrorIs(t, second, ErrInvalidMFACode)
+}
+
+func TestMFAPendingToken_RoundTrip(t *testing.T) {
+	// Given: A user who passed the password check
+	user := &models.User{Model: gorm.Model{ID: 5}}
+
+	// When: Generating and parsing the pending token
+	token, err := GenerateMFAPendingToken(user)
+	assert.NoError(t, err)
+	claims, err := ParseMFAPendingToken(token)
+
+	// Then: It should identify the user and carry a token ID
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), claims.UserID)
+	assert.NotEmpty(t, claims.JTI)
+	assert.WithinDuration(t, time.Now().Add(MFAPendingTokenTTL), claims.ExpiresAt, 5*time.Second)
+}
+
+func TestParseMFAPendingToken_RejectsOtherTokens(t *testing.T) {
+	// Given: An access token and an email verification token
+	accessToken, _ := GenerateToken(5, "user@example.com", "Jane", "Doe", "Organizer")
+	verificationToken, _ := GenerateEmailVerificationToken(&models.User{Model: gorm.Model{ID: 5}, Email: "user@example.com"})
+
+	// When: Parsing them as MFA pending tokens
+	_, accessErr := ParseMFAPendingToken(accessToken)
+	_, verificationErr := ParseMFAPendingToken(verificationToken)
+
+	// Then: Both should be rejected
+	assert.ErrorIs(t, accessErr, ErrInvalidMFAToken)
+	assert.ErrorIs(t, verificationErr, ErrInvalidMFAToken)
+}
@@ -0,0 +1,234 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PasswordAlgorithmBcrypt   = "bcrypt"
+	PasswordAlgorithmArgon2id = "argon2id"
+
+	argon2idPrefix     = "$argon2id$"
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+	legacyHashLength   = sha256.Size * 2
+
+	// MaxPasswordBytes is as much of a password as bcrypt reads. Anything
+	// after it would be silently ignored, so longer passwords are refused
+	// whichever hasher is configured, and switching hashers never locks
+	// anyone out.
+	MaxPasswordBytes = 72
+)
+
+var ErrPasswordTooLong = fmt.Errorf("password must be at most %d bytes", MaxPasswordBytes)
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hashedPassword, password string) bool
+	NeedsRehash(hashedPassword string) bool
+}
+
+var DefaultPasswordHasher PasswordHasher = NewBcryptHasher(bcrypt.DefaultCost)
+
+func NewPasswordHasher(cfg *config.Config) (PasswordHasher, error) {
+	switch strings.ToLower(cfg.PasswordHashAlgorithm) {
+	case "", PasswordAlgorithmBcrypt:
+		return NewBcryptHasher(cfg.BcryptCost), nil
+	case PasswordAlgorithmArgon2id:
+		return NewArgon2idHasher(Argon2idParams{
+			MemoryKiB:   uint32(cfg.Argon2MemoryKiB),
+			Iterations:  uint32(cfg.Argon2Iterations),
+			Parallelism: uint8(cfg.Argon2Parallelism),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", cfg.PasswordHashAlgorithm)
+	}
+}
+
+// dummyHashes holds one DummyPasswordHash per hasher.
+var dummyHashes sync.Map
+
+// DummyPasswordHash returns a hash made with hasher's settings that no
+// password is expected to match. Checking a password against it when an
+// account does not exist takes as long as checking a real one, so response
+// times do not reveal which emails are registered.
+func DummyPasswordHash(hasher PasswordHasher) string {
+	if hash, ok := dummyHashes.Load(hasher); ok {
+		return hash.(string)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return ""
+	}
+	hash, err := hasher.Hash(base64.RawStdEncoding.EncodeToString(secret))
+	if err != nil {
+		return ""
+	}
+	actual, _ := dummyHashes.LoadOrStore(hasher, hash)
+	return actual.(string)
+}
+
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	if len(password) > MaxPasswordBytes {
+		return "", ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Verify(hashedPassword, password string) bool {
+	return verifyAnyPasswordHash(hashedPassword, password)
+}
+
+func (h *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return true
+	}
+	return cost != h.cost
+}
+
+type Argon2idParams struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+type Argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) *Argon2idHasher {
+	if params.MemoryKiB == 0 {
+		params.MemoryKiB = 64 * 1024
+	}
+	if params.Iterations == 0 {
+		params.Iterations = 3
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = 2
+	}
+	return &Argon2idHasher{params: params}
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.MemoryKiB, h.params.Parallelism, argon2idKeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.MemoryKiB,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(hashedPassword, password string) bool {
+	return verifyAnyPasswordHash(hashedPassword, password)
+}
+
+func (h *Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	params, _, _, err := decodeArgon2idHash(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return params != h.params
+}
+
+func verifyAnyPasswordHash(hashedPassword, password string) bool {
+	switch {
+	case strings.HasPrefix(hashedPassword, argon2idPrefix):
+		return verifyArgon2id(hashedPassword, password)
+	case IsLegacyPasswordHash(hashedPassword):
+		return verifyLegacySHA256(hashedPassword, password)
+	default:
+		return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) == nil
+	}
+}
+
+func verifyArgon2id(hashedPassword, password string) bool {
+	params, salt, key, err := decodeArgon2idHash(hashedPassword)
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.MemoryKiB, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1
+}
+
+func decodeArgon2idHash(hashedPassword string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgorithmArgon2id {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version")
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	return params, salt, key, nil
+}
+
+func IsLegacyPasswordHash(hashedPassword string) bool {
+	if len(hashedPassword) != legacyHashLength {
+		return false
+	}
+	_, err := hex.DecodeString(hashedPassword)
+	return err == nil
+}
+
+func verifyLegacySHA256(hashedPassword, password string) bool {
+	hash := sha256.Sum256([]byte(password))
+	candidate := hex.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(candidate), []byte(strings.ToLower(hashedPassword))) == 1
+}
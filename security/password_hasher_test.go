@@ -0,0 +1,219 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func legacyHash(password string) string {
+	hash := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(hash[:])
+}
+
+func testArgon2idParams() Argon2idParams {
+	return Argon2idParams{MemoryKiB: 1024, Iterations: 1, Parallelism: 1}
+}
+
+func TestBcryptHasher_HashAndVerify(t *testing.T) {
+	// Given: A bcrypt hasher
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+
+	// When: Hashing a password
+	hash, err := hasher.Hash("password123")
+
+	// Then: The hash should verify only the original password
+	assert.NoError(t, err)
+	assert.NotEqual(t, "password123", hash)
+	assert.True(t, hasher.Verify(hash, "password123"))
+	assert.False(t, hasher.Verify(hash, "wrongpassword"))
+}
+
+func TestBcryptHasher_RejectsPasswordsBcryptWouldTruncate(t *testing.T) {
+	// Given: A bcrypt hasher and a password one byte over bcrypt's limit
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+	longest := strings.Repeat("a", MaxPasswordBytes)
+
+	// When: Hashing both
+	_, longestErr := hasher.Hash(longest)
+	_, err := hasher.Hash(longest + "b")
+
+	// Then: Only the password bcrypt reads in full is accepted
+	assert.NoError(t, longestErr)
+	assert.ErrorIs(t, err, ErrPasswordTooLong)
+}
+
+func TestBcryptHasher_HashIsSalted(t *testing.T) {
+	// Given: A bcrypt hasher
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+
+	// When: Hashing the same password twice
+	hash1, _ := hasher.Hash("samepassword")
+	hash2, _ := hasher.Hash("samepassword")
+
+	// Then: The hashes should differ
+	assert.NotEqual(t, hash1, hash2)
+}
+
+func TestBcryptHasher_InvalidCostFallsBackToDefault(t *testing.T) {
+	// Given: An out of range cost
+
+	// When: Creating a bcrypt hasher
+	hasher := NewBcryptHasher(100)
+
+	// Then: The default cost should be used
+	assert.Equal(t, bcrypt.DefaultCost, hasher.cost)
+}
+
+func TestBcryptHasher_NeedsRehash(t *testing.T) {
+	// Given: A bcrypt hasher and hashes of various kinds
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+	current, _ := hasher.Hash("password")
+	weaker, _ := NewBcryptHasher(bcrypt.MinCost + 1).Hash("password")
+	argon, _ := NewArgon2idHasher(testArgon2idParams()).Hash("password")
+
+	// When: Checking whether they need rehashing
+
+	// Then: Only the hash with the current cost should be kept
+	assert.False(t, hasher.NeedsRehash(current))
+	assert.True(t, hasher.NeedsRehash(weaker))
+	assert.True(t, hasher.NeedsRehash(argon))
+	assert.True(t, hasher.NeedsRehash(legacyHash("password")))
+}
+
+func TestArgon2idHasher_HashAndVerify(t *testing.T) {
+	// Given: An argon2id hasher
+	hasher := NewArgon2idHasher(testArgon2idParams())
+
+	// When: Hashing a password
+	hash, err := hasher.Hash("password123")
+
+	// Then: The hash should use the PHC format and verify only the original password
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.True(t, hasher.Verify(hash, "password123"))
+	assert.False(t, hasher.Verify(hash, "wrongpassword"))
+}
+
+func TestArgon2idHasher_DefaultParams(t *testing.T) {
+	// Given: Empty argon2id parameters
+
+	// When: Creating an argon2id hasher
+	hasher := NewArgon2idHasher(Argon2idParams{})
+
+	// Then: Sensible defaults should be applied
+	assert.Equal(t, uint32(64*1024), hasher.params.MemoryKiB)
+	assert.Equal(t, uint32(3), hasher.params.Iterations)
+	assert.Equal(t, uint8(2), hasher.params.Parallelism)
+}
+
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	// Given: An argon2id hasher and hashes of various kinds
+	hasher := NewArgon2idHasher(testArgon2idParams())
+	current, _ := hasher.Hash("password")
+	different, _ := NewArgon2idHasher(Argon2idParams{MemoryKiB: 2048, Iterations: 1, Parallelism: 1}).Hash("password")
+	bcryptHash, _ := NewBcryptHasher(bcrypt.MinCost).Hash("password")
+
+	// When: Checking whether they need rehashing
+
+	// Then: Only the hash with the current parameters should be kept
+	assert.False(t, hasher.NeedsRehash(current))
+	assert.True(t, hasher.NeedsRehash(different))
+	assert.True(t, hasher.NeedsRehash(bcryptHash))
+}
+
+func TestArgon2idHasher_VerifyMalformedHash(t *testing.T) {
+	// Given: An argon2id hasher
+	hasher := NewArgon2idHasher(testArgon2idParams())
+
+	// When: Verifying against a malformed argon2id hash
+
+	// Then: Verification should fail
+	assert.False(t, hasher.Verify("$argon2id$v=19$broken", "password"))
+}
+
+func TestPasswordHasher_VerifiesLegacySHA256(t *testing.T) {
+	// Given: A legacy SHA-256 hash
+	hash := legacyHash("password123")
+
+	// When: Verifying it with both adaptive hashers
+
+	// Then: The original password should verify and others should not
+	for _, hasher := range []PasswordHasher{NewBcryptHasher(bcrypt.MinCost), NewArgon2idHasher(testArgon2idParams())} {
+		assert.True(t, hasher.Verify(hash, "password123"))
+		assert.False(t, hasher.Verify(hash, "wrongpassword"))
+	}
+}
+
+func TestPasswordHasher_VerifiesAcrossAlgorithms(t *testing.T) {
+	// Given: A bcrypt hash and an argon2id hasher
+	bcryptHash, _ := NewBcryptHasher(bcrypt.MinCost).Hash("password123")
+	hasher := NewArgon2idHasher(testArgon2idParams())
+
+	// When: Verifying the bcrypt hash with the argon2id hasher
+	result := hasher.Verify(bcryptHash, "password123")
+
+	// Then: The hash should still verify so it can be upgraded on login
+	assert.True(t, result)
+}
+
+func TestDummyPasswordHash_MatchesHasherCost(t *testing.T) {
+	// Given: Two hashers with different settings
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	argon2Hasher := NewArgon2idHasher(testArgon2idParams())
+
+	// When: Asking each for its dummy hash twice
+	first := DummyPasswordHash(bcryptHasher)
+	second := DummyPasswordHash(bcryptHasher)
+	argon2Hash := DummyPasswordHash(argon2Hasher)
+
+	// Then: Each hasher gets one stable hash made with its own settings
+	assert.Equal(t, first, second)
+	assert.False(t, bcryptHasher.NeedsRehash(first))
+	assert.False(t, argon2Hasher.NeedsRehash(argon2Hash))
+	assert.False(t, bcryptHasher.Verify(first, ""))
+}
+
+func TestIsLegacyPasswordHash(t *testing.T) {
+	// Given: Various stored password values
+
+	// When: Checking whether they are legacy hashes
+
+	// Then: Only 64 character hex strings should be detected
+	assert.True(t, IsLegacyPasswordHash(legacyHash("password")))
+	assert.False(t, IsLegacyPasswordHash("$2a$10$abcdefghijklmnopqrstuv"))
+	assert.False(t, IsLegacyPasswordHash(strings.Repeat("z", 64)))
+}
+
+func TestNewPasswordHasher_FromConfig(t *testing.T) {
+	// Given: Configurations for each supported algorithm
+	bcryptCfg := &config.Config{PasswordHashAlgorithm: "bcrypt", BcryptCost: bcrypt.MinCost}
+	argonCfg := &config.Config{PasswordHashAlgorithm: "argon2id", Argon2MemoryKiB: 1024, Argon2Iterations: 1, Argon2Parallelism: 1}
+
+	// When: Creating hashers from the configurations
+	bcryptHasher, bcryptErr := NewPasswordHasher(bcryptCfg)
+	argonHasher, argonErr := NewPasswordHasher(argonCfg)
+
+	// Then: The matching hasher types should be returned
+	assert.NoError(t, bcryptErr)
+	assert.IsType(t, &BcryptHasher{}, bcryptHasher)
+	assert.NoError(t, argonErr)
+	assert.IsType(t, &Argon2idHasher{}, argonHasher)
+}
+
+func TestNewPasswordHasher_UnsupportedAlgorithm(t *testing.T) {
+	// Given: A configuration with an unknown algorithm
+	cfg := &config.Config{PasswordHashAlgorithm: "md5"}
+
+	// When: Creating a hasher
+	hasher, err := NewPasswordHasher(cfg)
+
+	// Then: An error should be returned
+	assert.Error(t, err)
+	assert.Nil(t, hasher)
+}
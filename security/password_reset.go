@@ -0,0 +1,72 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"gorm.io/gorm"
+)
+
+var PasswordResetTTL = time.Hour
+
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+type PasswordResetManager struct {
+	resetTokenRepo repositories.PasswordResetTokenRepository
+}
+
+func NewPasswordResetManager(resetTokenRepo repositories.PasswordResetTokenRepository) *PasswordResetManager {
+	return &PasswordResetManager{resetTokenRepo: resetTokenRepo}
+}
+
+// Issue creates a new reset token for the user and invalidates any token
+// sent earlier. Only the hash of the token is stored.
+func (m *PasswordResetManager) Issue(ctx context.Context, user *models.User) (string, error) {
+	if err := m.resetTokenRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return "", err
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	stored := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: HashToken(token),
+		ExpiresAt: time.Now().Add(PasswordResetTTL),
+	}
+	if err := m.resetTokenRepo.Create(ctx, stored); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// Redeem consumes the token and returns the ID of the user it was issued for.
+func (m *PasswordResetManager) Redeem(ctx context.Context, token string) (uint, error) {
+	stored, err := m.resetTokenRepo.FindByHash(ctx, HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrInvalidResetToken
+		}
+		return 0, err
+	}
+
+	if stored.IsUsed() || stored.IsExpired(time.Now()) {
+		return 0, ErrInvalidResetToken
+	}
+
+	consumed, err := m.resetTokenRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return 0, err
+	}
+	if !consumed {
+		return 0, ErrInvalidResetToken
+	}
+
+	return stored.UserID, nil
+}
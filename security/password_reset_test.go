@@ -0,0 +1,112 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestPasswordResetManager_Issue_StoresOnlyTokenHash(t *testing.T) {
+	// Given: A reset manager and a user
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	user := &models.User{Model: gorm.Model{ID: 4}}
+
+	var stored *models.PasswordResetToken
+	resetRepo.On("InvalidateAllForUser", mock.Anything, uint(4)).Return(nil)
+	resetRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.PasswordResetToken")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.PasswordResetToken)
+		}).
+		Return(nil)
+
+	// When: Issuing a reset token
+	token, err := manager.Issue(context.Background(), user)
+
+	// Then: Earlier tokens should be invalidated and only the hash stored
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, HashToken(token), stored.TokenHash)
+	assert.Equal(t, uint(4), stored.UserID)
+	assert.True(t, stored.ExpiresAt.After(time.Now()))
+	resetRepo.AssertExpectations(t)
+}
+
+func TestPasswordResetManager_Redeem_Success(t *testing.T) {
+	// Given: An unused, unexpired reset token
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	stored := &models.PasswordResetToken{Model: gorm.Model{ID: 2}, UserID: 9, ExpiresAt: time.Now().Add(time.Hour)}
+	resetRepo.On("FindByHash", mock.Anything, HashToken("valid")).Return(stored, nil)
+	resetRepo.On("MarkUsed", mock.Anything, uint(2)).Return(true, nil)
+
+	// When: Redeeming it
+	userID, err := manager.Redeem(context.Background(), "valid")
+
+	// Then: The owning user should be returned
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), userID)
+}
+
+func TestPasswordResetManager_Redeem_UsedToken(t *testing.T) {
+	// Given: A reset token that was already used
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	usedAt := time.Now()
+	stored := &models.PasswordResetToken{Model: gorm.Model{ID: 2}, UserID: 9, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	resetRepo.On("FindByHash", mock.Anything, HashToken("used")).Return(stored, nil)
+
+	// When: Redeeming it
+	_, err := manager.Redeem(context.Background(), "used")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestPasswordResetManager_Redeem_ExpiredToken(t *testing.T) {
+	// Given: A reset token past its expiry
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	stored := &models.PasswordResetToken{Model: gorm.Model{ID: 2}, UserID: 9, ExpiresAt: time.Now().Add(-time.Minute)}
+	resetRepo.On("FindByHash", mock.Anything, HashToken("expired")).Return(stored, nil)
+
+	// When: Redeeming it
+	_, err := manager.Redeem(context.Background(), "expired")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestPasswordResetManager_Redeem_LostRace(t *testing.T) {
+	// Given: A token consumed by a concurrent request between lookup and update
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	stored := &models.PasswordResetToken{Model: gorm.Model{ID: 2}, UserID: 9, ExpiresAt: time.Now().Add(time.Hour)}
+	resetRepo.On("FindByHash", mock.Anything, HashToken("racy")).Return(stored, nil)
+	resetRepo.On("MarkUsed", mock.Anything, uint(2)).Return(false, nil)
+
+	// When: Redeeming it
+	_, err := manager.Redeem(context.Background(), "racy")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestPasswordResetManager_Redeem_UnknownToken(t *testing.T) {
+	// Given: A token that was never issued
+	resetRepo := new(mocks.MockPasswordResetTokenRepository)
+	manager := NewPasswordResetManager(resetRepo)
+	resetRepo.On("FindByHash", mock.Anything, HashToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+
+	// When: Redeeming it
+	_, err := manager.Redeem(context.Background(), "unknown")
+
+	// Then: It should be rejected
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
@@ -0,0 +1,188 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"gorm.io/gorm"
+)
+
+var RefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+type Session struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+type SessionManager struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+}
+
+func NewSessionManager(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, revokedTokenRepo repositories.RevokedTokenRepository) *SessionManager {
+	return &SessionManager{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+	}
+}
+
+func (m *SessionManager) Issue(ctx context.Context, user *models.User) (*Session, error) {
+	familyID, err := NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+	return m.issueInFamily(ctx, user, familyID)
+}
+
+func (m *SessionManager) Refresh(ctx context.Context, refreshToken string) (*Session, *models.User, error) {
+	stored, err := m.refreshTokenRepo.FindByHash(ctx, HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	if stored.IsRevoked() {
+		return nil, nil, m.reused(ctx, stored.FamilyID)
+	}
+
+	if stored.IsExpired(time.Now()) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	user, err := m.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	// Two requests may both get this far with the same token; only the one
+	// whose update revokes it may rotate, the other counts as reuse.
+	if err := m.refreshTokenRepo.Revoke(ctx, stored.ID); err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenAlreadyRevoked) {
+			return nil, nil, m.reused(ctx, stored.FamilyID)
+		}
+		return nil, nil, err
+	}
+
+	session, err := m.issueInFamily(ctx, user, stored.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, user, nil
+}
+
+// reused revokes a token family after one of its tokens was presented again.
+func (m *SessionManager) reused(ctx context.Context, familyID string) error {
+	if err := m.refreshTokenRepo.RevokeFamily(ctx, familyID); err != nil {
+		log.Printf("Failed to revoke refresh token family %s: %v", familyID, err)
+	}
+	return ErrRefreshTokenReused
+}
+
+func (m *SessionManager) RevokeAccessToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+	return m.revokedTokenRepo.Revoke(ctx, jti, userID, expiresAt)
+}
+
+// IsTokenRevoked reports whether the token ID is on the revoked list, either
+// because the token was logged out or because it was single-use and spent.
+func (m *SessionManager) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return m.revokedTokenRepo.IsRevoked(ctx, jti)
+}
+
+func (m *SessionManager) RevokeRefreshToken(ctx context.Context, refreshToken string, userID uint) error {
+	stored, err := m.refreshTokenRepo.FindByHash(ctx, HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	if stored.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	return m.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (m *SessionManager) RevokeAll(ctx context.Context, user *models.User) error {
+	if err := m.refreshTokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.SessionsRevokedAt = &now
+	return m.userRepo.Update(ctx, user)
+}
+
+func (m *SessionManager) issueInFamily(ctx context.Context, user *models.User, familyID string) (*Session, error) {
+	accessToken, err := generateTokenAt(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role), issueTimeAfterRevocation(user, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: HashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(RefreshTokenTTL),
+	}
+	if err := m.refreshTokenRepo.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// issueTimeAfterRevocation dates a new access token. Tokens carry whole
+// seconds and anything issued in the second of a revocation is refused, so
+// a session started in that second is dated just after it.
+func issueTimeAfterRevocation(user *models.User, now time.Time) time.Time {
+	if user.SessionsRevokedAt == nil || now.Unix() > user.SessionsRevokedAt.Unix() {
+		return now
+	}
+	return time.Unix(user.SessionsRevokedAt.Unix()+1, 0)
+}
+
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func newOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
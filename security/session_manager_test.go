@@ -0,0 +1,168 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PI-Team04-GameClub/gameclub-backend/mocks"
+	"github.com/PI-Team04-GameClub/gameclub-backend/models"
+	"github.com/PI-Team04-GameClub/gameclub-backend/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestSessionManager() (*SessionManager, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockRevokedTokenRepository) {
+	userRepo := new(mocks.MockUserRepository)
+	refreshRepo := new(mocks.MockRefreshTokenRepository)
+	revokedRepo := new(mocks.MockRevokedTokenRepository)
+	return NewSessionManager(userRepo, refreshRepo, revokedRepo), userRepo, refreshRepo, revokedRepo
+}
+
+func TestSessionManager_Issue_StoresOnlyTokenHash(t *testing.T) {
+	// Given: A session manager and a user
+	manager, _, refreshRepo, _ := newTestSessionManager()
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "issue@example.com"}
+
+	var stored *models.RefreshToken
+	refreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.RefreshToken)
+		}).
+		Return(nil)
+
+	// When: Issuing a session
+	session, err := manager.Issue(context.Background(), user)
+
+	// Then: The refresh token should be stored only as a hash
+	assert.NoError(t, err)
+	assert.NotEmpty(t, session.AccessToken)
+	assert.NotEqual(t, session.RefreshToken, stored.TokenHash)
+	assert.Equal(t, HashToken(session.RefreshToken), stored.TokenHash)
+	assert.Equal(t, uint(1), stored.UserID)
+	assert.NotEmpty(t, stored.FamilyID)
+}
+
+func TestSessionManager_Refresh_RevokedTokenRevokesFamily(t *testing.T) {
+	// Given: A refresh token that has already been used
+	manager, _, refreshRepo, _ := newTestSessionManager()
+	revokedAt := time.Now()
+	stored := &models.RefreshToken{
+		Model:     gorm.Model{ID: 3},
+		UserID:    1,
+		TokenHash: HashToken("used"),
+		FamilyID:  "family-1",
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+	refreshRepo.On("FindByHash", mock.Anything, HashToken("used")).Return(stored, nil)
+	refreshRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil)
+
+	// When: Refreshing with it
+	session, user, err := manager.Refresh(context.Background(), "used")
+
+	// Then: Reuse should be reported and the whole family revoked
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, session)
+	assert.Nil(t, user)
+	refreshRepo.AssertExpectations(t)
+}
+
+func TestSessionManager_Refresh_ConcurrentUseRevokesFamily(t *testing.T) {
+	// Given: A valid refresh token that another request rotates first
+	manager, userRepo, refreshRepo, _ := newTestSessionManager()
+	stored := &models.RefreshToken{
+		Model:     gorm.Model{ID: 3},
+		UserID:    1,
+		TokenHash: HashToken("raced"),
+		FamilyID:  "family-1",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	refreshRepo.On("FindByHash", mock.Anything, HashToken("raced")).Return(stored, nil)
+	userRepo.On("FindByID", mock.Anything, uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	refreshRepo.On("Revoke", mock.Anything, uint(3)).Return(repositories.ErrRefreshTokenAlreadyRevoked)
+	refreshRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil)
+
+	// When: Refreshing with it
+	session, _, err := manager.Refresh(context.Background(), "raced")
+
+	// Then: No new token is issued and the whole family is revoked
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, session)
+	refreshRepo.AssertExpectations(t)
+	refreshRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSessionManager_Refresh_UnknownToken(t *testing.T) {
+	// Given: A refresh token that does not exist
+	manager, _, refreshRepo, _ := newTestSessionManager()
+	refreshRepo.On("FindByHash", mock.Anything, HashToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+
+	// When: Refreshing with it
+	_, _, err := manager.Refresh(context.Background(), "unknown")
+
+	// Then: It should be reported as invalid
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestSessionManager_RevokeRefreshToken_OtherUser(t *testing.T) {
+	// Given: A refresh token belonging to another user
+	manager, _, refreshRepo, _ := newTestSessionManager()
+	stored := &models.RefreshToken{UserID: 2, FamilyID: "family-2", ExpiresAt: time.Now().Add(time.Hour)}
+	refreshRepo.On("FindByHash", mock.Anything, HashToken("theirs")).Return(stored, nil)
+
+	// When: Revoking it as user 1
+	err := manager.RevokeRefreshToken(context.Background(), "theirs", 1)
+
+	// Then: It should be rejected without revoking anything
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	refreshRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+func TestSessionManager_RevokeAll_MarksUser(t *testing.T) {
+	// Given: A user with active sessions
+	manager, userRepo, refreshRepo, _ := newTestSessionManager()
+	user := &models.User{Model: gorm.Model{ID: 5}}
+	refreshRepo.On("RevokeAllForUser", mock.Anything, uint(5)).Return(nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+
+	// When: Revoking all sessions
+	err := manager.RevokeAll(context.Background(), user)
+
+	// Then: Refresh tokens should be revoked and the revocation time recorded
+	assert.NoError(t, err)
+	assert.NotNil(t, user.SessionsRevokedAt)
+	refreshRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestSessionManager_Issue_DatesTokenAfterRevocation(t *testing.T) {
+	// Given: A user who logged out everywhere later in this second
+	manager, _, refreshRepo, _ := newTestSessionManager()
+	revokedAt := time.Unix(time.Now().Unix(), 999_000_000)
+	user := &models.User{Model: gorm.Model{ID: 6}, SessionsRevokedAt: &revokedAt}
+	refreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	// When: Starting a new session
+	session, err := manager.Issue(context.Background(), user)
+
+	// Then: The access token is dated after the revocation's second
+	assert.NoError(t, err)
+	claims, err := ExtractClaims(session.AccessToken)
+	assert.NoError(t, err)
+	issuedAt, _ := claims.GetIssuedAt()
+	assert.Greater(t, issuedAt.Unix(), revokedAt.Unix())
+}
+
+func TestSessionManager_RevokeAccessToken_EmptyJTI(t *testing.T) {
+	// Given: A token without a jti
+	manager, _, _, revokedRepo := newTestSessionManager()
+
+	// When: Revoking it
+	err := manager.RevokeAccessToken(context.Background(), "", 1, time.Now())
+
+	// Then: Nothing should be stored
+	assert.NoError(t, err)
+	revokedRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
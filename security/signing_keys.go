@@ -0,0 +1,233 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	minHMACSecretLength = 32
+)
+
+var (
+	ErrUnknownSigningKey  = errors.New("unknown signing key")
+	ErrKeyCannotSign      = errors.New("signing key has no private part")
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewHMACKey(secret []byte) (*SigningKey, error) {
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("HMAC secret must be at least %d bytes", minHMACSecretLength)
+	}
+	digest := sha256.Sum256(append([]byte("hmac:"), secret...))
+	return &SigningKey{
+		ID:        base64.RawURLEncoding.EncodeToString(digest[:12]),
+		Algorithm: AlgorithmHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}, nil
+}
+
+func NewPrivateSigningKey(private crypto.PrivateKey) (*SigningKey, error) {
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		signingKey, err := NewPublicSigningKey(&key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		signingKey.signKey = key
+		return signingKey, nil
+	case ed25519.PrivateKey:
+		signingKey, err := NewPublicSigningKey(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		signingKey.signKey = key
+		return signingKey, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+func NewPublicSigningKey(public crypto.PublicKey) (*SigningKey, error) {
+	key := &SigningKey{verifyKey: public}
+	switch public.(type) {
+	case *rsa.PublicKey:
+		key.Algorithm = AlgorithmRS256
+	case ed25519.PublicKey:
+		key.Algorithm = AlgorithmEdDSA
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+	key.ID = key.thumbprint()
+	return key, nil
+}
+
+func ParsePrivateKeyPEM(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrivateSigningKey(key)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrivateSigningKey(key)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func ParsePublicKeyPEM(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewPublicSigningKey(key)
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewPublicSigningKey(key)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+func (k *SigningKey) IsSymmetric() bool {
+	return k.Algorithm == AlgorithmHS256
+}
+
+func (k *SigningKey) Method() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func (k *SigningKey) JWK() (JWK, bool) {
+	jwk := JWK{KeyID: k.ID, Algorithm: k.Algorithm, Use: "sig"}
+
+	switch key := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		return jwk, true
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		return jwk, true
+	default:
+		return JWK{}, false
+	}
+}
+
+// thumbprint derives the key ID from the public key as described in RFC 7638.
+func (k *SigningKey) thumbprint() string {
+	jwk, ok := k.JWK()
+	if !ok {
+		return ""
+	}
+
+	var canonical string
+	switch jwk.KeyType {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case "OKP":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, jwk.Curve, jwk.X)
+	}
+
+	digest := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// PublicKey converts a published RSA or Ed25519 JWK back into a key that
+// can verify signatures, e.g. for ID tokens from an external provider.
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return nil, ErrUnsupportedKeyType
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
@@ -0,0 +1,124 @@
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Base32 of the ASCII seed "12345678901234567890" used by RFC 6238.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func fakeClock(at time.Time) func() time.Time {
+	return func() time.Time { return at }
+}
+
+func TestTOTP_RFC6238TestVectors(t *testing.T) {
+	// Given: The SHA-1 test vectors from RFC 6238 Appendix B
+	totp := NewTOTP(time.Now)
+	totp.Digits = 8
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unix, expected := range vectors {
+		// When: Computing the code for each time
+		code, err := totp.CodeAt(rfc6238Secret, totp.Step(time.Unix(unix, 0)))
+
+		// Then: It should match the published value
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestTOTP_Verify_AcceptsCurrentAndAdjacentSteps(t *testing.T) {
+	// Given: A fake clock and codes for the previous, current and next step
+	now := time.Unix(1111111111, 0)
+	totp := NewTOTP(fakeClock(now))
+	step := totp.Step(now)
+
+	for _, offset := range []int64{-1, 0, 1} {
+		code, _ := totp.CodeAt(rfc6238Secret, step+offset)
+
+		// When: Verifying the code
+		matched, ok := totp.Verify(rfc6238Secret, code, 0)
+
+		// Then: It should be accepted and report its step
+		assert.True(t, ok)
+		assert.Equal(t, step+offset, matched)
+	}
+}
+
+func TestTOTP_Verify_RejectsCodesOutsideSkew(t *testing.T) {
+	// Given: A code from two steps ago
+	now := time.Unix(1111111111, 0)
+	totp := NewTOTP(fakeClock(now))
+	code, _ := totp.CodeAt(rfc6238Secret, totp.Step(now)-2)
+
+	// When: Verifying it
+	_, ok := totp.Verify(rfc6238Secret, code, 0)
+
+	// Then: It should be rejected
+	assert.False(t, ok)
+}
+
+func TestTOTP_Verify_RejectsReplayedStep(t *testing.T) {
+	// Given: A code whose step was already used
+	now := time.Unix(1111111111, 0)
+	totp := NewTOTP(fakeClock(now))
+	step := totp.Step(now)
+	code, _ := totp.CodeAt(rfc6238Secret, step)
+
+	// When: Verifying it with the last used step
+	_, ok := totp.Verify(rfc6238Secret, code, step)
+
+	// Then: It should be rejected
+	assert.False(t, ok)
+}
+
+func TestTOTP_Verify_RejectsMalformedCode(t *testing.T) {
+	// Given: A TOTP verifier
+	totp := NewTOTP(fakeClock(time.Unix(59, 0)))
+
+	// When: Verifying codes of the wrong length
+	_, short := totp.Verify(rfc6238Secret, "123", 0)
+	_, long := totp.Verify(rfc6238Secret, "1234567", 0)
+
+	// Then: They should be rejected
+	assert.False(t, short)
+	assert.False(t, long)
+}
+
+func TestTOTP_URI(t *testing.T) {
+	// Given: A secret and an account name
+	totp := NewTOTP(time.Now)
+
+	// When: Building the otpauth URI
+	uri := totp.URI("jane@example.com", rfc6238Secret)
+
+	// Then: It should follow the Key URI format understood by authenticator apps
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/GameClub:jane@example.com?"))
+	assert.Contains(t, uri, "secret="+rfc6238Secret)
+	assert.Contains(t, uri, "issuer=GameClub")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	// Given: Two freshly generated secrets
+	first, err := GenerateTOTPSecret()
+	second, _ := GenerateTOTPSecret()
+
+	// Then: They should be distinct, valid base32 secrets
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	_, err = NewTOTP(time.Now).CodeAt(first, 1)
+	assert.NoError(t, err)
+}
@@ -46,6 +46,10 @@ func RequiresAuthentication() Error {
 	return newBodyError("requested content requires authentication")
 }
 
+func EmailNotVerified() Error {
+	return newBodyError("email address must be verified")
+}
+
 func BadRequest(message string) Error {
 	return newBodyError(message)
 }
@@ -61,3 +65,11 @@ func Conflict(message string) Error {
 func Unauthorized(message string) Error {
 	return newBodyError(message)
 }
+
+func TooManyRequests(message string) Error {
+	return newBodyError(message)
+}
+
+func BadGateway(message string) Error {
+	return newBodyError(message)
+}
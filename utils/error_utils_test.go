@@ -78,6 +78,17 @@ func TestRequiresAuthentication(t *testing.T) {
 	assert.Equal(t, "requested content requires authentication", result.Errors["body"])
 }
 
+func TestEmailNotVerified(t *testing.T) {
+	// Given: A request from a user whose email is not verified
+
+	// When: Creating an email not verified error
+	result := EmailNotVerified()
+
+	// Then: The error should ask for a verified email address
+	assert.NotNil(t, result.Errors)
+	assert.Equal(t, "email address must be verified", result.Errors["body"])
+}
+
 func TestBadRequest(t *testing.T) {
 	// Given: A bad request message
 	message := "invalid input"
@@ -135,3 +146,27 @@ func TestErrorStruct_HasCorrectJSONTag(t *testing.T) {
 	// Then: The body field should contain the correct value
 	assert.Equal(t, "test", e.Errors["body"])
 }
+
+func TestTooManyRequests(t *testing.T) {
+	// Given: A rate limit message
+	message := "slow down"
+
+	// When: Creating a too many requests error
+	result := TooManyRequests(message)
+
+	// Then: The error should contain the message
+	assert.NotNil(t, result.Errors)
+	assert.Equal(t, "slow down", result.Errors["body"])
+}
+
+func TestBadGateway(t *testing.T) {
+	// Given: An upstream failure message
+	message := "provider down"
+
+	// When: Creating a bad gateway error
+	result := BadGateway(message)
+
+	// Then: The error should contain the message
+	assert.NotNil(t, result.Errors)
+	assert.Equal(t, "provider down", result.Errors["body"])
+}